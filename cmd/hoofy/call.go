@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	sddserver "github.com/HendryAvila/Hoofy/internal/server"
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// errToolFailed is returned when the tool itself reported an error;
+// its message has already been printed.
+var errToolFailed = fmt.Errorf("tool call failed: %w", errReported)
+
+// stringList is a repeatable string flag (--arg a=1 --arg b=2).
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ", ") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
+
+// runCall builds the same MCP server as "hoofy serve" and invokes one
+// tool in-process, printing its text result (or the raw result with
+// --json). Useful from Makefiles and git hooks.
+func runCall(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("call", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to user config (default ~/.hoofy/config.json)")
+	rawArgs := fs.String("args", "", "tool arguments as a JSON object")
+	var pairs stringList
+	fs.Var(&pairs, "arg", "tool argument as key=value (repeatable; typed by the tool's schema)")
+	asJSON := fs.Bool("json", false, "print the full CallToolResult as JSON")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		printCallUsage()
+		return errors.New("expected exactly one tool name")
+	}
+	name := positional[0]
+
+	s, cleanup, err := newCLIServer(*configPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	tool := s.GetTool(name)
+	if tool == nil {
+		return fmt.Errorf("unknown tool %q (see `hoofy tools list`)", name)
+	}
+
+	arguments := map[string]any{}
+	if *rawArgs != "" {
+		if err := json.Unmarshal([]byte(*rawArgs), &arguments); err != nil {
+			return fmt.Errorf("--args must be a JSON object: %w", err)
+		}
+	}
+	for _, pair := range pairs {
+		key, value, err := sddserver.ParseToolArg(tool.Tool, pair)
+		if err != nil {
+			return err
+		}
+		arguments[key] = value
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	result, err := sddserver.CallTool(ctx, s, name, arguments)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		_, _ = fmt.Fprintln(stdout, sddserver.ResultText(result))
+	}
+
+	if result.IsError {
+		return errToolFailed
+	}
+	return nil
+}
+
+// runTools dispatches "hoofy tools <subcommand>".
+func runTools(args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] != "list" {
+		printCallUsage()
+		if len(args) == 0 || args[0] == "help" || args[0] == "--help" || args[0] == "-h" {
+			return nil
+		}
+		return fmt.Errorf("unknown tools subcommand: %s", args[0])
+	}
+
+	fs := flag.NewFlagSet("tools list", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to user config (default ~/.hoofy/config.json)")
+	asJSON := fs.Bool("json", false, "print tool definitions as JSON")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	s, cleanup, err := newCLIServer(*configPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	defs := sddserver.ToolDefinitions(s)
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(defs)
+	}
+
+	for _, t := range defs {
+		printToolDefinition(stdout, t)
+	}
+	_, _ = fmt.Fprintf(stdout, "%d tools\n", len(defs))
+	return nil
+}
+
+// newCLIServer builds the MCP server from the user config, exactly as
+// "hoofy serve" would.
+func newCLIServer(configPath string) (*server.MCPServer, func(), error) {
+	cfg, err := userconfig.Load(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, cleanup, err := sddserver.New(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating server: %w", err)
+	}
+	return s, cleanup, nil
+}
+
+func printToolDefinition(w io.Writer, t mcp.Tool) {
+	_, _ = fmt.Fprintf(w, "%s\n", t.Name)
+	if desc, _, _ := strings.Cut(t.Description, "\n"); desc != "" {
+		_, _ = fmt.Fprintf(w, "  %s\n", desc)
+	}
+
+	required := make(map[string]bool, len(t.InputSchema.Required))
+	for _, r := range t.InputSchema.Required {
+		required[r] = true
+	}
+	names := make([]string, 0, len(t.InputSchema.Properties))
+	for name := range t.InputSchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, _ := t.InputSchema.Properties[name].(map[string]any)
+		typ, _ := prop["type"].(string)
+		if enum, ok := prop["enum"].([]string); ok {
+			typ = strings.Join(enum, "|")
+		}
+		marker := ""
+		if required[name] {
+			marker = " (required)"
+		}
+		_, _ = fmt.Fprintf(w, "    --arg %s=<%s>%s\n", name, typ, marker)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func printCallUsage() {
+	fmt.Fprint(os.Stderr, `Usage:
+  hoofy call <tool> [--args '{"key": "value"}'] [--arg key=value ...] [--json]
+  hoofy tools list [--json]
+
+Calls run in-process against the same tools "hoofy serve" exposes,
+using your ~/.hoofy/config.json. Exit code is 1 if the tool reports an error.
+`)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/HendryAvila/Hoofy/internal/changelog"
+	"github.com/HendryAvila/Hoofy/internal/changes"
+)
+
+// runChangelog prints a Keep a Changelog section for the changes
+// completed in a range, or merges them into CHANGELOG.md with --write.
+func runChangelog(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("changelog", flag.ContinueOnError)
+	project := fs.String("project", "", "project directory (default: nearest parent with docs/hoofy.json)")
+	from := fs.String("from", "", "start of the range: YYYY-MM-DD (inclusive) or git tag (exclusive)")
+	to := fs.String("to", "", "end of the range: YYYY-MM-DD or git tag (inclusive)")
+	version := fs.String("version", "", "release version for the heading (default [Unreleased])")
+	write := fs.Bool("write", false, "merge the entries into the [Unreleased] section of CHANGELOG.md")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *write && *version != "" {
+		return fmt.Errorf("--version and --write can't be combined: --write always targets [Unreleased]")
+	}
+
+	root, err := resolveProjectRoot(*project)
+	if err != nil {
+		return err
+	}
+	reg, err := changes.LoadRegistry(root)
+	if err != nil {
+		return err
+	}
+
+	rel, err := changelog.Build(root, changes.NewFileStore(), reg, changelog.Options{From: *from, To: *to, Version: *version})
+	if err != nil {
+		return err
+	}
+
+	if !*write {
+		_, err := io.WriteString(stdout, rel.Markdown())
+		return err
+	}
+	added, err := changelog.UpdateFile(changelog.Path(root), rel)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%s: added %d of %d completed change(s) to [Unreleased]\n", changelog.File, added, len(rel.Entries))
+	return err
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/HendryAvila/Hoofy/internal/check"
+)
+
+// runCheck runs the deterministic spec gates for CI. It exits non-zero
+// (via errChecksFailed) when any gate fails.
+func runCheck(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	project := fs.String("project", "", "project directory (default: nearest parent with docs/hoofy.json)")
+	format := fs.String("format", "text", "output format: text, json, or junit")
+	output := fs.String("output", "", "write the report to this file instead of stdout")
+	stale := fs.Duration("stale", check.DefaultStaleAfter, "fail active changes not updated within this duration (e.g. 24h)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := check.ParseFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	root, err := resolveProjectRoot(*project)
+	if err != nil {
+		return err
+	}
+
+	report, err := check.Run(root, check.Options{StaleAfter: *stale})
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", *output, err)
+		}
+		defer func() { _ = file.Close() }()
+		w = file
+	}
+	if err := report.Write(w, f); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	if report.Failed() {
+		return errChecksFailed
+	}
+	return nil
+}
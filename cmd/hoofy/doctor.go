@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/doctor"
+)
+
+// errChecksFailed makes the process exit non-zero; the report already
+// explains what failed.
+var errChecksFailed = fmt.Errorf("one or more checks failed: %w", errReported)
+
+// runDoctor diagnoses the memory database, user config, and the Hoofy
+// project in the current directory, printing a fix for each problem.
+func runDoctor(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to user config (default ~/.hoofy/config.json)")
+	project := fs.String("project", "", "project directory to check (default: nearest parent with docs/hoofy.json)")
+	fix := fs.Bool("fix", false, "apply safe repairs (rebuild out-of-sync search indexes)")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	root, err := resolveProjectRoot(*project)
+	if err != nil {
+		return err
+	}
+
+	report := doctor.Run(*configPath, doctor.Options{ProjectRoot: root, Fix: *fix})
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(stdout, report)
+	}
+
+	if report.Failed() {
+		return errChecksFailed
+	}
+	return nil
+}
+
+// resolveProjectRoot resolves --project, or searches upward from cwd
+// for docs/hoofy.json, falling back to cwd itself (changes can exist
+// without hoofy.json).
+func resolveProjectRoot(flagValue string) (string, error) {
+	if flagValue != "" {
+		return filepath.Abs(flagValue)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("getting working directory: %w", err)
+	}
+	root, _ := config.FindProjectRoot(cwd)
+	return root, nil
+}
+
+var doctorSections = []struct{ key, title string }{
+	{"config", "User config"},
+	{"memory", "Memory"},
+	{"project", "Project"},
+	{"changes", "Changes"},
+	{"templates", "Templates"},
+}
+
+func printDoctorReport(w io.Writer, r *doctor.Report) {
+	for _, section := range doctorSections {
+		var printed bool
+		for _, f := range r.Findings {
+			if f.Section != section.key {
+				continue
+			}
+			if !printed {
+				_, _ = fmt.Fprintf(w, "\n%s\n", section.title)
+				printed = true
+			}
+			_, _ = fmt.Fprintf(w, "  %s %s: %s\n", severityIcon(f.Severity), f.Check, f.Message)
+			if f.Fix != "" {
+				_, _ = fmt.Fprintf(w, "     → %s\n", f.Fix)
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%d ok, %d warning(s), %d failure(s)\n",
+		r.Count(doctor.SeverityOK), r.Count(doctor.SeverityWarn), r.Count(doctor.SeverityFail))
+}
+
+func severityIcon(s doctor.Severity) string {
+	switch s {
+	case doctor.SeverityOK:
+		return "✅"
+	case doctor.SeverityWarn:
+		return "⚠️ "
+	case doctor.SeverityFail:
+		return "❌"
+	default:
+		return "➖"
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/HendryAvila/Hoofy/internal/install"
+)
+
+// runInstall merges the "hoofy serve" entry into an AI client's MCP
+// config (or removes it with --uninstall). Without a client it lists
+// the supported clients and whether each one was found.
+func runInstall(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("install", flag.ContinueOnError)
+	scopeFlag := fs.String("scope", "user", "config to edit: user (home directory) or project")
+	project := fs.String("project", "", "project directory for --scope project (default: nearest parent with docs/hoofy.json, else cwd)")
+	uninstall := fs.Bool("uninstall", false, "remove the hoofy entry instead of adding it")
+	printOnly := fs.Bool("print", false, "print the JSON to merge by hand; change nothing")
+	command := fs.String("command", "hoofy", "command the client runs (use an absolute path if hoofy is not on the client's PATH)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	scope, err := install.ParseScope(*scopeFlag)
+	if err != nil {
+		return err
+	}
+	root := ""
+	if scope == install.ScopeProject {
+		if root, err = resolveProjectRoot(*project); err != nil {
+			return err
+		}
+	}
+	env, err := install.DefaultEnv(root)
+	if err != nil {
+		return err
+	}
+
+	switch len(positional) {
+	case 0:
+		printInstallClients(stdout, env, scope)
+		return nil
+	case 1:
+	default:
+		printInstallUsage()
+		return errors.New("expected exactly one client")
+	}
+	if *printOnly && *uninstall {
+		return errors.New("--print and --uninstall cannot be combined")
+	}
+
+	c, err := install.Lookup(positional[0])
+	if err != nil {
+		return err
+	}
+	server := install.DefaultServer()
+	server.Command = *command
+
+	if *printOnly {
+		snippet, err := c.Snippet(server)
+		if err != nil {
+			return err
+		}
+		if path, err := c.Path(env, scope); err == nil {
+			fmt.Fprintf(os.Stderr, "Merge into %s:\n\n", path)
+		}
+		_, err = stdout.Write(snippet)
+		return err
+	}
+
+	var res install.Result
+	if *uninstall {
+		res, err = install.Uninstall(c, env, scope, server.Name)
+	} else {
+		res, err = install.Install(c, env, scope, server)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch res.Action {
+	case install.ActionAdded:
+		_, _ = fmt.Fprintf(stdout, "✅ Added %s to %s (%s scope): %s\n", server.Name, c.Title, scope, res.Path)
+	case install.ActionUpdated:
+		_, _ = fmt.Fprintf(stdout, "✅ Updated %s in %s (%s scope): %s\n", server.Name, c.Title, scope, res.Path)
+	case install.ActionUnchanged:
+		_, _ = fmt.Fprintf(stdout, "✅ %s is already configured in %s (%s scope): %s\n", server.Name, c.Title, scope, res.Path)
+		return nil
+	case install.ActionRemoved:
+		_, _ = fmt.Fprintf(stdout, "✅ Removed %s from %s (%s scope): %s\n", server.Name, c.Title, scope, res.Path)
+	case install.ActionNotFound:
+		_, _ = fmt.Fprintf(stdout, "⚠️  %s is not configured in %s (%s scope): %s\n", server.Name, c.Title, scope, res.Path)
+		return nil
+	}
+	_, _ = fmt.Fprintf(stdout, "   Restart %s to pick up the change.\n", c.Title)
+	return nil
+}
+
+func printInstallClients(w io.Writer, env install.Env, scope install.Scope) {
+	_, _ = fmt.Fprintln(w, "Supported clients (hoofy install <client>):")
+	for _, c := range install.Clients {
+		icon := "➖"
+		if c.Detected(env) {
+			icon = "✅"
+		}
+		path, err := c.Path(env, scope)
+		if err != nil {
+			path = err.Error()
+		}
+		_, _ = fmt.Fprintf(w, "  %s %-9s %-12s %s\n", icon, c.Name, c.Title, path)
+	}
+}
+
+func printInstallUsage() {
+	fmt.Fprint(os.Stderr, `Usage:
+  hoofy install                        List clients and their config paths
+  hoofy install <client> [flags]       Add hoofy to the client's MCP config
+
+Clients: claude, cursor, vscode, opencode, gemini
+
+Flags:
+  --scope user|project   Home-directory config (default) or the project's
+  --project DIR          Project directory for --scope project
+  --uninstall            Remove the hoofy entry
+  --print                Print the JSON snippet instead of editing files
+  --command PATH         Command to run (default "hoofy")
+`)
+}
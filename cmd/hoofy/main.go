@@ -6,20 +6,35 @@
 //
 // Usage:
 //
-//	hoofy serve    # Start MCP server (stdio transport)
-//	hoofy update   # Update to the latest version
+//	hoofy serve          # Start MCP server (stdio transport)
+//	hoofy serve --transport http --addr 127.0.0.1:7777
+//	hoofy update         # Update to the latest version
+//	hoofy memory export  # Dump persistent memory as JSON
+//	hoofy memory import  # Load a memory dump
+//	hoofy mem search X   # Browse memory (also list, get, rm)
+//	hoofy doctor         # Diagnose memory, config, and project files
+//	hoofy check          # Deterministic spec gates for CI
+//	hoofy changelog --from v1.2.0 --write
+//	hoofy metrics --from 2026-01-01 --format json
+//	hoofy templates eject requirements
+//	hoofy call sdd_change_status --arg detail_level=summary
+//	hoofy tools list     # Tool definitions and input schemas
+//	hoofy install claude # Register hoofy in an AI client's MCP config
 package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	sddserver "github.com/HendryAvila/Hoofy/internal/server"
 	"github.com/HendryAvila/Hoofy/internal/updater"
-	"github.com/mark3labs/mcp-go/server"
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
 )
 
 func main() {
@@ -30,12 +45,27 @@ func main() {
 
 	switch os.Args[1] {
 	case "serve":
-		if err := run(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+		exitOnError(run(os.Args[2:]))
 	case "update":
 		runUpdate()
+	case "memory", "mem":
+		exitOnError(runMemory(os.Args[2:]))
+	case "doctor":
+		exitOnError(runDoctor(os.Args[2:], os.Stdout))
+	case "check":
+		exitOnError(runCheck(os.Args[2:], os.Stdout))
+	case "changelog":
+		exitOnError(runChangelog(os.Args[2:], os.Stdout))
+	case "metrics":
+		exitOnError(runMetrics(os.Args[2:], os.Stdout))
+	case "templates":
+		exitOnError(runTemplates(os.Args[2:], os.Stdout))
+	case "call":
+		exitOnError(runCall(os.Args[2:], os.Stdout))
+	case "tools":
+		exitOnError(runTools(os.Args[2:], os.Stdout))
+	case "install":
+		exitOnError(runInstall(os.Args[2:], os.Stdout))
 	case "--help", "-h", "help":
 		printUsage()
 		os.Exit(0)
@@ -49,32 +79,82 @@ func main() {
 	}
 }
 
-func run() error {
-	s, cleanup, err := sddserver.New()
+// errReported marks failures the command already explained in its own
+// output (a failing check report, a tool error result); main exits 1
+// without printing them again.
+var errReported = errors.New("failure already reported")
+
+// exitOnError prints err to stderr and exits 1. A nil err is a no-op.
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+	if !errors.Is(err, errReported) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	os.Exit(1)
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to user config (default $HOOFY_CONFIG or ~/.hoofy/config.json)")
+	transport := fs.String("transport", string(sddserver.TransportStdio), "transport: stdio, http, or sse")
+	addr := fs.String("addr", sddserver.DefaultAddr, "listen address for http/sse transports")
+	path := fs.String("path", sddserver.DefaultHTTPPath, "endpoint path for the http transport")
+	token := fs.String("token", "", "bearer token required by http/sse clients (default $HOOFY_TOKEN)")
+	shutdownTimeout := fs.Duration("shutdown-timeout", sddserver.DefaultShutdownTimeout, "how long to wait for in-flight tool calls on shutdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Validation errors surface here, before any transport starts.
+	cfg, err := userconfig.Load(*configPath)
+	if err != nil {
+		return err
+	}
+
+	// Flags win over the config file (and HOOFY_* env, already applied
+	// by Load); unset flags fall back to the config's server section.
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if !set["transport"] && cfg.Server.Transport != "" {
+		*transport = cfg.Server.Transport
+	}
+	if !set["addr"] && cfg.Server.Addr != "" {
+		*addr = cfg.Server.Addr
+	}
+	if !set["path"] && cfg.Server.Path != "" {
+		*path = cfg.Server.Path
+	}
+	if !set["token"] {
+		*token = cfg.Server.Token
+	}
+	if !set["shutdown-timeout"] && cfg.Server.ShutdownTimeout > 0 {
+		*shutdownTimeout = time.Duration(cfg.Server.ShutdownTimeout)
+	}
+
+	t, err := sddserver.ParseTransport(*transport)
 	if err != nil {
-		return fmt.Errorf("creating server: %w", err)
+		return err
 	}
-	defer cleanup()
 
 	// Background version check — prints to stderr so it doesn't
 	// interfere with MCP's stdio transport on stdout.
 	go checkForUpdates()
 
-	// Graceful shutdown on interrupt.
-	ctx, cancel := context.WithCancel(context.Background())
+	// Graceful shutdown on interrupt: Serve stops accepting requests
+	// and drains in-flight tool calls before closing the memory store.
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-sigCh
-		cancel()
-	}()
-
-	_ = ctx // stdio server manages its own lifecycle
-
-	return server.ServeStdio(s)
+	return sddserver.Serve(ctx, sddserver.ServeOptions{
+		Config:          cfg,
+		Transport:       t,
+		Addr:            *addr,
+		Path:            *path,
+		Token:           *token,
+		ShutdownTimeout: *shutdownTimeout,
+	})
 }
 
 // checkForUpdates runs a non-blocking version check and prints a notice
@@ -119,11 +199,34 @@ func printUsage() {
 	fmt.Fprintf(os.Stderr, `Hoofy v%s — Spec-Driven Development MCP Server
 
 Usage:
-  hoofy serve    Start the MCP server (stdio transport)
-  hoofy update   Update to the latest version
+  hoofy serve            Start the MCP server (stdio transport)
+      --config FILE          User config (default ~/.hoofy/config.json)
+      --transport http|sse   Serve over streamable HTTP or SSE instead
+      --addr HOST:PORT       Listen address (default 127.0.0.1:7777)
+      --token TOKEN          Require "Authorization: Bearer TOKEN" (or $HOOFY_TOKEN)
+  hoofy update           Update to the latest version
+  hoofy memory export    Export persistent memory as JSON (stdout)
+  hoofy memory import    Import a memory export (--dry-run to preview)
+  hoofy mem search|list|get|rm   Browse and curate memory from the terminal
+  hoofy doctor           Diagnose memory, config, and project files (--fix to repair)
+  hoofy check            Run spec gates for CI (--format text|json|junit)
+  hoofy changelog        Keep a Changelog section from completed changes
+      --from, --to DATE|TAG  Range bounds (YYYY-MM-DD or git tag)
+      --version X.Y.Z        Release heading (default [Unreleased])
+      --write                Merge into CHANGELOG.md under [Unreleased]
+  hoofy metrics          Cycle-time metrics: lead time, time in stage, throughput, outliers
+      --from, --to DATE      Range bounds (YYYY-MM-DD, inclusive)
+      --format markdown|json
+  hoofy templates list   Artifact templates, marking project overrides
+  hoofy templates eject NAME   Copy a template to docs/.hoofy/templates for editing
+  hoofy call TOOL        Invoke a tool in-process (--args '{...}' or --arg key=value)
+  hoofy tools list       List every tool with its input schema (--json)
+  hoofy install CLIENT   Add hoofy to claude, cursor, vscode, opencode, or gemini
+      --scope user|project   Edit the home-directory or project config
+      --uninstall            Remove the entry; --print shows the JSON instead
 
 Configuration:
-  Add to your AI tool's MCP config:
+  Run "hoofy install <client>", or add to your AI tool's MCP config:
 
   {
     "mcpServers": {
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/HendryAvila/Hoofy/internal/memory"
+)
+
+// browseFlags holds the flags shared by the memory browser subcommands.
+type browseFlags struct {
+	config    *string
+	project   *string
+	scope     *string
+	namespace *string
+	asJSON    *bool
+}
+
+func registerBrowseFlags(fs *flag.FlagSet, filters bool) browseFlags {
+	f := browseFlags{
+		config: fs.String("config", "", "path to user config (default ~/.hoofy/config.json)"),
+		asJSON: fs.Bool("json", false, "print JSON instead of a table"),
+	}
+	if filters {
+		f.project = fs.String("project", "", "only this project")
+		f.scope = fs.String("scope", "", "only this scope (project or personal)")
+		f.namespace = fs.String("namespace", "", "only this sub-agent namespace")
+	}
+	return f
+}
+
+// runMemorySearch runs a full-text search (Store.Search).
+func runMemorySearch(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("memory search", flag.ContinueOnError)
+	f := registerBrowseFlags(fs, true)
+	typ := fs.String("type", "", "only this observation type (decision, bugfix, ...)")
+	limit := fs.Int("limit", 10, "maximum results")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("usage: hoofy memory search <query> [filters]")
+	}
+
+	store, err := openMemoryStore(*f.config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	results, err := store.Search(strings.Join(positional, " "), memory.SearchOptions{
+		Type:      *typ,
+		Project:   *f.project,
+		Scope:     *f.scope,
+		Namespace: *f.namespace,
+		Limit:     *limit,
+	})
+	if err != nil {
+		return err
+	}
+	if *f.asJSON {
+		return writeJSON(stdout, results)
+	}
+
+	obs := make([]memory.Observation, len(results))
+	for i, r := range results {
+		obs[i] = r.Observation
+	}
+	return writeObservationTable(stdout, obs)
+}
+
+// runMemoryList shows the most recent observations (Store.RecentObservations).
+func runMemoryList(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("memory list", flag.ContinueOnError)
+	f := registerBrowseFlags(fs, true)
+	limit := fs.Int("limit", 20, "maximum results")
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return err
+	}
+
+	store, err := openMemoryStore(*f.config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	obs, err := store.RecentObservations(*f.project, *f.scope, *f.namespace, *limit)
+	if err != nil {
+		return err
+	}
+	if *f.asJSON {
+		return writeJSON(stdout, obs)
+	}
+	return writeObservationTable(stdout, obs)
+}
+
+// runMemoryGet prints one observation in full, optionally with the
+// observations around it (Store.Timeline) and its relation graph
+// (Store.BuildContext).
+func runMemoryGet(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("memory get", flag.ContinueOnError)
+	f := registerBrowseFlags(fs, false)
+	timeline := fs.Int("timeline", 0, "also show N observations before and after in the same session")
+	depth := fs.Int("depth", 0, "also show related observations up to this many hops (max 5)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: hoofy memory get <id> [--timeline N] [--depth N]")
+	}
+	id, err := parseObservationID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	store, err := openMemoryStore(*f.config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	obs, err := store.GetObservation(id)
+	if err != nil {
+		return observationLookupError(id, err)
+	}
+
+	out := struct {
+		Observation *memory.Observation    `json:"observation"`
+		Timeline    *memory.TimelineResult `json:"timeline,omitempty"`
+		Context     *memory.ContextResult  `json:"context,omitempty"`
+	}{Observation: obs}
+
+	if *timeline > 0 {
+		if out.Timeline, err = store.Timeline(id, *timeline, *timeline); err != nil {
+			return fmt.Errorf("timeline: %w", err)
+		}
+	}
+	if *depth > 0 {
+		if out.Context, err = store.BuildContext(id, *depth); err != nil {
+			return fmt.Errorf("relations: %w", err)
+		}
+	}
+
+	if *f.asJSON {
+		return writeJSON(stdout, out)
+	}
+
+	writeObservationDetail(stdout, obs)
+	if out.Timeline != nil {
+		_, _ = fmt.Fprintf(stdout, "\nTimeline (session %s)\n", obs.SessionID)
+		tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		for _, e := range append(append(out.Timeline.Before, memory.TimelineEntry{
+			ID: obs.ID, Type: obs.Type, Title: obs.Title, CreatedAt: obs.CreatedAt, IsFocus: true,
+		}), out.Timeline.After...) {
+			marker := " "
+			if e.IsFocus {
+				marker = "▶"
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t#%d\t%s\t%s\t%s\n", marker, e.ID, e.Type, e.CreatedAt, e.Title)
+		}
+		_ = tw.Flush()
+	}
+	if out.Context != nil {
+		_, _ = fmt.Fprintf(stdout, "\nRelations (up to %d hop(s))\n", *depth)
+		if len(out.Context.Connected) == 0 {
+			_, _ = fmt.Fprintln(stdout, "  (none)")
+		}
+		tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		for _, n := range out.Context.Connected {
+			arrow := "→"
+			if n.Direction == "incoming" {
+				arrow = "←"
+			}
+			_, _ = fmt.Fprintf(tw, "  %s%s %s\t#%d\t%s\t%s\n", strings.Repeat("  ", n.Depth-1), arrow, n.RelationType, n.ID, n.Type, n.Title)
+		}
+		_ = tw.Flush()
+	}
+	return nil
+}
+
+// runMemoryRm deletes observations (Store.DeleteObservation). Soft
+// delete by default, like mem_delete; --hard removes rows permanently.
+func runMemoryRm(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("memory rm", flag.ContinueOnError)
+	f := registerBrowseFlags(fs, false)
+	hard := fs.Bool("hard", false, "permanently delete instead of soft-deleting")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("usage: hoofy memory rm <id>... [--hard]")
+	}
+
+	ids := make([]int64, len(positional))
+	for i, p := range positional {
+		if ids[i], err = parseObservationID(p); err != nil {
+			return err
+		}
+	}
+
+	store, err := openMemoryStore(*f.config)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	type removed struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title,omitempty"`
+		Hard  bool   `json:"hard"`
+	}
+	var done []removed
+	for _, id := range ids {
+		obs, err := store.GetObservation(id)
+		switch {
+		case err == nil:
+		case errors.Is(err, sql.ErrNoRows) && *hard:
+			// Already soft-deleted rows are invisible to GetObservation
+			// but can still be purged.
+			obs = &memory.Observation{ID: id}
+		default:
+			return observationLookupError(id, err)
+		}
+		if err := store.DeleteObservation(id, *hard); err != nil {
+			return fmt.Errorf("deleting #%d: %w", id, err)
+		}
+		done = append(done, removed{ID: id, Title: obs.Title, Hard: *hard})
+	}
+
+	if *f.asJSON {
+		return writeJSON(stdout, done)
+	}
+	verb := "Deleted"
+	if *hard {
+		verb = "Permanently deleted"
+	}
+	for _, r := range done {
+		_, _ = fmt.Fprintf(stdout, "%s #%d %s\n", verb, r.ID, r.Title)
+	}
+	return nil
+}
+
+func parseObservationID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(s, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid observation ID %q", s)
+	}
+	return id, nil
+}
+
+func observationLookupError(id int64, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("observation #%d not found (or already deleted)", id)
+	}
+	return fmt.Errorf("reading observation #%d: %w", id, err)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeObservationTable(w io.Writer, obs []memory.Observation) error {
+	if len(obs) == 0 {
+		_, err := fmt.Fprintln(w, "No observations found.")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tTYPE\tPROJECT\tSCOPE\tCREATED\tTITLE")
+	for _, o := range obs {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			o.ID, o.Type, deref(o.Project), o.Scope, o.CreatedAt, memory.Truncate(o.Title, 60))
+	}
+	return tw.Flush()
+}
+
+func writeObservationDetail(w io.Writer, o *memory.Observation) {
+	_, _ = fmt.Fprintf(w, "#%d %s\n\n", o.ID, o.Title)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	rows := [][2]string{
+		{"type", o.Type},
+		{"project", deref(o.Project)},
+		{"scope", o.Scope},
+		{"namespace", deref(o.Namespace)},
+		{"topic_key", deref(o.TopicKey)},
+		{"session", o.SessionID},
+		{"created", o.CreatedAt},
+		{"updated", o.UpdatedAt},
+		{"revisions", strconv.Itoa(o.RevisionCount)},
+	}
+	for _, r := range rows {
+		if r[1] != "" {
+			_, _ = fmt.Fprintf(tw, "%s:\t%s\n", r[0], r[1])
+		}
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%s\n", o.Content)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/HendryAvila/Hoofy/internal/memory"
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
+)
+
+// runMemory dispatches the "hoofy memory <subcommand>" family ("hoofy mem"
+// for short). These commands operate directly on ~/.hoofy/memory.db — no
+// MCP client involved — so memory can be backed up, moved between
+// machines, and inspected or curated by hand.
+func runMemory(args []string) error {
+	if len(args) == 0 {
+		printMemoryUsage()
+		return errors.New("missing memory subcommand")
+	}
+
+	switch args[0] {
+	case "export":
+		return runMemoryExport(args[1:], os.Stdout, os.Stderr)
+	case "import":
+		return runMemoryImport(args[1:], os.Stdin, os.Stdout)
+	case "search":
+		return runMemorySearch(args[1:], os.Stdout)
+	case "list", "ls":
+		return runMemoryList(args[1:], os.Stdout)
+	case "get", "show":
+		return runMemoryGet(args[1:], os.Stdout)
+	case "rm", "delete":
+		return runMemoryRm(args[1:], os.Stdout)
+	case "--help", "-h", "help":
+		printMemoryUsage()
+		return nil
+	default:
+		printMemoryUsage()
+		return fmt.Errorf("unknown memory subcommand: %s", args[0])
+	}
+}
+
+// runMemoryExport writes a JSON dump of the memory database to stdout
+// (or --output), optionally restricted by project/scope/namespace/date.
+// The summary goes to stderr so it never mixes with the dump.
+func runMemoryExport(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("memory export", flag.ContinueOnError)
+	filters := registerExportFilters(fs)
+	output := fs.String("output", "", "write to this file instead of stdout")
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return err
+	}
+
+	opts, err := filters.options()
+	if err != nil {
+		return err
+	}
+
+	store, err := filters.openStore()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	data, err := store.ExportFiltered(opts)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		err = encodeExport(stdout, data)
+	} else {
+		err = writeExportFile(*output, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "Exported %d sessions, %d observations, %d prompts, %d relations (format v%s)\n",
+		len(data.Sessions), len(data.Observations), len(data.Prompts), len(data.Relations), data.Version)
+	return nil
+}
+
+func encodeExport(w io.Writer, data *memory.ExportData) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("writing export: %w", err)
+	}
+	return nil
+}
+
+// writeExportFile writes the dump to a temp file next to path and renames
+// it into place, so a failed export never truncates an earlier backup.
+func writeExportFile(path string, data *memory.ExportData) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := encodeExport(tmp, data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// runMemoryImport loads a JSON dump (file path or "-" for stdin) into the
+// memory database and prints the ImportResult summary.
+func runMemoryImport(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("memory import", flag.ContinueOnError)
+	filters := registerExportFilters(fs)
+	dryRun := fs.Bool("dry-run", false, "report what would be imported without writing")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: hoofy memory import <file.json|-> [--dry-run]")
+	}
+
+	opts, err := filters.options()
+	if err != nil {
+		return err
+	}
+
+	in := stdin
+	if positional[0] != "-" {
+		f, err := os.Open(positional[0])
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", positional[0], err)
+		}
+		defer func() { _ = f.Close() }()
+		in = f
+	}
+
+	var data memory.ExportData
+	if err := json.NewDecoder(in).Decode(&data); err != nil {
+		return fmt.Errorf("decoding export: %w", err)
+	}
+
+	store, err := filters.openStore()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = store.Close() }()
+
+	result, err := store.ImportWithOptions(data.Filter(opts), memory.ImportOptions{DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	prefix := "Imported"
+	if result.DryRun {
+		prefix = "Dry run — would import"
+	}
+	fmt.Fprintf(stdout, "%s %d sessions, %d observations, %d prompts, %d relations\n",
+		prefix, result.SessionsImported, result.ObservationsImported, result.PromptsImported, result.RelationsImported)
+	if result.RelationsSkipped > 0 {
+		fmt.Fprintf(stdout, "Skipped %d relations (endpoint missing from dump or already present)\n", result.RelationsSkipped)
+	}
+	return nil
+}
+
+// exportFilters holds the flags shared by export and import.
+type exportFilters struct {
+	config    *string
+	project   *string
+	scope     *string
+	namespace *string
+	since     *string
+}
+
+func registerExportFilters(fs *flag.FlagSet) exportFilters {
+	return exportFilters{
+		config:    fs.String("config", "", "path to user config (default ~/.hoofy/config.json)"),
+		project:   fs.String("project", "", "only records for this project"),
+		scope:     fs.String("scope", "", "only observations with this scope (project|personal)"),
+		namespace: fs.String("namespace", "", "only records in this namespace"),
+		since:     fs.String("since", "", "only records created on/after this date (YYYY-MM-DD)"),
+	}
+}
+
+func (f exportFilters) options() (memory.ExportOptions, error) {
+	since, err := memory.ParseSince(*f.since)
+	if err != nil {
+		return memory.ExportOptions{}, err
+	}
+	return memory.ExportOptions{
+		Project:   *f.project,
+		Scope:     *f.scope,
+		Namespace: *f.namespace,
+		Since:     since,
+	}, nil
+}
+
+// openStore opens the memory database configured in the user config.
+func (f exportFilters) openStore() (*memory.Store, error) {
+	return openMemoryStore(*f.config)
+}
+
+// openMemoryStore opens the memory database configured in the user
+// config at configPath (empty means the default location).
+func openMemoryStore(configPath string) (*memory.Store, error) {
+	cfg, err := userconfig.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return memory.New(cfg.MemoryStoreConfig())
+}
+
+// parseInterspersed parses flags that may appear before or after positional
+// arguments (the stdlib flag package stops at the first positional).
+// Returns the positional arguments in order.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func printMemoryUsage() {
+	fmt.Fprint(os.Stderr, `Usage: hoofy memory <command> (or "hoofy mem")
+
+  search <query> [--type T] [--limit N]   Full-text search
+  list [--limit N]                        Most recent observations
+  get <id> [--timeline N] [--depth N]     Full observation, with neighbours and relations
+  rm <id>... [--hard]                     Soft-delete (or permanently delete) observations
+  export [--since DATE] [--output FILE]   Write a JSON backup to stdout
+  import <file.json|-> [--dry-run]        Load a JSON backup
+
+Filters (search, list, export, import): --project X --scope S --namespace N
+Browse commands accept --json for machine-readable output.
+`)
+}
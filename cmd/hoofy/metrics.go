@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/metrics"
+)
+
+// runMetrics prints cycle-time metrics for the changes in a date range.
+func runMetrics(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	project := fs.String("project", "", "project directory (default: nearest parent with docs/hoofy.json)")
+	from := fs.String("from", "", "start of the range: YYYY-MM-DD (inclusive)")
+	to := fs.String("to", "", "end of the range: YYYY-MM-DD (inclusive)")
+	outliers := fs.Int("outliers", metrics.DefaultOutliers, "number of outliers to list")
+	format := fs.String("format", metrics.FormatMarkdown, "output format: markdown or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	root, err := resolveProjectRoot(*project)
+	if err != nil {
+		return err
+	}
+
+	report, err := metrics.Build(root, changes.NewFileStore(), metrics.Options{From: *from, To: *to, Outliers: *outliers})
+	if err != nil {
+		return err
+	}
+	out, err := report.Render(*format)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(stdout, out)
+	return err
+}
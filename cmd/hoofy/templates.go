@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/templates"
+)
+
+// runTemplates lists the artifact templates and ejects them into the
+// project's overrides directory for editing.
+func runTemplates(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		printTemplatesUsage()
+		return errors.New("missing templates subcommand")
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return runTemplatesList(args[1:], stdout)
+	case "eject":
+		return runTemplatesEject(args[1:], stdout)
+	case "--help", "-h", "help":
+		printTemplatesUsage()
+		return nil
+	default:
+		printTemplatesUsage()
+		return fmt.Errorf("unknown templates subcommand: %s", args[0])
+	}
+}
+
+// runTemplatesList prints every template, marking the ones the project
+// overrides.
+func runTemplatesList(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("templates list", flag.ContinueOnError)
+	project := fs.String("project", "", "project directory (default: nearest parent with docs/hoofy.json)")
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return err
+	}
+	root, err := resolveProjectRoot(*project)
+	if err != nil {
+		return err
+	}
+
+	dir := templates.OverridesPath(root)
+	overridden, problems, err := templates.ValidateOverrides(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range templates.Names() {
+		marker := "⬜"
+		if slices.Contains(overridden, name) {
+			marker = "✅"
+			if slices.ContainsFunc(problems, func(p error) bool { return strings.HasPrefix(p.Error(), name+" ") }) {
+				marker = "❌"
+			}
+		}
+		fmt.Fprintf(stdout, "%s %s\n", marker, name)
+	}
+	fmt.Fprintf(stdout, "\nOverrides: %s (%d of %d overridden)\n", dir, len(overridden), len(templates.Names()))
+	for _, p := range problems {
+		fmt.Fprintf(stdout, "❌ %v\n", p)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintln(stdout, "   Broken overrides make Hoofy fall back to the built-in templates.")
+		return errReported
+	}
+	return nil
+}
+
+// runTemplatesEject copies built-in templates into the project's
+// overrides directory.
+func runTemplatesEject(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("templates eject", flag.ContinueOnError)
+	project := fs.String("project", "", "project directory (default: nearest parent with docs/hoofy.json)")
+	force := fs.Bool("force", false, "replace an existing override with the default")
+	names, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		printTemplatesUsage()
+		return errors.New("expected at least one template name")
+	}
+	root, err := resolveProjectRoot(*project)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		path, err := templates.Eject(root, name, *force)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "✅ Ejected %s\n", path)
+	}
+	fmt.Fprintln(stdout, "\nEdit the copies to match your house style. Hoofy checks them when the server starts;")
+	fmt.Fprintln(stdout, "run \"hoofy doctor\" after editing to catch fields that don't exist.")
+	return nil
+}
+
+func printTemplatesUsage() {
+	fmt.Fprint(os.Stderr, `Usage: hoofy templates <command>
+
+  list                             Templates, marking the ones this project overrides
+  eject <name>... [--force]        Copy built-in templates into docs/.hoofy/templates
+
+Names may omit the extension: "hoofy templates eject requirements".
+Both commands take --project DIR (default: nearest parent with docs/hoofy.json).
+`)
+}
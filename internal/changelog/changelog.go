@@ -0,0 +1,297 @@
+// Package changelog renders Keep a Changelog sections from completed
+// changes.
+//
+// Every completed change already carries what a release note needs: a
+// one-line description, a type, and the ADRs it produced. Build selects
+// the changes completed in a range — bounded by dates or git tags — and
+// files each under the section its type maps to:
+//
+//   - feature              → Added
+//   - fix                  → Fixed
+//   - refactor/enhancement → Changed
+//
+// Project types from docs/hoofy-flows.json follow the built-in type they
+// extend; types without a base land in Changed.
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+// File is the changelog file name, at the project root.
+const File = "CHANGELOG.md"
+
+// Path returns the absolute path to the project's CHANGELOG.md.
+func Path(projectRoot string) string {
+	return filepath.Join(projectRoot, File)
+}
+
+// Keep a Changelog section names Hoofy fills.
+const (
+	SectionAdded   = "Added"
+	SectionChanged = "Changed"
+	SectionFixed   = "Fixed"
+)
+
+// sectionOrder is the Keep a Changelog ordering of every section, so
+// merged sections land where a human would put them.
+var sectionOrder = []string{SectionAdded, SectionChanged, "Deprecated", "Removed", SectionFixed, "Security"}
+
+// sectionsByType maps built-in change types to sections.
+var sectionsByType = map[changes.ChangeType]string{
+	changes.TypeFeature:     SectionAdded,
+	changes.TypeFix:         SectionFixed,
+	changes.TypeRefactor:    SectionChanged,
+	changes.TypeEnhancement: SectionChanged,
+}
+
+// Section returns the changelog section for a change type.
+func Section(reg *changes.Registry, t changes.ChangeType) string {
+	if s, ok := sectionsByType[reg.Base(t)]; ok {
+		return s
+	}
+	return SectionChanged
+}
+
+// Options selects the changes to include.
+type Options struct {
+	// From and To bound the range by completion time. Each is a date
+	// (YYYY-MM-DD) or a git tag; empty means unbounded. A From tag
+	// excludes changes completed at or before the tagged commit; a To
+	// tag includes them. Dates are inclusive, whole UTC days.
+	From string
+	To   string
+	// Version heads the section; empty renders [Unreleased].
+	Version string
+	// Now is the release date when To is empty (defaults to time.Now).
+	Now func() time.Time
+}
+
+// Entry is one line of the changelog.
+type Entry struct {
+	ChangeID    string    `json:"change_id"`
+	Description string    `json:"description"`
+	Type        string    `json:"type"`
+	Section     string    `json:"section"`
+	CompletedAt time.Time `json:"completed_at"`
+	ADRs        []ADRLink `json:"adrs,omitempty"`
+}
+
+// ADRLink is an ADR referenced by an entry. Path is relative to the
+// project root, empty when the ADR file can't be found.
+type ADRLink struct {
+	ID   string `json:"id"`
+	Path string `json:"path,omitempty"`
+}
+
+// Release is a rendered changelog section.
+type Release struct {
+	Version string  `json:"version,omitempty"` // "" = Unreleased
+	Date    string  `json:"date,omitempty"`    // YYYY-MM-DD, set with Version
+	Entries []Entry `json:"entries"`
+}
+
+// Build collects the changes completed within opts' range into a Release.
+func Build(projectRoot string, store changes.Store, reg *changes.Registry, opts Options) (*Release, error) {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	var after, through time.Time
+	if opts.From != "" {
+		b, err := resolveBound(projectRoot, opts.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		after = b.lower()
+	}
+	end := opts.Now()
+	if opts.To != "" {
+		b, err := resolveBound(projectRoot, opts.To)
+		if err != nil {
+			return nil, fmt.Errorf("to: %w", err)
+		}
+		through = b.upper()
+		end = b.at
+	}
+	if !after.IsZero() && !through.IsZero() && !after.Before(through) {
+		return nil, fmt.Errorf("range is empty: %s is not before %s", opts.From, opts.To)
+	}
+
+	records, err := store.List(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("listing changes: %w", err)
+	}
+
+	rel := &Release{Version: opts.Version, Entries: []Entry{}}
+	if opts.Version != "" {
+		rel.Date = end.UTC().Format("2006-01-02")
+	}
+	for i := range records {
+		c := &records[i]
+		if c.Status != changes.StatusCompleted && c.Status != changes.StatusArchived {
+			continue
+		}
+		done, ok := completedAt(c)
+		if !ok {
+			continue
+		}
+		if (!after.IsZero() && !done.After(after)) || (!through.IsZero() && done.After(through)) {
+			continue
+		}
+		rel.Entries = append(rel.Entries, Entry{
+			ChangeID:    c.ID,
+			Description: summary(c.Description),
+			Type:        string(c.Type),
+			Section:     Section(reg, c.Type),
+			CompletedAt: done,
+			ADRs:        adrLinks(projectRoot, c.ADRs),
+		})
+	}
+	sort.SliceStable(rel.Entries, func(i, j int) bool {
+		return rel.Entries[i].CompletedAt.Before(rel.Entries[j].CompletedAt)
+	})
+	return rel, nil
+}
+
+// bound is a resolved From/To value.
+type bound struct {
+	at   time.Time
+	date bool // a whole day rather than a tagged instant
+}
+
+// lower returns the instant a From bound excludes up to.
+func (b bound) lower() time.Time {
+	if b.date {
+		return b.at.Add(-time.Nanosecond)
+	}
+	return b.at
+}
+
+// upper returns the last instant a To bound includes.
+func (b bound) upper() time.Time {
+	if b.date {
+		return b.at.Add(24*time.Hour - time.Nanosecond)
+	}
+	return b.at
+}
+
+// resolveBound parses a YYYY-MM-DD date, falling back to a git tag.
+func resolveBound(projectRoot, s string) (bound, error) {
+	if d, err := time.Parse("2006-01-02", s); err == nil {
+		return bound{at: d, date: true}, nil
+	}
+	t, err := changes.RefTime(projectRoot, s)
+	if err != nil {
+		return bound{}, fmt.Errorf("%q is neither a YYYY-MM-DD date nor a git tag: %w", s, err)
+	}
+	return bound{at: t}, nil
+}
+
+// completedAt returns when a change finished: its last stage's
+// completion time, or its last update for records without one.
+func completedAt(c *changes.ChangeRecord) (time.Time, bool) {
+	stamp := c.UpdatedAt
+	if n := len(c.Stages); n > 0 && c.Stages[n-1].CompletedAt != "" {
+		stamp = c.Stages[n-1].CompletedAt
+	}
+	t, err := time.Parse(time.RFC3339, stamp)
+	return t, err == nil
+}
+
+// summary returns the first line of a description, without a trailing period.
+func summary(desc string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(desc), "\n")
+	return strings.TrimSuffix(strings.TrimSpace(line), ".")
+}
+
+// adrFilePattern matches ADR files like "001-use-postgres.md".
+var adrFilePattern = regexp.MustCompile(`^(\d{3,})-.*\.md$`)
+
+// adrLinks resolves ADR IDs ("ADR-001") to their files under docs/adrs/.
+func adrLinks(projectRoot string, ids []string) []ADRLink {
+	if len(ids) == 0 {
+		return nil
+	}
+	files := map[string]string{}
+	adrsDir := config.ADRsPath(projectRoot)
+	if entries, err := os.ReadDir(adrsDir); err == nil {
+		for _, e := range entries {
+			if m := adrFilePattern.FindStringSubmatch(e.Name()); m != nil && !e.IsDir() {
+				rel, err := filepath.Rel(projectRoot, filepath.Join(adrsDir, e.Name()))
+				if err == nil {
+					files["ADR-"+m[1]] = filepath.ToSlash(rel)
+				}
+			}
+		}
+	}
+
+	links := make([]ADRLink, len(ids))
+	for i, id := range ids {
+		links[i] = ADRLink{ID: id, Path: files[id]}
+	}
+	return links
+}
+
+// Heading returns the release's "## [...]" heading line.
+func (r *Release) Heading() string {
+	if r.Version == "" {
+		return "## [Unreleased]"
+	}
+	return fmt.Sprintf("## [%s] - %s", r.Version, r.Date)
+}
+
+// Line renders an entry as a changelog bullet.
+func (e Entry) Line() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "- %s (`%s`)", e.Description, e.ChangeID)
+	for i, adr := range e.ADRs {
+		if i == 0 {
+			sb.WriteString(" — ")
+		} else {
+			sb.WriteString(", ")
+		}
+		if adr.Path == "" {
+			sb.WriteString(adr.ID)
+		} else {
+			fmt.Fprintf(&sb, "[%s](%s)", adr.ID, adr.Path)
+		}
+	}
+	return sb.String()
+}
+
+// bySection groups entry lines by section, in sectionOrder.
+func (r *Release) bySection() ([]string, map[string][]string) {
+	lines := map[string][]string{}
+	for _, e := range r.Entries {
+		lines[e.Section] = append(lines[e.Section], e.Line())
+	}
+	var sections []string
+	for _, s := range sectionOrder {
+		if len(lines[s]) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	return sections, lines
+}
+
+// Markdown renders the release as a Keep a Changelog section.
+func (r *Release) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(r.Heading() + "\n")
+	sections, lines := r.bySection()
+	for _, s := range sections {
+		fmt.Fprintf(&sb, "\n### %s\n\n", s)
+		sb.WriteString(strings.Join(lines[s], "\n") + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,265 @@
+package changelog
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+// completedChange stores a change that finished at the given time.
+func completedChange(t *testing.T, root, id string, ct changes.ChangeType, status changes.ChangeStatus, doneAt string, adrs ...string) {
+	t.Helper()
+	c := &changes.ChangeRecord{
+		ID: id, Type: ct, Size: changes.SizeSmall,
+		Description: "Change " + id + ".\nMore detail.",
+		Stages: []changes.StageEntry{
+			{Name: changes.StageDescribe, Status: "completed", CompletedAt: doneAt},
+			{Name: changes.StageVerify, Status: "completed", CompletedAt: doneAt},
+		},
+		CurrentStage: changes.StageVerify,
+		ADRs:         adrs,
+		Status:       status,
+		UpdatedAt:    doneAt,
+	}
+	if err := changes.NewFileStore().Create(root, c); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+}
+
+func seedProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	completedChange(t, root, "add-login", changes.TypeFeature, changes.StatusCompleted, "2026-03-02T10:00:00Z", "ADR-001", "ADR-007")
+	completedChange(t, root, "fix-crash", changes.TypeFix, changes.StatusArchived, "2026-03-05T12:00:00Z")
+	completedChange(t, root, "split-store", changes.TypeRefactor, changes.StatusCompleted, "2026-03-04T09:00:00Z")
+	completedChange(t, root, "old-feature", changes.TypeFeature, changes.StatusCompleted, "2026-01-10T09:00:00Z")
+	completedChange(t, root, "dropped", changes.TypeFeature, changes.StatusAbandoned, "2026-03-03T09:00:00Z")
+
+	adrs := config.ADRsPath(root)
+	if err := os.MkdirAll(adrs, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(adrs, "001-use-jwt.md"), []byte("# Use JWT\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return root
+}
+
+func TestBuild_DateRange(t *testing.T) {
+	root := seedProject(t)
+
+	rel, err := Build(root, changes.NewFileStore(), changes.DefaultRegistry(), Options{From: "2026-03-01", To: "2026-03-05"})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	want := `## [Unreleased]
+
+### Added
+
+- Change add-login (` + "`add-login`" + `) — [ADR-001](docs/adrs/001-use-jwt.md), ADR-007
+
+### Changed
+
+- Change split-store (` + "`split-store`" + `)
+
+### Fixed
+
+- Change fix-crash (` + "`fix-crash`" + `)
+`
+	if got := rel.Markdown(); got != want {
+		t.Errorf("Markdown() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBuild_VersionAndBounds(t *testing.T) {
+	root := seedProject(t)
+	store := changes.NewFileStore()
+	reg := changes.DefaultRegistry()
+
+	rel, err := Build(root, store, reg, Options{From: "2026-03-04", Version: "1.2.0",
+		Now: func() time.Time { return time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC) }})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if rel.Heading() != "## [1.2.0] - 2026-04-01" {
+		t.Errorf("Heading() = %q", rel.Heading())
+	}
+	if len(rel.Entries) != 2 || rel.Entries[0].ChangeID != "split-store" || rel.Entries[1].ChangeID != "fix-crash" {
+		t.Errorf("entries = %+v, want split-store then fix-crash", rel.Entries)
+	}
+
+	if _, err := Build(root, store, reg, Options{From: "2026-03-05", To: "2026-03-01"}); err == nil {
+		t.Error("inverted range should fail")
+	}
+	if _, err := Build(root, store, reg, Options{From: "not-a-tag"}); err == nil || !strings.Contains(err.Error(), "neither a YYYY-MM-DD date nor a git tag") {
+		t.Errorf("unknown bound error = %v", err)
+	}
+}
+
+func TestBuild_TagRange(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := seedProject(t)
+	git := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", root, "-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git(nil, "init", "-q")
+	for _, tag := range []struct{ name, date string }{{"v1.0.0", "2026-03-01T00:00:00Z"}, {"v1.1.0", "2026-03-04T12:00:00Z"}} {
+		git([]string{"GIT_COMMITTER_DATE=" + tag.date, "GIT_AUTHOR_DATE=" + tag.date}, "commit", "-q", "--allow-empty", "-m", tag.name)
+		git(nil, "tag", tag.name)
+	}
+
+	rel, err := Build(root, changes.NewFileStore(), changes.DefaultRegistry(), Options{From: "v1.0.0", To: "v1.1.0", Version: "1.1.0"})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if rel.Date != "2026-03-04" {
+		t.Errorf("Date = %q, want the v1.1.0 commit date", rel.Date)
+	}
+	if len(rel.Entries) != 2 || rel.Entries[0].ChangeID != "add-login" || rel.Entries[1].ChangeID != "split-store" {
+		t.Errorf("entries = %+v, want add-login and split-store", rel.Entries)
+	}
+}
+
+func TestSection_ProjectTypes(t *testing.T) {
+	reg, err := changes.DefaultRegistry().Merge(changes.FlowConfig{Types: map[changes.ChangeType]changes.TypeConfig{
+		"hotfix": {Extends: changes.TypeFix},
+		"chore": {Flows: map[changes.ChangeSize][]changes.ChangeStage{
+			changes.SizeSmall:  {changes.StageDescribe, changes.StageContextCheck, changes.StageVerify},
+			changes.SizeMedium: {changes.StageDescribe, changes.StageContextCheck, changes.StageVerify},
+			changes.SizeLarge:  {changes.StageDescribe, changes.StageContextCheck, changes.StageVerify},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	tests := map[changes.ChangeType]string{
+		changes.TypeFeature:     SectionAdded,
+		changes.TypeFix:         SectionFixed,
+		changes.TypeRefactor:    SectionChanged,
+		changes.TypeEnhancement: SectionChanged,
+		"hotfix":                SectionFixed,
+		"chore":                 SectionChanged,
+	}
+	for ct, want := range tests {
+		if got := Section(reg, ct); got != want {
+			t.Errorf("Section(%s) = %q, want %q", ct, got, want)
+		}
+	}
+}
+
+func TestUpdateFile_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	rel := &Release{Entries: []Entry{
+		{ChangeID: "add-login", Description: "Add login", Section: SectionAdded},
+	}}
+
+	added, err := UpdateFile(path, rel)
+	if err != nil {
+		t.Fatalf("UpdateFile failed: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("added = %d, want 1", added)
+	}
+	data, _ := os.ReadFile(path)
+	want := header + "\n## [Unreleased]\n\n### Added\n\n- Add login (`add-login`)\n"
+	if string(data) != want {
+		t.Errorf("CHANGELOG.md =\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestUpdateFile_MergesUnreleased(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	existing := `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- Hand-written fix
+
+## [1.0.0] - 2026-01-01
+
+### Added
+
+- First release
+`
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	rel := &Release{Entries: []Entry{
+		{ChangeID: "add-login", Description: "Add login", Section: SectionAdded},
+		{ChangeID: "fix-crash", Description: "Fix crash", Section: SectionFixed},
+	}}
+
+	added, err := UpdateFile(path, rel)
+	if err != nil {
+		t.Fatalf("UpdateFile failed: %v", err)
+	}
+	if added != 2 {
+		t.Errorf("added = %d, want 2", added)
+	}
+	want := `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- Add login (` + "`add-login`" + `)
+
+### Fixed
+
+- Hand-written fix
+- Fix crash (` + "`fix-crash`" + `)
+
+## [1.0.0] - 2026-01-01
+
+### Added
+
+- First release
+`
+	data, _ := os.ReadFile(path)
+	if string(data) != want {
+		t.Errorf("CHANGELOG.md =\n%s\nwant:\n%s", data, want)
+	}
+
+	// Running again adds nothing and leaves the file alone.
+	added, err = UpdateFile(path, rel)
+	if err != nil || added != 0 {
+		t.Errorf("second UpdateFile = %d, %v; want 0, nil", added, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != want {
+		t.Errorf("second run changed the file:\n%s", data)
+	}
+}
+
+func TestUpdateFile_InsertsUnreleasedAboveLatestRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+	existing := "# Changelog\n\nIntro.\n\n## [1.0.0] - 2026-01-01\n\n- First release\n"
+	if err := os.WriteFile(path, []byte(existing), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	rel := &Release{Entries: []Entry{{ChangeID: "split-store", Description: "Split store", Section: SectionChanged}}}
+	if _, err := UpdateFile(path, rel); err != nil {
+		t.Fatalf("UpdateFile failed: %v", err)
+	}
+	want := "# Changelog\n\nIntro.\n\n## [Unreleased]\n\n### Changed\n\n- Split store (`split-store`)\n\n## [1.0.0] - 2026-01-01\n\n- First release\n"
+	if data, _ := os.ReadFile(path); string(data) != want {
+		t.Errorf("CHANGELOG.md =\n%s\nwant:\n%s", data, want)
+	}
+}
@@ -0,0 +1,167 @@
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// header starts a CHANGELOG.md created by Hoofy.
+const header = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).
+`
+
+// unreleasedPattern matches the Unreleased heading, bracketed or not.
+var unreleasedPattern = regexp.MustCompile(`(?i)^##\s+\[?unreleased\]?\s*$`)
+
+// UpdateFile merges the release's entries into the [Unreleased] section
+// of the changelog at path, creating the file or the section as needed.
+// Entries whose change ID already appears in the section are skipped, so
+// running it repeatedly is safe. Returns how many entries were added.
+func UpdateFile(path string, rel *Release) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("reading %s: %w", File, err)
+	}
+	content := string(data)
+	if os.IsNotExist(err) {
+		content = header
+	}
+
+	updated, added := merge(content, rel.Entries)
+	if added == 0 && len(data) > 0 {
+		return 0, nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return 0, fmt.Errorf("writing %s: %w", File, err)
+	}
+	return added, nil
+}
+
+// merge adds entries to the Unreleased section of a changelog.
+func merge(content string, entries []Entry) (string, int) {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	start := slices.IndexFunc(lines, func(l string) bool { return unreleasedPattern.MatchString(l) })
+	if start < 0 {
+		// New section above the latest release, or at the end.
+		if at := slices.IndexFunc(lines, isReleaseHeading); at >= 0 {
+			lines = slices.Insert(lines, at, "## [Unreleased]", "")
+			start = at
+		} else {
+			lines = append(lines, "", "## [Unreleased]")
+			start = len(lines) - 1
+		}
+	}
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if isReleaseHeading(lines[i]) {
+			end = i
+			break
+		}
+	}
+
+	section := parseSection(lines[start+1 : end])
+	existing := strings.Join(lines[start+1:end], "\n")
+	added := 0
+	for _, e := range entries {
+		if strings.Contains(existing, "(`"+e.ChangeID+"`)") {
+			continue
+		}
+		section.add(e.Section, e.Line())
+		added++
+	}
+
+	out := append([]string(nil), lines[:start+1]...)
+	out = append(out, section.render()...)
+	if end < len(lines) {
+		out = append(out, "")
+		out = append(out, lines[end:]...)
+	}
+	return strings.Join(out, "\n") + "\n", added
+}
+
+// isReleaseHeading reports whether a line starts a release section.
+func isReleaseHeading(line string) bool {
+	return strings.HasPrefix(line, "## ")
+}
+
+// unreleased is the body of the Unreleased section: free text before the
+// first subsection, then "### Added"-style subsections in file order.
+type unreleased struct {
+	preamble    []string
+	subsections []subsection
+}
+
+// subsection is one "### Name" block of the Unreleased section.
+type subsection struct {
+	name  string
+	lines []string
+}
+
+// parseSection splits the lines under the Unreleased heading.
+func parseSection(body []string) *unreleased {
+	u := &unreleased{}
+	for _, line := range body {
+		if name, ok := strings.CutPrefix(line, "### "); ok {
+			u.subsections = append(u.subsections, subsection{name: strings.TrimSpace(name)})
+			continue
+		}
+		if n := len(u.subsections); n > 0 {
+			u.subsections[n-1].lines = append(u.subsections[n-1].lines, line)
+		} else {
+			u.preamble = append(u.preamble, line)
+		}
+	}
+	return u
+}
+
+// add appends a line to a subsection, creating it in Keep a Changelog
+// order when missing.
+func (u *unreleased) add(name, line string) {
+	for i := range u.subsections {
+		if strings.EqualFold(u.subsections[i].name, name) {
+			u.subsections[i].lines = append(trimBlank(u.subsections[i].lines), line)
+			return
+		}
+	}
+	rank := slices.Index(sectionOrder, name)
+	at := len(u.subsections)
+	for i, s := range u.subsections {
+		if r := slices.Index(sectionOrder, s.name); r > rank {
+			at = i
+			break
+		}
+	}
+	u.subsections = slices.Insert(u.subsections, at, subsection{name: name, lines: []string{"", line}})
+}
+
+// render returns the section body with one blank line between blocks.
+func (u *unreleased) render() []string {
+	var out []string
+	if pre := trimBlank(u.preamble); len(pre) > 0 {
+		out = append(out, "")
+		out = append(out, pre...)
+	}
+	for _, s := range u.subsections {
+		out = append(out, "", "### "+s.name, "")
+		out = append(out, trimBlank(s.lines)...)
+	}
+	return out
+}
+
+// trimBlank drops leading and trailing blank lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,187 @@
+package changes
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+// CodeSummary links a change to the code that implemented it: the
+// commits made since the change's base commit and the files touched.
+// Captured when the verify stage completes.
+type CodeSummary struct {
+	HeadCommit string   `json:"head_commit,omitempty"`
+	Commits    []Commit `json:"commits,omitempty"`
+	DiffStat   string   `json:"diff_stat,omitempty"`
+	Files      []string `json:"files,omitempty"`
+	CapturedAt string   `json:"captured_at"`
+}
+
+// Commit is one commit in a CodeSummary.
+type Commit struct {
+	SHA     string `json:"sha"`
+	Subject string `json:"subject"`
+}
+
+// runGit runs git in dir and returns its stdout. Unlike ReadGitHead,
+// commit history and diffs need the git binary. A variable so tests can
+// simulate git being unavailable.
+var runGit = func(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("running git: %w", err)
+	}
+	return string(out), nil
+}
+
+// commitSHAPattern matches an (abbreviated) commit SHA. The base commit
+// comes from change.json, which is hand-editable, so it is checked
+// before it reaches git's argument list.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,64}$`)
+
+// CaptureCode collects the commits between base and HEAD, plus the diff
+// of the working tree against base (so uncommitted work counts), for
+// the project at projectRoot. Change artifacts under docs/changes and
+// docs/history are left out of the diff.
+func CaptureCode(projectRoot, base string) (*CodeSummary, error) {
+	if base == "" {
+		return nil, fmt.Errorf("no base commit recorded for this change")
+	}
+	if !commitSHAPattern.MatchString(base) {
+		return nil, fmt.Errorf("invalid base commit %q: must be a commit SHA", base)
+	}
+
+	head, err := runGit(projectRoot, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	summary := &CodeSummary{
+		HeadCommit: strings.TrimSpace(head),
+		CapturedAt: timeNow().UTC().Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	log, err := runGit(projectRoot, "log", "--reverse", "--format=%H%x09%s", "--end-of-options", base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(log), "\n") {
+		if sha, subject, ok := strings.Cut(line, "\t"); ok {
+			summary.Commits = append(summary.Commits, Commit{SHA: sha, Subject: subject})
+		}
+	}
+
+	pathspec := codePathspec(projectRoot)
+	stat, err := runGit(projectRoot, append([]string{"diff", "--relative", "--stat", "--end-of-options", base}, pathspec...)...)
+	if err != nil {
+		return nil, err
+	}
+	summary.DiffStat = strings.TrimRight(stat, "\n")
+
+	names, err := runGit(projectRoot, append([]string{"diff", "--relative", "--name-only", "--end-of-options", base}, pathspec...)...)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := runGit(projectRoot, append([]string{"ls-files", "--others", "--exclude-standard"}, pathspec...)...)
+	if err != nil {
+		return nil, err
+	}
+	summary.Files = uniqueLines(names + "\n" + untracked)
+
+	return summary, nil
+}
+
+// RefTime returns the committer time of a git ref — typically a release
+// tag — in the project at projectRoot.
+func RefTime(projectRoot, ref string) (time.Time, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return time.Time{}, fmt.Errorf("invalid git ref %q", ref)
+	}
+	out, err := runGit(projectRoot, "log", "-1", "--format=%cI", ref, "--")
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(out))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing commit time of %s: %w", ref, err)
+	}
+	return t, nil
+}
+
+// codePathspec limits diffs to the project while excluding change
+// artifacts, which would otherwise dominate every summary.
+func codePathspec(projectRoot string) []string {
+	docs, err := filepath.Rel(projectRoot, config.DocsPath(projectRoot))
+	if err != nil {
+		docs = config.DocsDir
+	}
+	docs = filepath.ToSlash(docs)
+	return []string{"--", ".",
+		":(exclude)" + docs + "/" + ChangesDir,
+		":(exclude)" + docs + "/" + HistoryDir,
+	}
+}
+
+// uniqueLines returns the non-empty lines of s, sorted and deduplicated.
+func uniqueLines(s string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !seen[line] {
+			seen[line] = true
+			out = append(out, line)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Markdown renders the summary as a section appended to verify.md.
+func (s *CodeSummary) Markdown(base string) string {
+	var sb strings.Builder
+	sb.WriteString("## Code Changes\n\n")
+	fmt.Fprintf(&sb, "**Base:** `%s` → **Head:** `%s`\n\n", ShortSHA(base), ShortSHA(s.HeadCommit))
+
+	sb.WriteString("### Commits\n\n")
+	if len(s.Commits) == 0 {
+		sb.WriteString("_No commits since the base — changes are uncommitted._\n")
+	}
+	for _, c := range s.Commits {
+		fmt.Fprintf(&sb, "- `%s` %s\n", ShortSHA(c.SHA), c.Subject)
+	}
+
+	sb.WriteString("\n### Diffstat\n\n")
+	if s.DiffStat == "" {
+		sb.WriteString("_No differences against the base._\n")
+	} else {
+		fmt.Fprintf(&sb, "```\n%s\n```\n", s.DiffStat)
+	}
+
+	sb.WriteString("\n### Files Touched\n\n")
+	if len(s.Files) == 0 {
+		sb.WriteString("_None._\n")
+	}
+	for _, f := range s.Files {
+		fmt.Fprintf(&sb, "- `%s`\n", f)
+	}
+	return sb.String()
+}
+
+// ShortSHA abbreviates a commit SHA for display.
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
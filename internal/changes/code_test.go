@@ -0,0 +1,139 @@
+package changes
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// gitRepo creates a git repository with one commit and returns its root.
+// Skips the test when git is not installed.
+func gitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	git(t, root, "init", "-q", "-b", "main")
+	writeRepoFile(t, root, "main.go", "package main\n")
+	git(t, root, "add", ".")
+	git(t, root, "commit", "-q", "-m", "initial")
+	return root
+}
+
+// git runs a git command in dir with a fixed identity.
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeRepoFile(t *testing.T, root, name, body string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func TestCaptureCode(t *testing.T) {
+	root := gitRepo(t)
+
+	c := testChangeRecord("capture", "Capture", TypeFix, SizeSmall)
+	c.RecordBaseCommit(root)
+	if c.BaseCommit != git(t, root, "rev-parse", "HEAD") {
+		t.Fatalf("BaseCommit = %q, want HEAD", c.BaseCommit)
+	}
+
+	writeRepoFile(t, root, "handler.go", "package main\n\nfunc handle() {}\n")
+	git(t, root, "add", ".")
+	git(t, root, "commit", "-q", "-m", "Add handler")
+	writeRepoFile(t, root, "main.go", "package main\n\nfunc main() {}\n") // uncommitted
+	writeRepoFile(t, root, "new_test.go", "package main\n")               // untracked
+	writeRepoFile(t, root, "docs/changes/capture/tasks.md", "# Tasks\n")  // change artifact
+
+	summary, err := CaptureCode(root, c.BaseCommit)
+	if err != nil {
+		t.Fatalf("CaptureCode failed: %v", err)
+	}
+	if len(summary.Commits) != 1 || summary.Commits[0].Subject != "Add handler" {
+		t.Errorf("Commits = %+v", summary.Commits)
+	}
+	if want := []string{"handler.go", "main.go", "new_test.go"}; !slices.Equal(summary.Files, want) {
+		t.Errorf("Files = %v, want %v", summary.Files, want)
+	}
+	if !strings.Contains(summary.DiffStat, "handler.go") || strings.Contains(summary.DiffStat, "docs/changes") {
+		t.Errorf("DiffStat = %q", summary.DiffStat)
+	}
+
+	md := summary.Markdown(c.BaseCommit)
+	for _, want := range []string{"## Code Changes", "Add handler", "`new_test.go`", ShortSHA(c.BaseCommit)} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestCaptureCode_Errors(t *testing.T) {
+	if _, err := CaptureCode(t.TempDir(), ""); err == nil {
+		t.Error("expected error without a base commit")
+	}
+
+	orig := runGit
+	t.Cleanup(func() { runGit = orig })
+	runGit = func(string, ...string) (string, error) { return "", errors.New("git not found") }
+
+	if _, err := CaptureCode(t.TempDir(), "abc1234"); err == nil || !strings.Contains(err.Error(), "git not found") {
+		t.Errorf("err = %v, want git failure", err)
+	}
+}
+
+func TestCaptureCode_RejectsHostileBase(t *testing.T) {
+	root := gitRepo(t)
+	out := filepath.Join(t.TempDir(), "pwned")
+
+	for _, base := range []string{"--output=" + out, "-p", "HEAD~1", "main; rm -rf /", "abc..HEAD"} {
+		if _, err := CaptureCode(root, base); err == nil || !strings.Contains(err.Error(), "invalid base commit") {
+			t.Errorf("CaptureCode(%q) err = %v, want invalid base commit", base, err)
+		}
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Errorf("git wrote %s from a base_commit option", out)
+	}
+}
+
+func TestRefTime(t *testing.T) {
+	root := gitRepo(t)
+	git(t, root, "tag", "v1.0.0")
+
+	got, err := RefTime(root, "v1.0.0")
+	if err != nil {
+		t.Fatalf("RefTime failed: %v", err)
+	}
+	want, err := time.Parse(time.RFC3339, git(t, root, "log", "-1", "--format=%cI", "HEAD"))
+	if err != nil {
+		t.Fatalf("parsing commit time: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("RefTime = %s, want %s", got, want)
+	}
+
+	if _, err := RefTime(root, "v9.9.9"); err == nil {
+		t.Error("unknown tag should fail")
+	}
+	if _, err := RefTime(root, "--all"); err == nil {
+		t.Error("option-like ref should be rejected")
+	}
+}
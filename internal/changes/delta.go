@@ -0,0 +1,497 @@
+package changes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+// SpecDelta is what a change's spec.md says about the project-level
+// artifacts, written as delta sections:
+//
+//	## ADDED Requirements
+//	### Should Have
+//	- **FR-001**: Users can export reports as CSV
+//	- **NFR-001**: Exports finish within 5 seconds
+//
+//	## MODIFIED Requirements
+//	- **FR-004**: Users can log in with email or SSO
+//
+//	## REMOVED Requirements
+//	- **FR-009**: Replaced by SSO login
+//
+//	## ADDED Business Rules
+//	- When an export exceeds 10k rows Then it runs in the background
+//
+// IDs under ADDED are local to the change; project IDs are allocated when
+// the delta is applied. MODIFIED and REMOVED refer to project IDs.
+type SpecDelta struct {
+	Added      []DeltaRequirement
+	Modified   []DeltaRequirement
+	Removed    []DeltaRequirement
+	AddedRules []string
+}
+
+// DeltaRequirement is one requirement line of a delta section.
+type DeltaRequirement struct {
+	ID       string // FR-001, NFR-002, ...
+	Text     string // new text; for REMOVED, an optional reason
+	Priority string // ADDED only: the "### Should Have"-style subsection, if any
+}
+
+// Empty reports whether the delta changes nothing.
+func (d *SpecDelta) Empty() bool {
+	return d == nil || len(d.Added)+len(d.Modified)+len(d.Removed)+len(d.AddedRules) == 0
+}
+
+// SpecMerge records a delta applied to the project artifacts, kept in
+// change.json.
+type SpecMerge struct {
+	Added      []IDMapping `json:"added,omitempty"`
+	Modified   []string    `json:"modified,omitempty"`
+	Removed    []string    `json:"removed,omitempty"`
+	RulesAdded int         `json:"business_rules_added,omitempty"`
+	AppliedAt  string      `json:"applied_at"`
+}
+
+// IDMapping pairs a requirement's change-local ID with the project ID it
+// was given.
+type IDMapping struct {
+	Local   string `json:"local"`
+	Project string `json:"project"`
+}
+
+var (
+	// deltaHeadingPattern matches "## ADDED Requirements" and friends.
+	deltaHeadingPattern = regexp.MustCompile(`(?i)^(#{2,3})\s+(added|modified|removed)\s+(requirements|business rules)\s*$`)
+	// deltaRequirementPattern matches "- **FR-001**: text" (colon inside or outside the bold).
+	deltaRequirementPattern = regexp.MustCompile(`^\s*[-*]\s+\*\*(N?FR)-([A-Za-z0-9]+):?\*\*:?\s*(.*)$`)
+	// listItemPattern matches any list item.
+	listItemPattern = regexp.MustCompile(`^\s*[-*]\s+(.+)$`)
+	// attributionPattern matches the trailing "_(added by `id`)_" notes.
+	attributionPattern = regexp.MustCompile(`(?:\s+_\((?:added|modified|removed) by [^)]*\)_)+\s*$`)
+	// markdownHeadingPattern matches any markdown heading.
+	markdownHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*$`)
+)
+
+// ParseSpecDelta extracts the delta sections of a spec artifact. Content
+// outside them is ignored; a spec without delta sections yields an empty
+// delta.
+func ParseSpecDelta(content string) *SpecDelta {
+	d := &SpecDelta{}
+	var action, target, priority string
+	level := 0
+	for _, line := range strings.Split(content, "\n") {
+		if m := deltaHeadingPattern.FindStringSubmatch(line); m != nil {
+			action, target = strings.ToLower(m[2]), strings.ToLower(m[3])
+			level, priority = len(m[1]), ""
+			continue
+		}
+		if m := markdownHeadingPattern.FindStringSubmatch(line); m != nil {
+			if len(m[1]) <= level {
+				action, target, level = "", "", 0
+			} else {
+				priority = m[2]
+			}
+			continue
+		}
+		if action == "" {
+			continue
+		}
+
+		if target == "business rules" {
+			if m := listItemPattern.FindStringSubmatch(line); m != nil && action == "added" {
+				d.AddedRules = append(d.AddedRules, strings.TrimSpace(m[1]))
+			}
+			continue
+		}
+		m := deltaRequirementPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		req := DeltaRequirement{ID: m[1] + "-" + m[2], Text: strings.TrimSpace(m[3])}
+		switch action {
+		case "added":
+			req.Priority = priority
+			d.Added = append(d.Added, req)
+		case "modified":
+			d.Modified = append(d.Modified, req)
+		case "removed":
+			d.Removed = append(d.Removed, req)
+		}
+	}
+	return d
+}
+
+// DeltaPlan is a spec delta resolved against the project artifacts:
+// the new file contents, ready to preview or apply.
+type DeltaPlan struct {
+	Merge    SpecMerge
+	Warnings []string
+	patches  []filePatch
+}
+
+// filePatch is the planned content of one project artifact.
+type filePatch struct {
+	path          string
+	before, after string
+	existed       bool // set by Write, for rollback
+}
+
+// PlanSpecDelta reads the change's spec artifact and plans its delta
+// against docs/requirements.md and docs/business-rules.md. Returns nil
+// when the spec has no delta sections, or when the delta was already
+// applied.
+func PlanSpecDelta(projectRoot string, change *ChangeRecord) (*DeltaPlan, error) {
+	if change.SpecMerge != nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(ChangePath(projectRoot, change.ID), StageFilename(StageSpec)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+	delta := ParseSpecDelta(string(data))
+	if delta.Empty() {
+		return nil, nil
+	}
+
+	plan := &DeltaPlan{}
+	if len(delta.Added)+len(delta.Modified)+len(delta.Removed) > 0 {
+		if err := plan.planRequirements(config.StagePath(projectRoot, config.StageSpecify), change.ID, delta); err != nil {
+			return nil, err
+		}
+	}
+	if len(delta.AddedRules) > 0 {
+		if err := plan.planRules(config.StagePath(projectRoot, config.StageBusinessRules), change.ID, delta.AddedRules); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// planRequirements applies the requirement delta to requirements.md,
+// creating the file when the delta only adds.
+func (p *DeltaPlan) planRequirements(path, changeID string, delta *SpecDelta) error {
+	before, err := readArtifact(path)
+	if err != nil {
+		return err
+	}
+	content := before
+	if content == "" {
+		content = "# Requirements\n"
+	}
+	lines := splitLines(content)
+
+	for _, req := range delta.Modified {
+		i := findRequirement(lines, req.ID)
+		if i < 0 {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("MODIFIED %s is not in requirements.md — skipped", req.ID))
+			continue
+		}
+		prefix, _, notes := splitRequirement(lines[i], req.ID)
+		lines[i] = fmt.Sprintf("%s**%s**: %s%s _(modified by `%s`)_", prefix, req.ID, req.Text, notes, changeID)
+		p.Merge.Modified = append(p.Merge.Modified, req.ID)
+	}
+
+	for _, req := range delta.Removed {
+		i := findRequirement(lines, req.ID)
+		if i < 0 {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("REMOVED %s is not in requirements.md — skipped", req.ID))
+			continue
+		}
+		prefix, text, notes := splitRequirement(lines[i], req.ID)
+		reason := ""
+		if req.Text != "" {
+			reason = ": " + req.Text
+		}
+		lines[i] = fmt.Sprintf("%s~~**%s**: %s~~%s _(removed by `%s`%s)_", prefix, req.ID, text, notes, changeID, reason)
+		p.Merge.Removed = append(p.Merge.Removed, req.ID)
+	}
+
+	next := map[string]int{"FR": maxRequirementNumber(lines, "FR") + 1, "NFR": maxRequirementNumber(lines, "NFR") + 1}
+	for _, req := range delta.Added {
+		kind, _, _ := strings.Cut(req.ID, "-")
+		id := fmt.Sprintf("%s-%03d", kind, next[kind])
+		next[kind]++
+
+		line := fmt.Sprintf("- **%s**: %s _(added by `%s`)_", id, req.Text, changeID)
+		lines = insertRequirement(lines, kind, req.Priority, line)
+		p.Merge.Added = append(p.Merge.Added, IDMapping{Local: req.ID, Project: id})
+	}
+
+	p.patches = append(p.patches, filePatch{path: path, before: before, after: strings.Join(lines, "\n") + "\n"})
+	return nil
+}
+
+// planRules appends added business rules to the Constraints section of
+// business-rules.md. Without the file there is nothing to extend.
+func (p *DeltaPlan) planRules(path, changeID string, rules []string) error {
+	before, err := readArtifact(path)
+	if err != nil {
+		return err
+	}
+	if before == "" {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("%d ADDED business rule(s) skipped — business-rules.md does not exist", len(rules)))
+		return nil
+	}
+
+	lines := splitLines(before)
+	for _, rule := range rules {
+		lines = insertUnder(lines, []string{"Constraints"}, fmt.Sprintf("- %s _(added by `%s`)_", rule, changeID))
+	}
+	p.Merge.RulesAdded = len(rules)
+	p.patches = append(p.patches, filePatch{path: path, before: before, after: strings.Join(lines, "\n") + "\n"})
+	return nil
+}
+
+// Diff renders the planned edits as unified diffs, paths relative to
+// projectRoot.
+func (p *DeltaPlan) Diff(projectRoot string) string {
+	var sb strings.Builder
+	for _, fp := range p.patches {
+		name, err := filepath.Rel(projectRoot, fp.path)
+		if err != nil {
+			name = fp.path
+		}
+		sb.WriteString(UnifiedDiff(filepath.ToSlash(name), fp.before, fp.after))
+	}
+	return sb.String()
+}
+
+// Apply writes the planned artifacts and records the merge on the change.
+func (p *DeltaPlan) Apply(change *ChangeRecord) error {
+	if err := p.Write(); err != nil {
+		return err
+	}
+	p.Record(change)
+	return nil
+}
+
+// Record marks the delta as merged on the change (SpecMerge), so a later
+// completion does not apply it again. Callers persisting the change
+// should save it before Write: a merge written but not recorded would be
+// applied twice, allocating new IDs.
+func (p *DeltaPlan) Record(change *ChangeRecord) {
+	merge := p.Merge
+	merge.AppliedAt = timeNow().UTC().Format("2006-01-02T15:04:05Z07:00")
+	change.SpecMerge = &merge
+}
+
+// Write writes the planned artifacts all or nothing: each file goes to a
+// temp file next to it, and only once every one is written are they
+// renamed into place. If a rename fails, the files already replaced are
+// restored.
+func (p *DeltaPlan) Write() error {
+	temps := make([]string, len(p.patches))
+	cleanup := func() {
+		for _, tmp := range temps {
+			if tmp != "" {
+				_ = os.Remove(tmp)
+			}
+		}
+	}
+	for i, fp := range p.patches {
+		tmp, err := writeTemp(fp.path, fp.after)
+		if err != nil {
+			cleanup()
+			return err
+		}
+		temps[i] = tmp
+	}
+
+	for i, fp := range p.patches {
+		_, statErr := os.Stat(fp.path)
+		existed := statErr == nil
+		if err := os.Rename(temps[i], fp.path); err != nil {
+			cleanup()
+			p.restore(i)
+			return fmt.Errorf("writing %s: %w", filepath.Base(fp.path), err)
+		}
+		temps[i] = ""
+		p.patches[i].existed = existed
+	}
+	return nil
+}
+
+// restore puts back the original content of the first n patched files.
+func (p *DeltaPlan) restore(n int) {
+	for _, fp := range p.patches[:n] {
+		if fp.existed {
+			_ = os.WriteFile(fp.path, []byte(fp.before), 0o644)
+		} else {
+			_ = os.Remove(fp.path)
+		}
+	}
+}
+
+// writeTemp writes content to a temp file in path's directory, creating
+// the directory if needed, and returns the temp file's name.
+func writeTemp(path, content string) (string, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating docs directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("writing %s: %w", filepath.Base(path), err)
+	}
+	_, err = f.WriteString(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0o644)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("writing %s: %w", filepath.Base(path), err)
+	}
+	return f.Name(), nil
+}
+
+// readArtifact returns a file's content, or "" when it doesn't exist.
+func readArtifact(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+	return string(data), nil
+}
+
+// requirementLinePattern returns a pattern for the live (not struck)
+// definition of id: prefix, text.
+func requirementLinePattern(id string) *regexp.Regexp {
+	return regexp.MustCompile(`^(\s*[-*]\s+)\*\*` + regexp.QuoteMeta(id) + `:?\*\*:?\s*(.*)$`)
+}
+
+// findRequirement returns the line index defining id, or -1.
+func findRequirement(lines []string, id string) int {
+	pattern := requirementLinePattern(id)
+	for i, line := range lines {
+		if pattern.MatchString(line) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitRequirement splits a requirement line into its list prefix, its
+// text, and any trailing attribution notes.
+func splitRequirement(line, id string) (prefix, text, notes string) {
+	m := requirementLinePattern(id).FindStringSubmatch(line)
+	text = m[2]
+	if loc := attributionPattern.FindStringIndex(text); loc != nil {
+		notes = text[loc[0]:]
+		text = text[:loc[0]]
+	}
+	return m[1], strings.TrimSpace(text), strings.TrimRight(notes, " ")
+}
+
+// maxRequirementNumber returns the highest number used by kind ("FR" or
+// "NFR") anywhere in the artifact, removed requirements included.
+func maxRequirementNumber(lines []string, kind string) int {
+	pattern := regexp.MustCompile(`\b` + kind + `-(\d+)\b`)
+	highest := 0
+	for _, line := range lines {
+		for _, m := range pattern.FindAllStringSubmatch(line, -1) {
+			if n, err := strconv.Atoi(m[1]); err == nil && n > highest {
+				highest = n
+			}
+		}
+	}
+	return highest
+}
+
+// insertRequirement places a new requirement line: functional ones under
+// their priority subsection (default Must Have) of "Functional
+// Requirements", non-functional ones under "Non-Functional Requirements".
+func insertRequirement(lines []string, kind, priority, line string) []string {
+	if kind == "NFR" {
+		return insertUnder(lines, []string{"Non-Functional Requirements"}, line)
+	}
+	if priority == "" {
+		priority = "Must Have"
+	}
+	return insertUnder(lines, []string{"Functional Requirements", priority}, line)
+}
+
+// insertUnder appends line at the end of the section reached by the
+// heading path (each heading nested in the previous one), creating
+// missing headings at the end of their parent.
+func insertUnder(lines []string, path []string, line string) []string {
+	start, end, level := -1, len(lines), 1
+	for k, title := range path {
+		i := findHeading(lines, start+1, end, title)
+		if i < 0 {
+			var block []string
+			for n, missing := range path[k:] {
+				block = append(block, "", strings.Repeat("#", level+1+n)+" "+missing)
+			}
+			block = append(block, "", line)
+			return insertLines(lines, trimmedEnd(lines, start+1, end), block)
+		}
+		start, level = i, headingLevel(lines[i])
+		end = sectionEnd(lines, i)
+	}
+	at := trimmedEnd(lines, start+1, end)
+	if at == start+1 {
+		return insertLines(lines, at, []string{"", line})
+	}
+	return insertLines(lines, at, []string{line})
+}
+
+// findHeading returns the index of the heading titled title (case
+// insensitive) within lines[from:to], or -1.
+func findHeading(lines []string, from, to int, title string) int {
+	for i := from; i < to; i++ {
+		if m := markdownHeadingPattern.FindStringSubmatch(lines[i]); m != nil && strings.EqualFold(m[2], title) {
+			return i
+		}
+	}
+	return -1
+}
+
+// headingLevel returns the number of leading #s of a heading line.
+func headingLevel(line string) int {
+	return len(line) - len(strings.TrimLeft(line, "#"))
+}
+
+// sectionEnd returns the index of the next heading at the same or a
+// higher level than the heading at i, or len(lines).
+func sectionEnd(lines []string, i int) int {
+	level := headingLevel(lines[i])
+	for j := i + 1; j < len(lines); j++ {
+		if m := markdownHeadingPattern.FindStringSubmatch(lines[j]); m != nil && len(m[1]) <= level {
+			return j
+		}
+	}
+	return len(lines)
+}
+
+// trimmedEnd returns the index just past the last non-blank line in
+// lines[from:to], or from when the range is blank.
+func trimmedEnd(lines []string, from, to int) int {
+	for to > from && strings.TrimSpace(lines[to-1]) == "" {
+		to--
+	}
+	return to
+}
+
+// insertLines inserts block at index at.
+func insertLines(lines []string, at int, block []string) []string {
+	out := make([]string, 0, len(lines)+len(block))
+	out = append(out, lines[:at]...)
+	out = append(out, block...)
+	return append(out, lines[at:]...)
+}
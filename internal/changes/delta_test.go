@@ -0,0 +1,259 @@
+package changes
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+const deltaSpec = `# Spec
+
+Context that is not part of the delta: FR-900 stays local.
+
+## ADDED Requirements
+
+- **FR-001**: Users can export reports as CSV
+### Should Have
+- **FR-002**: Users can schedule exports
+- **NFR-001**: Exports finish within 5 seconds
+
+## MODIFIED Requirements
+
+- **FR-002**: Users can log in with email or SSO
+
+## REMOVED Requirements
+
+- **FR-003**: Replaced by SSO login
+- **FR-042**
+
+## ADDED Business Rules
+
+- When an export exceeds 10k rows Then it runs in the background
+
+## Notes
+
+- **FR-777**: not a delta item
+`
+
+const projectRequirements = `# Demo — Requirements
+
+## Functional Requirements
+
+### Must Have
+
+- **FR-001**: Users can sign up _(added by ` + "`bootstrap`" + `)_
+- **FR-002**: Users can log in with email
+- **FR-003**: Users can log in with a magic link
+
+### Should Have
+
+- **FR-004**: Users can reset their password
+
+## Non-Functional Requirements
+
+- **NFR-001**: p95 latency under 200ms
+`
+
+func TestParseSpecDelta(t *testing.T) {
+	d := ParseSpecDelta(deltaSpec)
+
+	if len(d.Added) != 3 || d.Added[0] != (DeltaRequirement{ID: "FR-001", Text: "Users can export reports as CSV"}) ||
+		d.Added[1].Priority != "Should Have" || d.Added[2].ID != "NFR-001" {
+		t.Errorf("Added = %+v", d.Added)
+	}
+	if len(d.Modified) != 1 || d.Modified[0].ID != "FR-002" {
+		t.Errorf("Modified = %+v", d.Modified)
+	}
+	if len(d.Removed) != 2 || d.Removed[0].Text != "Replaced by SSO login" || d.Removed[1] != (DeltaRequirement{ID: "FR-042"}) {
+		t.Errorf("Removed = %+v", d.Removed)
+	}
+	if len(d.AddedRules) != 1 || !strings.HasPrefix(d.AddedRules[0], "When an export") {
+		t.Errorf("AddedRules = %+v", d.AddedRules)
+	}
+
+	if !ParseSpecDelta("- **FR-001**: plain spec, no delta sections").Empty() {
+		t.Error("a spec without delta sections should yield an empty delta")
+	}
+}
+
+// deltaProject writes the project artifacts and a change with deltaSpec.
+func deltaProject(t *testing.T, rules string) (string, *ChangeRecord) {
+	t.Helper()
+	root := t.TempDir()
+	writeRepoFile(t, root, "docs/requirements.md", projectRequirements)
+	if rules != "" {
+		writeRepoFile(t, root, "docs/business-rules.md", rules)
+	}
+	change := testChangeRecord("add-export", "Add export", TypeFeature, SizeMedium)
+	writeRepoFile(t, root, filepath.Join(config.DocsDir, ChangesDir, change.ID, StageFilename(StageSpec)), deltaSpec)
+	return root, change
+}
+
+func TestPlanSpecDelta_ApplyRequirements(t *testing.T) {
+	root, change := deltaProject(t, "# Rules\n\n## Constraints\n\n- When a user is locked Then deny login\n\n## Derivations\n\n- Age is derived\n")
+
+	plan, err := PlanSpecDelta(root, change)
+	if err != nil || plan == nil {
+		t.Fatalf("PlanSpecDelta = %v, %v", plan, err)
+	}
+	if len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], "REMOVED FR-042") {
+		t.Errorf("Warnings = %v", plan.Warnings)
+	}
+
+	diff := plan.Diff(root)
+	for _, want := range []string{
+		"--- docs/requirements.md",
+		"-- **FR-002**: Users can log in with email\n",
+		"+- **FR-002**: Users can log in with email or SSO _(modified by `add-export`)_\n",
+		"+- ~~**FR-003**: Users can log in with a magic link~~ _(removed by `add-export`: Replaced by SSO login)_",
+		"--- docs/business-rules.md",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff missing %q:\n%s", want, diff)
+		}
+	}
+	// Previewing writes nothing.
+	if data, _ := os.ReadFile(filepath.Join(root, "docs", "requirements.md")); string(data) != projectRequirements {
+		t.Error("Diff must not modify requirements.md")
+	}
+
+	if err := plan.Apply(change); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	want := `# Demo — Requirements
+
+## Functional Requirements
+
+### Must Have
+
+- **FR-001**: Users can sign up _(added by ` + "`bootstrap`" + `)_
+- **FR-002**: Users can log in with email or SSO _(modified by ` + "`add-export`" + `)_
+- ~~**FR-003**: Users can log in with a magic link~~ _(removed by ` + "`add-export`" + `: Replaced by SSO login)_
+- **FR-005**: Users can export reports as CSV _(added by ` + "`add-export`" + `)_
+
+### Should Have
+
+- **FR-004**: Users can reset their password
+- **FR-006**: Users can schedule exports _(added by ` + "`add-export`" + `)_
+
+## Non-Functional Requirements
+
+- **NFR-001**: p95 latency under 200ms
+- **NFR-002**: Exports finish within 5 seconds _(added by ` + "`add-export`" + `)_
+`
+	if data, _ := os.ReadFile(filepath.Join(root, "docs", "requirements.md")); string(data) != want {
+		t.Errorf("requirements.md =\n%s\nwant:\n%s", data, want)
+	}
+	rules, _ := os.ReadFile(filepath.Join(root, "docs", "business-rules.md"))
+	if !strings.Contains(string(rules), "- When a user is locked Then deny login\n- When an export exceeds 10k rows Then it runs in the background _(added by `add-export`)_\n\n## Derivations") {
+		t.Errorf("business-rules.md =\n%s", rules)
+	}
+
+	m := change.SpecMerge
+	if m == nil || m.AppliedAt == "" || m.RulesAdded != 1 ||
+		!slices.Equal(m.Modified, []string{"FR-002"}) || !slices.Equal(m.Removed, []string{"FR-003"}) ||
+		!slices.Equal(m.Added, []IDMapping{{"FR-001", "FR-005"}, {"FR-002", "FR-006"}, {"NFR-001", "NFR-002"}}) {
+		t.Errorf("SpecMerge = %+v", m)
+	}
+
+	// Applied once: planning again is a no-op.
+	if again, err := PlanSpecDelta(root, change); again != nil || err != nil {
+		t.Errorf("second PlanSpecDelta = %+v, %v; want nil", again, err)
+	}
+}
+
+func TestDeltaPlan_WriteRollsBack(t *testing.T) {
+	root, change := deltaProject(t, "# Rules\n\n## Constraints\n\n- When a user is locked Then deny login\n")
+	plan, err := PlanSpecDelta(root, change)
+	if err != nil || plan == nil {
+		t.Fatalf("PlanSpecDelta = %v, %v", plan, err)
+	}
+
+	// business-rules.md can't be replaced: requirements.md, written
+	// first, must be put back.
+	rulesPath := filepath.Join(root, "docs", "business-rules.md")
+	if err := os.Remove(rulesPath); err != nil {
+		t.Fatal(err)
+	}
+	writeRepoFile(t, root, "docs/business-rules.md/keep", "x")
+
+	if err := plan.Apply(change); err == nil {
+		t.Fatal("Apply should fail")
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "docs", "requirements.md")); string(data) != projectRequirements {
+		t.Errorf("requirements.md not rolled back:\n%s", data)
+	}
+	if change.SpecMerge != nil {
+		t.Error("a failed Apply must not record the merge")
+	}
+	entries, _ := os.ReadDir(filepath.Join(root, "docs"))
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temp file left behind: %s", e.Name())
+		}
+	}
+}
+
+func TestPlanSpecDelta_MissingArtifacts(t *testing.T) {
+	root := t.TempDir()
+	change := testChangeRecord("add-export", "Add export", TypeFeature, SizeMedium)
+	writeRepoFile(t, root, filepath.Join(config.DocsDir, ChangesDir, change.ID, StageFilename(StageSpec)),
+		"## ADDED Requirements\n\n- **FR-001**: Export CSV\n\n## ADDED Business Rules\n\n- When x Then y\n")
+
+	plan, err := PlanSpecDelta(root, change)
+	if err != nil {
+		t.Fatalf("PlanSpecDelta failed: %v", err)
+	}
+	if len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], "business-rules.md does not exist") {
+		t.Errorf("Warnings = %v", plan.Warnings)
+	}
+	if err := plan.Apply(change); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(root, "docs", "requirements.md"))
+	want := "# Requirements\n\n## Functional Requirements\n\n### Must Have\n\n- **FR-001**: Export CSV _(added by `add-export`)_\n"
+	if string(data) != want {
+		t.Errorf("requirements.md =\n%q\nwant:\n%q", data, want)
+	}
+}
+
+func TestPlanSpecDelta_NoDelta(t *testing.T) {
+	root := t.TempDir()
+	change := testChangeRecord("fix-typo", "Fix typo", TypeFix, SizeSmall)
+	if plan, err := PlanSpecDelta(root, change); plan != nil || err != nil {
+		t.Errorf("without spec.md: %+v, %v", plan, err)
+	}
+	writeRepoFile(t, root, filepath.Join(config.DocsDir, ChangesDir, change.ID, StageFilename(StageSpec)), "- **FR-001**: local only\n")
+	if plan, err := PlanSpecDelta(root, change); plan != nil || err != nil {
+		t.Errorf("without delta sections: %+v, %v", plan, err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\n"
+	after := "a\nb\nC\nd\ne\nf\ng\nh\ni\nj\n"
+	want := `--- x.md
++++ x.md
+@@ -1,5 +1,5 @@
+ a
+ b
+-c
++C
+ d
+ e
+@@ -8,2 +8,3 @@
+ h
+ i
++j
+`
+	if got := UnifiedDiff("x.md", before, after); got != want {
+		t.Errorf("UnifiedDiff =\n%s\nwant:\n%s", got, want)
+	}
+	if UnifiedDiff("x.md", before, before) != "" {
+		t.Error("identical texts should produce no diff")
+	}
+}
@@ -0,0 +1,281 @@
+package changes
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Landed reports whether the change's work is done: completed, or
+// completed and archived.
+func (c *ChangeRecord) Landed() bool {
+	return c.Status == StatusCompleted || c.Status == StatusArchived
+}
+
+// prerequisiteMap returns change ID → the IDs that must land before it,
+// combining each change's depends_on with the blocks links declared on
+// the other side.
+func prerequisiteMap(all []ChangeRecord) map[string][]string {
+	pre := make(map[string][]string, len(all))
+	add := func(id, dep string) {
+		if !slices.Contains(pre[id], dep) {
+			pre[id] = append(pre[id], dep)
+		}
+	}
+	for _, c := range all {
+		for _, dep := range c.DependsOn {
+			add(c.ID, dep)
+		}
+		for _, blocked := range c.Blocks {
+			add(blocked, c.ID)
+		}
+	}
+	for id := range pre {
+		sort.Strings(pre[id])
+	}
+	return pre
+}
+
+// Prerequisites returns the IDs of the changes that must land before
+// change: the ones it depends on and the ones that declare they block it.
+func Prerequisites(change *ChangeRecord, all []ChangeRecord) []string {
+	return prerequisiteMap(withChange(all, change))[change.ID]
+}
+
+// PendingPrerequisites returns the prerequisites of change that haven't
+// landed yet, abandoned ones included — they will never land on their
+// own. Links to unknown changes are skipped.
+func PendingPrerequisites(change *ChangeRecord, all []ChangeRecord) []ChangeRecord {
+	var pending []ChangeRecord
+	for _, id := range Prerequisites(change, all) {
+		if i := slices.IndexFunc(all, func(c ChangeRecord) bool { return c.ID == id }); i >= 0 && !all[i].Landed() {
+			pending = append(pending, all[i])
+		}
+	}
+	return pending
+}
+
+// ValidateLinks checks change's depends_on and blocks links against all
+// known changes: every linked ID must exist, a change can't link to
+// itself, and the links of all changes together must not form a cycle.
+func ValidateLinks(change *ChangeRecord, all []ChangeRecord) error {
+	var problems []string
+	for _, links := range []struct {
+		verb string
+		ids  []string
+	}{{"depends on", change.DependsOn}, {"blocks", change.Blocks}} {
+		for _, id := range links.ids {
+			switch {
+			case id == change.ID:
+				problems = append(problems, fmt.Sprintf("%s %s itself", change.ID, links.verb))
+			case !slices.ContainsFunc(all, func(c ChangeRecord) bool { return c.ID == id }):
+				problems = append(problems, fmt.Sprintf("%s %s %s, which does not exist", change.ID, links.verb, id))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid change links: %s", strings.Join(problems, "; "))
+	}
+	if cycle := findLinkCycle(prerequisiteMap(withChange(all, change))); cycle != "" {
+		return fmt.Errorf("invalid change links: dependency cycle %s", cycle)
+	}
+	return nil
+}
+
+// Link adds depends_on and blocks links to change, validated with
+// ValidateLinks against all known changes. Links already present are
+// kept once. On error the change is left as it was.
+func Link(change *ChangeRecord, all []ChangeRecord, dependsOn, blocks []string) error {
+	if len(dependsOn)+len(blocks) == 0 {
+		return fmt.Errorf("no links given: pass depends_on and/or blocks")
+	}
+	prevDeps, prevBlocks := change.DependsOn, change.Blocks
+	change.DependsOn = appendUnique(slices.Clone(prevDeps), dependsOn)
+	change.Blocks = appendUnique(slices.Clone(prevBlocks), blocks)
+	if err := ValidateLinks(change, all); err != nil {
+		change.DependsOn, change.Blocks = prevDeps, prevBlocks
+		return err
+	}
+	return nil
+}
+
+// Unlink removes depends_on and blocks links from change. Links to
+// changes that no longer exist can be removed too. Every ID must be
+// linked on change itself; a blocks link declared by the other change
+// has to be removed there.
+func Unlink(change *ChangeRecord, dependsOn, blocks []string) error {
+	if len(dependsOn)+len(blocks) == 0 {
+		return fmt.Errorf("no links given: pass depends_on and/or blocks")
+	}
+	var problems []string
+	for _, id := range dependsOn {
+		if !slices.Contains(change.DependsOn, id) {
+			problems = append(problems, fmt.Sprintf("%s does not depend on %s", change.ID, id))
+		}
+	}
+	for _, id := range blocks {
+		if !slices.Contains(change.Blocks, id) {
+			problems = append(problems, fmt.Sprintf("%s does not block %s", change.ID, id))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("cannot unlink: %s", strings.Join(problems, "; "))
+	}
+	change.DependsOn = slices.DeleteFunc(slices.Clone(change.DependsOn), func(id string) bool { return slices.Contains(dependsOn, id) })
+	change.Blocks = slices.DeleteFunc(slices.Clone(change.Blocks), func(id string) bool { return slices.Contains(blocks, id) })
+	return nil
+}
+
+// appendUnique appends the ids not already in list.
+func appendUnique(list, ids []string) []string {
+	for _, id := range ids {
+		if !slices.Contains(list, id) {
+			list = append(list, id)
+		}
+	}
+	return list
+}
+
+// withChange returns all with change in place of the record with the
+// same ID, or appended when it isn't there yet.
+func withChange(all []ChangeRecord, change *ChangeRecord) []ChangeRecord {
+	merged := make([]ChangeRecord, 0, len(all)+1)
+	for _, c := range all {
+		if c.ID != change.ID {
+			merged = append(merged, c)
+		}
+	}
+	return append(merged, *change)
+}
+
+// findLinkCycle returns one cycle in the prerequisite map, in landing
+// order ("a → b → a"), or "" when there is none.
+func findLinkCycle(pre map[string][]string) string {
+	ids := make([]string, 0, len(pre))
+	for id := range pre {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	state := map[string]int{} // 0 unvisited, 1 on stack, 2 done
+	var stack, cycle []string
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		state[id] = 1
+		stack = append(stack, id)
+		for _, dep := range pre[id] {
+			if state[dep] == 1 {
+				start := slices.Index(stack, dep)
+				cycle = append(append([]string(nil), stack[start:]...), dep)
+				return true
+			}
+			if state[dep] == 0 && visit(dep) {
+				return true
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = 2
+		return false
+	}
+	for _, id := range ids {
+		if state[id] == 0 && visit(id) {
+			break
+		}
+	}
+	// Edges point at prerequisites; show them in landing order.
+	slices.Reverse(cycle)
+	return strings.Join(cycle, " → ")
+}
+
+// QueueEntry is one change waiting to land.
+type QueueEntry struct {
+	Change ChangeRecord
+
+	// Level is the change's depth in the dependency DAG: 1 when no
+	// pending change comes before it, one more than its deepest pending
+	// prerequisite otherwise.
+	Level int
+
+	// Waiting lists the prerequisites that haven't landed.
+	Waiting []ChangeRecord
+}
+
+// Queue orders the changes that are still to land — active and paused
+// ones — so that each comes after its pending prerequisites, by level
+// and then by creation time. Changes caught in a cycle (only possible in
+// hand-edited change.json files) are listed last.
+func Queue(all []ChangeRecord) []QueueEntry {
+	pre := prerequisiteMap(all)
+	byID := make(map[string]ChangeRecord, len(all))
+	for _, c := range all {
+		byID[c.ID] = c
+	}
+
+	var entries []QueueEntry
+	for _, c := range all {
+		if c.Status != StatusActive && c.Status != StatusPaused {
+			continue
+		}
+		entry := QueueEntry{Change: c}
+		for _, id := range pre[c.ID] {
+			if dep, ok := byID[id]; ok && !dep.Landed() {
+				entry.Waiting = append(entry.Waiting, dep)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	// Levels by repeated relaxation; a change in a cycle never settles
+	// and keeps level 0.
+	level := map[string]int{}
+	for settled := true; ; settled = true {
+		for _, e := range entries {
+			if level[e.Change.ID] > 0 {
+				continue
+			}
+			l := 1
+			for _, dep := range e.Waiting {
+				if dep.Status != StatusActive && dep.Status != StatusPaused {
+					continue // abandoned: nothing to wait for in the queue
+				}
+				if level[dep.ID] == 0 {
+					l = 0
+					break
+				}
+				l = max(l, level[dep.ID]+1)
+			}
+			if l > 0 {
+				level[e.Change.ID] = l
+				settled = false
+			}
+		}
+		if settled {
+			break
+		}
+	}
+	for i := range entries {
+		entries[i].Level = level[entries[i].Change.ID]
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		li, lj := entries[i].Level, entries[j].Level
+		if li != lj {
+			return li != 0 && (lj == 0 || li < lj)
+		}
+		return entries[i].Change.CreatedAt < entries[j].Change.CreatedAt
+	})
+	return entries
+}
+
+// ParseLinks splits a comma-separated list of change IDs, trimming
+// spaces and dropping empty entries and duplicates.
+func ParseLinks(s string) []string {
+	var ids []string
+	for _, part := range strings.Split(s, ",") {
+		if id := strings.TrimSpace(part); id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
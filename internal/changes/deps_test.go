@@ -0,0 +1,134 @@
+package changes
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// linked returns a change record for link tests.
+func linked(id string, status ChangeStatus, createdAt string, dependsOn, blocks []string) ChangeRecord {
+	return ChangeRecord{ID: id, Status: status, CreatedAt: createdAt, DependsOn: dependsOn, Blocks: blocks}
+}
+
+func TestPrerequisites(t *testing.T) {
+	all := []ChangeRecord{
+		linked("schema", StatusCompleted, "1", nil, []string{"api"}),
+		linked("auth", StatusPaused, "2", nil, nil),
+		linked("api", StatusActive, "3", []string{"auth"}, nil),
+	}
+	if got := Prerequisites(&all[2], all); !slices.Equal(got, []string{"auth", "schema"}) {
+		t.Errorf("Prerequisites = %v", got)
+	}
+	pending := PendingPrerequisites(&all[2], all)
+	if len(pending) != 1 || pending[0].ID != "auth" {
+		t.Errorf("PendingPrerequisites = %+v", pending)
+	}
+}
+
+func TestValidateLinks(t *testing.T) {
+	all := []ChangeRecord{
+		linked("a", StatusPaused, "1", nil, nil),
+		linked("b", StatusPaused, "2", []string{"a"}, nil),
+	}
+
+	ok := linked("c", StatusActive, "3", []string{"b"}, []string{"a"})
+	if err := ValidateLinks(&ok, all); err == nil || !strings.Contains(err.Error(), "dependency cycle a → b → c → a") {
+		t.Errorf("cycle through blocks: %v", err)
+	}
+
+	ok = linked("c", StatusActive, "3", []string{"b"}, nil)
+	if err := ValidateLinks(&ok, all); err != nil {
+		t.Errorf("valid links: %v", err)
+	}
+
+	bad := linked("c", StatusActive, "3", []string{"c", "missing"}, nil)
+	err := ValidateLinks(&bad, all)
+	if err == nil || !strings.Contains(err.Error(), "c depends on itself") ||
+		!strings.Contains(err.Error(), "c depends on missing, which does not exist") {
+		t.Errorf("bad links: %v", err)
+	}
+}
+
+func TestLinkAndUnlink(t *testing.T) {
+	all := []ChangeRecord{
+		linked("a", StatusActive, "1", nil, nil),
+		linked("b", StatusPaused, "2", []string{"a"}, nil),
+		linked("c", StatusPaused, "3", nil, nil),
+	}
+	a := &all[0]
+
+	// Created before c, a can still be made to wait for it.
+	if err := Link(a, all, []string{"c", "c"}, nil); err != nil {
+		t.Fatalf("Link failed: %v", err)
+	}
+	if !slices.Equal(a.DependsOn, []string{"c"}) {
+		t.Errorf("DependsOn = %v", a.DependsOn)
+	}
+
+	// Refused links leave the change untouched.
+	if err := Link(a, all, []string{"b"}, []string{"ghost"}); err == nil ||
+		!strings.Contains(err.Error(), "a blocks ghost, which does not exist") {
+		t.Errorf("unknown ID: %v", err)
+	}
+	if err := Link(a, all, []string{"b"}, nil); err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("cycle: %v", err)
+	}
+	if !slices.Equal(a.DependsOn, []string{"c"}) || len(a.Blocks) != 0 {
+		t.Errorf("refused Link changed the record: %+v", a)
+	}
+
+	// A link to a change that no longer exists can be removed.
+	a.Blocks = []string{"deleted"}
+	if err := Unlink(a, []string{"c"}, []string{"deleted"}); err != nil {
+		t.Fatalf("Unlink failed: %v", err)
+	}
+	if len(a.DependsOn) != 0 || len(a.Blocks) != 0 {
+		t.Errorf("after Unlink: %+v", a)
+	}
+	if err := Unlink(&all[2], []string{"a"}, nil); err == nil || !strings.Contains(err.Error(), "c does not depend on a") {
+		t.Errorf("Unlink of a missing link: %v", err)
+	}
+	if err := Link(a, all, nil, nil); err == nil {
+		t.Error("Link without IDs should fail")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	all := []ChangeRecord{
+		linked("ui", StatusPaused, "1", []string{"api"}, nil),
+		linked("api", StatusActive, "2", []string{"schema", "dropped"}, nil),
+		linked("schema", StatusPaused, "3", nil, nil),
+		linked("dropped", StatusAbandoned, "0", nil, nil),
+		linked("done", StatusArchived, "0", nil, []string{"ui"}),
+		linked("docs", StatusActive, "4", nil, nil),
+	}
+
+	queue := Queue(all)
+	var got []string
+	for _, e := range queue {
+		got = append(got, e.Change.ID)
+	}
+	if !slices.Equal(got, []string{"schema", "docs", "api", "ui"}) {
+		t.Fatalf("queue order = %v", got)
+	}
+	if queue[2].Level != 2 || queue[3].Level != 3 {
+		t.Errorf("levels = %d, %d", queue[2].Level, queue[3].Level)
+	}
+	// The abandoned prerequisite is still reported as waiting.
+	if len(queue[2].Waiting) != 2 || queue[2].Waiting[0].ID != "dropped" {
+		t.Errorf("api waiting = %+v", queue[2].Waiting)
+	}
+	if len(queue[3].Waiting) != 1 || queue[3].Waiting[0].ID != "api" {
+		t.Errorf("ui waiting = %+v", queue[3].Waiting)
+	}
+}
+
+func TestParseLinks(t *testing.T) {
+	if got := ParseLinks(" a, b,,a ,"); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("ParseLinks = %v", got)
+	}
+	if got := ParseLinks(""); got != nil {
+		t.Errorf("ParseLinks(\"\") = %v", got)
+	}
+}
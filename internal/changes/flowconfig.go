@@ -0,0 +1,232 @@
+package changes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+// FlowsFile is the optional project file, under docs/, that declares
+// extra change types and overrides stage flows.
+const FlowsFile = "hoofy-flows.json"
+
+// FlowConfig is the shape of docs/hoofy-flows.json.
+//
+//	{
+//	  "stages": {"threat-model": {"filename": "threat-model.md"}},
+//	  "types": {
+//	    "hotfix":   {"description": "urgent production fix", "extends": "fix",
+//	                 "flows": {"small": ["describe", "context-check", "verify"]}},
+//	    "security": {"extends": "fix",
+//	                 "flows": {"large": ["describe", "context-check", "threat-model", "design", "tasks", "verify"]}}
+//	  }
+//	}
+type FlowConfig struct {
+	// Stages declares custom stages, or renames a built-in stage's artifact.
+	Stages map[ChangeStage]StageConfig `json:"stages,omitempty"`
+	// Types declares new change types or overrides flows of existing ones.
+	Types map[ChangeType]TypeConfig `json:"types,omitempty"`
+}
+
+// StageConfig configures one stage in docs/hoofy-flows.json.
+type StageConfig struct {
+	// Filename of the stage artifact. Defaults to "<stage>.md".
+	Filename string `json:"filename,omitempty"`
+}
+
+// TypeConfig configures one change type in docs/hoofy-flows.json.
+type TypeConfig struct {
+	Description string `json:"description,omitempty"`
+	// Extends copies every built-in flow of a built-in type before
+	// applying Flows.
+	Extends ChangeType `json:"extends,omitempty"`
+	// Flows overrides the stage sequence per size. A new type must end up
+	// with a flow for every size.
+	Flows map[ChangeSize][]ChangeStage `json:"flows,omitempty"`
+}
+
+// namePattern restricts custom type and stage names to lowercase slugs.
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// FlowsPath returns the absolute path to docs/hoofy-flows.json.
+func FlowsPath(projectRoot string) string {
+	return filepath.Join(config.DocsPath(projectRoot), FlowsFile)
+}
+
+// LoadRegistry returns the built-in registry merged with the project's
+// docs/hoofy-flows.json. A project without the file gets the defaults.
+func LoadRegistry(projectRoot string) (*Registry, error) {
+	data, err := os.ReadFile(FlowsPath(projectRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultRegistry(), nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", FlowsFile, err)
+	}
+
+	var cfg FlowConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", FlowsFile, err)
+	}
+
+	r, err := DefaultRegistry().Merge(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", FlowsFile, err)
+	}
+	return r, nil
+}
+
+// Merge returns a new registry with cfg applied on top of r. Every flow
+// of the result must include context-check and end with verify.
+func (r *Registry) Merge(cfg FlowConfig) (*Registry, error) {
+	out := &Registry{
+		types:        append([]ChangeType(nil), r.types...),
+		descriptions: make(map[ChangeType]string, len(r.descriptions)+len(cfg.Types)),
+		bases:        make(map[ChangeType]ChangeType, len(r.bases)+len(cfg.Types)),
+		flows:        make(map[ChangeType]map[ChangeSize][]ChangeStage, len(r.flows)+len(cfg.Types)),
+		filenames:    make(map[ChangeStage]string, len(r.filenames)+len(cfg.Stages)),
+	}
+	for t, d := range r.descriptions {
+		out.descriptions[t] = d
+	}
+	for t, b := range r.bases {
+		out.bases[t] = b
+	}
+	for t, sizes := range r.flows {
+		out.flows[t] = copySizes(sizes)
+	}
+	for s, f := range r.filenames {
+		out.filenames[s] = f
+	}
+
+	if err := out.mergeStages(cfg.Stages); err != nil {
+		return nil, err
+	}
+	if err := out.mergeTypes(cfg.Types); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// mergeStages registers custom stages and filename overrides.
+func (r *Registry) mergeStages(stages map[ChangeStage]StageConfig) error {
+	for _, stage := range sortedKeys(stages) {
+		if !namePattern.MatchString(string(stage)) {
+			return fmt.Errorf("stage %q: name must be lowercase letters, digits, and hyphens", stage)
+		}
+		filename := stages[stage].Filename
+		if filename == "" {
+			filename = string(stage) + ".md"
+		}
+		if filename != filepath.Base(filename) || !strings.HasSuffix(filename, ".md") {
+			return fmt.Errorf("stage %q: filename %q must be a plain .md file name", stage, filename)
+		}
+		r.filenames[stage] = filename
+	}
+
+	seen := make(map[string]ChangeStage, len(r.filenames))
+	for _, stage := range sortedKeys(r.filenames) {
+		filename := r.filenames[stage]
+		if other, dup := seen[filename]; dup {
+			return fmt.Errorf("stages %q and %q both use %s", other, stage, filename)
+		}
+		seen[filename] = stage
+	}
+	return nil
+}
+
+// mergeTypes adds new types and overrides flows of existing ones.
+func (r *Registry) mergeTypes(types map[ChangeType]TypeConfig) error {
+	for _, t := range sortedKeys(types) {
+		tc := types[t]
+		if !namePattern.MatchString(string(t)) {
+			return fmt.Errorf("type %q: name must be lowercase letters, digits, and hyphens", t)
+		}
+
+		sizes, existing := r.flows[t]
+		if !existing {
+			sizes = map[ChangeSize][]ChangeStage{}
+		}
+		if tc.Extends != "" {
+			base, ok := FlowRegistry[tc.Extends]
+			if !ok {
+				return fmt.Errorf("type %q: extends %q, which is not a built-in type", t, tc.Extends)
+			}
+			sizes = copySizes(base)
+			if _, builtin := FlowRegistry[t]; !builtin {
+				r.bases[t] = tc.Extends
+			}
+		}
+
+		for _, size := range sortedKeys(tc.Flows) {
+			if err := ValidateSize(size); err != nil {
+				return fmt.Errorf("type %q: %w", t, err)
+			}
+			flow := tc.Flows[size]
+			if err := r.validateFlow(flow); err != nil {
+				return fmt.Errorf("type %q, size %s: %w", t, size, err)
+			}
+			sizes[size] = append([]ChangeStage(nil), flow...)
+		}
+
+		for _, size := range []ChangeSize{SizeSmall, SizeMedium, SizeLarge} {
+			if _, ok := sizes[size]; !ok {
+				return fmt.Errorf("type %q: no flow for size %s — add it to \"flows\" or set \"extends\"", t, size)
+			}
+		}
+
+		r.flows[t] = sizes
+		if tc.Description != "" {
+			r.descriptions[t] = tc.Description
+		}
+		if !existing {
+			r.types = append(r.types, t)
+		}
+	}
+	return nil
+}
+
+// validateFlow checks that a flow uses known stages once each, includes
+// context-check, and ends with verify.
+func (r *Registry) validateFlow(flow []ChangeStage) error {
+	if len(flow) == 0 {
+		return fmt.Errorf("flow is empty")
+	}
+	seen := make(map[ChangeStage]bool, len(flow))
+	for _, stage := range flow {
+		if _, ok := r.filenames[stage]; !ok {
+			return fmt.Errorf("unknown stage %q — declare it under \"stages\"", stage)
+		}
+		if seen[stage] {
+			return fmt.Errorf("stage %q appears more than once", stage)
+		}
+		seen[stage] = true
+	}
+	if !seen[StageContextCheck] {
+		return fmt.Errorf("flow must include %s", StageContextCheck)
+	}
+	if flow[len(flow)-1] != StageVerify {
+		return fmt.Errorf("flow must end with %s", StageVerify)
+	}
+	return nil
+}
+
+// sortedKeys returns a map's keys in ascending order, so merge errors
+// and the order of new types are deterministic.
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,171 @@
+package changes
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeFlows writes docs/hoofy-flows.json under root.
+func writeFlows(t *testing.T, root, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(FlowsPath(root), []byte(body), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+const teamFlows = `{
+  "stages": {
+    "threat-model": {},
+    "rollback-plan": {"filename": "rollback.md"}
+  },
+  "types": {
+    "hotfix":    {"description": "urgent production fix", "extends": "fix",
+                  "flows": {"small": ["describe", "context-check", "verify"]}},
+    "spike":     {"extends": "feature"},
+    "chore":     {"extends": "refactor"},
+    "security":  {"extends": "fix",
+                  "flows": {"large": ["describe", "context-check", "threat-model", "design", "tasks", "verify"]}},
+    "migration": {"extends": "refactor",
+                  "flows": {"medium": ["scope", "context-check", "rollback-plan", "tasks", "verify"]}},
+    "feature":   {"flows": {"small": ["describe", "context-check", "spec", "tasks", "verify"]}}
+  }
+}`
+
+func TestLoadRegistry_NoFileUsesDefaults(t *testing.T) {
+	reg, err := LoadRegistry(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	if !slices.Equal(reg.Types(), builtinTypes) {
+		t.Errorf("Types() = %v, want %v", reg.Types(), builtinTypes)
+	}
+}
+
+func TestLoadRegistry_MergesProjectFlows(t *testing.T) {
+	root := t.TempDir()
+	writeFlows(t, root, teamFlows)
+
+	reg, err := LoadRegistry(root)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+
+	wantTypes := []ChangeType{TypeFeature, TypeFix, TypeRefactor, TypeEnhancement, "chore", "hotfix", "migration", "security", "spike"}
+	if !slices.Equal(reg.Types(), wantTypes) {
+		t.Errorf("Types() = %v, want %v", reg.Types(), wantTypes)
+	}
+	if err := reg.ValidateType("hotfix"); err != nil {
+		t.Errorf("hotfix should be valid: %v", err)
+	}
+	if reg.Base("hotfix") != TypeFix || reg.Base(TypeFeature) != TypeFeature || reg.Base("unknown") != "" {
+		t.Errorf("Base: hotfix=%q feature=%q unknown=%q", reg.Base("hotfix"), reg.Base(TypeFeature), reg.Base("unknown"))
+	}
+	if reg.Describe("hotfix") != "urgent production fix" {
+		t.Errorf("Describe(hotfix) = %q", reg.Describe("hotfix"))
+	}
+
+	tests := []struct {
+		t    ChangeType
+		s    ChangeSize
+		want []ChangeStage
+	}{
+		{"hotfix", SizeSmall, []ChangeStage{StageDescribe, StageContextCheck, StageVerify}},
+		{"hotfix", SizeLarge, FlowRegistry[TypeFix][SizeLarge]}, // inherited
+		{"security", SizeLarge, []ChangeStage{StageDescribe, StageContextCheck, "threat-model", StageDesign, StageTasks, StageVerify}},
+		{"migration", SizeMedium, []ChangeStage{StageScope, StageContextCheck, "rollback-plan", StageTasks, StageVerify}},
+		{TypeFeature, SizeSmall, []ChangeStage{StageDescribe, StageContextCheck, StageSpec, StageTasks, StageVerify}},
+		{TypeFeature, SizeLarge, FlowRegistry[TypeFeature][SizeLarge]}, // untouched
+	}
+	for _, tt := range tests {
+		got, err := reg.StageFlow(tt.t, tt.s)
+		if err != nil {
+			t.Errorf("StageFlow(%s, %s) failed: %v", tt.t, tt.s, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("StageFlow(%s, %s) = %v, want %v", tt.t, tt.s, got, tt.want)
+		}
+	}
+
+	if got := reg.StageFilename("threat-model"); got != "threat-model.md" {
+		t.Errorf("StageFilename(threat-model) = %q, want default threat-model.md", got)
+	}
+	if got := reg.StageFilename("rollback-plan"); got != "rollback.md" {
+		t.Errorf("StageFilename(rollback-plan) = %q, want rollback.md", got)
+	}
+
+	// The built-in tables are never modified.
+	if len(FlowRegistry[TypeFeature][SizeSmall]) != 4 {
+		t.Error("merging must not mutate FlowRegistry")
+	}
+}
+
+func TestLoadRegistry_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"bad JSON", `{"types": `, "parsing hoofy-flows.json"},
+		{"unknown field", `{"type": {}}`, "unknown field"},
+		{"missing verify", `{"types": {"chore": {"extends": "fix", "flows": {"small": ["describe", "context-check", "tasks"]}}}}`,
+			"must end with verify"},
+		{"missing context-check", `{"types": {"chore": {"extends": "fix", "flows": {"small": ["describe", "tasks", "verify"]}}}}`,
+			"must include context-check"},
+		{"unknown stage", `{"types": {"chore": {"extends": "fix", "flows": {"small": ["lint", "context-check", "verify"]}}}}`,
+			`unknown stage "lint"`},
+		{"duplicate stage", `{"types": {"fix": {"flows": {"small": ["describe", "context-check", "describe", "verify"]}}}}`,
+			"more than once"},
+		{"bad size", `{"types": {"fix": {"flows": {"huge": ["describe", "context-check", "verify"]}}}}`,
+			"invalid change size"},
+		{"incomplete new type", `{"types": {"spike": {"flows": {"small": ["describe", "context-check", "verify"]}}}}`,
+			"no flow for size medium"},
+		{"extends unknown", `{"types": {"spike": {"extends": "hotfix"}}}`, "not a built-in type"},
+		{"bad type name", `{"types": {"Hot Fix": {"extends": "fix"}}}`, "lowercase"},
+		{"path in filename", `{"stages": {"notes": {"filename": "../notes.md"}}}`, "plain .md file name"},
+		{"duplicate filename", `{"stages": {"notes": {"filename": "spec.md"}}}`, "both use spec.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFlows(t, root, tt.body)
+			_, err := LoadRegistry(root)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadRegistry error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRegistry_DrivesPackageHelpers(t *testing.T) {
+	root := t.TempDir()
+	writeFlows(t, root, teamFlows)
+	reg, err := LoadRegistry(root)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+
+	SetRegistry(reg)
+	t.Cleanup(func() { SetRegistry(nil) })
+
+	if err := ValidateType("spike"); err != nil {
+		t.Errorf("ValidateType(spike) after SetRegistry: %v", err)
+	}
+	if _, err := StageFlow("security", SizeLarge); err != nil {
+		t.Errorf("StageFlow(security, large) after SetRegistry: %v", err)
+	}
+	if StageFilename("rollback-plan") != "rollback.md" {
+		t.Error("StageFilename should use the installed registry")
+	}
+
+	SetRegistry(nil)
+	if err := ValidateType("spike"); err == nil {
+		t.Error("SetRegistry(nil) should restore the built-in types")
+	}
+}
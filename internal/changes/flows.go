@@ -1,8 +1,6 @@
 package changes
 
-import "fmt"
-
-// FlowRegistry defines the stage sequence for each (ChangeType, ChangeSize) pair.
+// FlowRegistry defines the built-in stage sequence for each (ChangeType, ChangeSize) pair.
 // This is the heart of the adaptive pipeline: instead of a fixed 7-stage sequence,
 // the flow adapts to what the change actually needs.
 //
@@ -14,6 +12,9 @@ import "fmt"
 // StageContextCheck is ALWAYS at index 1 (after the initial stage, before
 // everything else). This ensures every change — even small ones — scans
 // existing specs and business rules for conflicts before proceeding.
+//
+// Projects can add types and override flows in docs/hoofy-flows.json;
+// see LoadRegistry. The tables here are never modified.
 var FlowRegistry = map[ChangeType]map[ChangeSize][]ChangeStage{
 	TypeFix: {
 		SizeSmall:  {StageDescribe, StageContextCheck, StageTasks, StageVerify},
@@ -37,32 +38,7 @@ var FlowRegistry = map[ChangeType]map[ChangeSize][]ChangeStage{
 	},
 }
 
-// StageFlow returns the ordered list of stages for the given type and size.
-// Returns an error if the combination is not recognized.
-func StageFlow(t ChangeType, s ChangeSize) ([]ChangeStage, error) {
-	if err := ValidateType(t); err != nil {
-		return nil, err
-	}
-	if err := ValidateSize(s); err != nil {
-		return nil, err
-	}
-
-	sizes, ok := FlowRegistry[t]
-	if !ok {
-		return nil, fmt.Errorf("no flow defined for type %q", t)
-	}
-	flow, ok := sizes[s]
-	if !ok {
-		return nil, fmt.Errorf("no flow defined for %s/%s", t, s)
-	}
-
-	// Return a copy to prevent mutation of the registry.
-	result := make([]ChangeStage, len(flow))
-	copy(result, flow)
-	return result, nil
-}
-
-// stageFilenames maps change stages to their artifact filenames.
+// stageFilenames maps the built-in change stages to their artifact filenames.
 var stageFilenames = map[ChangeStage]string{
 	StageDescribe:     "describe.md",
 	StageScope:        "scope.md",
@@ -75,8 +51,15 @@ var stageFilenames = map[ChangeStage]string{
 	StageVerify:       "verify.md",
 }
 
-// StageFilename returns the artifact filename for a given stage.
-// Returns empty string for unknown stages.
+// StageFlow returns the ordered list of stages for the given type and size,
+// as defined by the current registry. Returns an error if the combination
+// is not recognized.
+func StageFlow(t ChangeType, s ChangeSize) ([]ChangeStage, error) {
+	return CurrentRegistry().StageFlow(t, s)
+}
+
+// StageFilename returns the artifact filename for a given stage in the
+// current registry. Returns empty string for unknown stages.
 func StageFilename(stage ChangeStage) string {
-	return stageFilenames[stage]
+	return CurrentRegistry().StageFilename(stage)
 }
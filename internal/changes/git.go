@@ -0,0 +1,166 @@
+package changes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GitHead describes what is checked out in the git work tree that
+// contains a project. It is read straight from .git/HEAD — no git
+// binary is required.
+type GitHead struct {
+	Branch   string // short branch name; empty when detached or outside git
+	Commit   string // commit SHA HEAD points at; empty before the first commit
+	Worktree string // linked worktree name; empty for the main work tree
+}
+
+// ReadGitHead finds the .git entry at or above projectRoot and parses
+// its HEAD. Linked worktrees (.git is a "gitdir:" file) are followed.
+// A project outside any git repository yields a zero GitHead and no error.
+func ReadGitHead(projectRoot string) (GitHead, error) {
+	gitPath, err := findGitPath(projectRoot)
+	if err != nil || gitPath == "" {
+		return GitHead{}, err
+	}
+
+	var head GitHead
+	gitDir := gitPath
+	info, err := os.Stat(gitPath)
+	if err != nil {
+		return GitHead{}, fmt.Errorf("reading %s: %w", gitPath, err)
+	}
+	if !info.IsDir() {
+		gitDir, err = readGitDirFile(gitPath)
+		if err != nil {
+			return GitHead{}, err
+		}
+		// Linked worktrees live at <repo>/.git/worktrees/<name>.
+		if filepath.Base(filepath.Dir(gitDir)) == "worktrees" {
+			head.Worktree = filepath.Base(gitDir)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return GitHead{}, fmt.Errorf("reading git HEAD: %w", err)
+	}
+
+	ref := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(ref, "ref: "):
+		fullRef := strings.TrimPrefix(ref, "ref: ")
+		head.Branch = strings.TrimPrefix(fullRef, "refs/heads/")
+		head.Commit = resolveRef(commonGitDir(gitDir), fullRef)
+	default:
+		head.Commit = ref
+	}
+	return head, nil
+}
+
+// commonGitDir returns the directory holding refs for gitDir. Linked
+// worktrees point to the main repository's .git via a "commondir" file.
+func commonGitDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	common := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitDir, common)
+	}
+	return filepath.Clean(common)
+}
+
+// resolveRef returns the commit a ref points at, from its loose file or
+// packed-refs. Returns "" when the ref does not exist yet (no commits).
+func resolveRef(gitDir, ref string) string {
+	if data, err := os.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref))); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	data, err := os.ReadFile(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if sha, name, ok := strings.Cut(strings.TrimSpace(line), " "); ok && name == ref {
+			return sha
+		}
+	}
+	return ""
+}
+
+// findGitPath walks up from dir to the nearest .git entry (directory
+// or file). Returns "" when there is none.
+func findGitPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolving project root: %w", err)
+	}
+	for {
+		candidate := filepath.Join(dir, ".git")
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// readGitDirFile parses a ".git" file of the form "gitdir: <path>".
+// Relative paths are resolved against the file's directory.
+func readGitDirFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	line := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(line, "gitdir:") {
+		return "", fmt.Errorf("%s: expected \"gitdir: <path>\"", path)
+	}
+	gitDir := strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return filepath.Clean(gitDir), nil
+}
+
+// BindToHead binds the change to the branch and worktree checked out
+// in projectRoot. Outside git, or on a detached HEAD, the change is
+// left unbound.
+func (c *ChangeRecord) BindToHead(projectRoot string) {
+	head, _ := ReadGitHead(projectRoot)
+	c.Branch = head.Branch
+	c.Worktree = head.Worktree
+}
+
+// RecordBaseCommit stores the commit checked out in projectRoot as the
+// point the change's code is diffed against at verify. Outside git, or
+// before the first commit, nothing is recorded.
+func (c *ChangeRecord) RecordBaseCommit(projectRoot string) {
+	head, _ := ReadGitHead(projectRoot)
+	c.BaseCommit = head.Commit
+}
+
+// resolveActive picks the active change for the checked-out branch:
+// a change bound to that branch wins, then an unbound change. Changes
+// bound to other branches are never returned.
+func resolveActive(active []*ChangeRecord, branch string) *ChangeRecord {
+	for _, c := range active {
+		if c.Branch == branch {
+			return c
+		}
+	}
+	if branch != "" {
+		for _, c := range active {
+			if c.Branch == "" {
+				return c
+			}
+		}
+	}
+	return nil
+}
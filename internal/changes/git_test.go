@@ -0,0 +1,253 @@
+package changes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeGitHead creates <root>/.git/HEAD with the given content.
+func writeGitHead(t *testing.T, root, content string) {
+	t.Helper()
+	gitDir := filepath.Join(root, ".git")
+	if err := os.MkdirAll(gitDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+// --- ReadGitHead ---
+
+func TestReadGitHead_Branch(t *testing.T) {
+	root := t.TempDir()
+	writeGitHead(t, root, "ref: refs/heads/feature/login\n")
+
+	head, err := ReadGitHead(root)
+	if err != nil {
+		t.Fatalf("ReadGitHead failed: %v", err)
+	}
+	if head.Branch != "feature/login" || head.Commit != "" || head.Worktree != "" {
+		t.Errorf("head = %+v", head)
+	}
+}
+
+func TestReadGitHead_FromSubdirectory(t *testing.T) {
+	root := t.TempDir()
+	writeGitHead(t, root, "ref: refs/heads/main\n")
+	sub := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+
+	head, err := ReadGitHead(sub)
+	if err != nil || head.Branch != "main" {
+		t.Errorf("ReadGitHead(sub) = %+v, %v; want branch main", head, err)
+	}
+}
+
+func TestReadGitHead_Detached(t *testing.T) {
+	root := t.TempDir()
+	writeGitHead(t, root, "3f2a9c1d4e5b6a7980f1e2d3c4b5a69788796a5b\n")
+
+	head, err := ReadGitHead(root)
+	if err != nil {
+		t.Fatalf("ReadGitHead failed: %v", err)
+	}
+	if head.Branch != "" || head.Commit != "3f2a9c1d4e5b6a7980f1e2d3c4b5a69788796a5b" {
+		t.Errorf("head = %+v", head)
+	}
+}
+
+func TestReadGitHead_LinkedWorktree(t *testing.T) {
+	repo := t.TempDir()
+	wtGitDir := filepath.Join(repo, ".git", "worktrees", "hotfix")
+	if err := os.MkdirAll(wtGitDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wtGitDir, "HEAD"), []byte("ref: refs/heads/hotfix-123\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+wtGitDir+"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	head, err := ReadGitHead(worktree)
+	if err != nil {
+		t.Fatalf("ReadGitHead failed: %v", err)
+	}
+	if head.Branch != "hotfix-123" || head.Worktree != "hotfix" {
+		t.Errorf("head = %+v", head)
+	}
+}
+
+func TestReadGitHead_NotARepo(t *testing.T) {
+	head, err := ReadGitHead(t.TempDir())
+	if err != nil {
+		t.Fatalf("ReadGitHead failed: %v", err)
+	}
+	if head != (GitHead{}) {
+		t.Errorf("head = %+v, want zero value", head)
+	}
+}
+
+func TestReadGitHead_MalformedGitFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".git"), []byte("nonsense"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := ReadGitHead(root); err == nil {
+		t.Error("expected error for malformed .git file")
+	}
+}
+
+// --- LoadActive with branches ---
+
+func TestLoadActive_ResolvesByBranch(t *testing.T) {
+	root := t.TempDir()
+	store := NewFileStore()
+
+	onMain := testChangeRecord("on-main", "On main", TypeFix, SizeSmall)
+	onMain.Branch = "main"
+	onFeature := testChangeRecord("on-feature", "On feature", TypeFeature, SizeSmall)
+	onFeature.Branch = "feature/x"
+	for _, c := range []*ChangeRecord{onMain, onFeature} {
+		if err := store.Create(root, c); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		head string
+		want string
+	}{
+		{"ref: refs/heads/main\n", "on-main"},
+		{"ref: refs/heads/feature/x\n", "on-feature"},
+		{"ref: refs/heads/other\n", ""},
+		{"3f2a9c1d4e5b6a7980f1e2d3c4b5a69788796a5b\n", ""},
+	}
+	for _, tt := range tests {
+		writeGitHead(t, root, tt.head)
+		active, err := store.LoadActive(root)
+		if err != nil {
+			t.Fatalf("LoadActive failed: %v", err)
+		}
+		got := ""
+		if active != nil {
+			got = active.ID
+		}
+		if got != tt.want {
+			t.Errorf("HEAD %q: LoadActive = %q, want %q", tt.head, got, tt.want)
+		}
+	}
+}
+
+func TestLoadActive_UnboundChangeMatchesAnyBranch(t *testing.T) {
+	root := t.TempDir()
+	store := NewFileStore()
+	if err := store.Create(root, testChangeRecord("legacy", "Legacy", TypeFix, SizeSmall)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	writeGitHead(t, root, "ref: refs/heads/anything\n")
+
+	active, err := store.LoadActive(root)
+	if err != nil || active == nil || active.ID != "legacy" {
+		t.Errorf("LoadActive = %v, %v; want legacy", active, err)
+	}
+}
+
+func TestListInFlight_ActiveAndPausedOnly(t *testing.T) {
+	root := t.TempDir()
+	store := NewFileStore()
+	for id, status := range map[string]ChangeStatus{"a": StatusActive, "p": StatusPaused, "c": StatusCompleted} {
+		c := testChangeRecord(id, id, TypeFix, SizeSmall)
+		c.Status = status
+		if err := store.Create(root, c); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	inFlight, err := store.ListInFlight(root)
+	if err != nil {
+		t.Fatalf("ListInFlight failed: %v", err)
+	}
+	if len(inFlight) != 2 || inFlight[0].ID != "a" || inFlight[1].ID != "p" {
+		t.Errorf("ListInFlight = %+v", inFlight)
+	}
+}
+
+func TestBindToHead(t *testing.T) {
+	root := t.TempDir()
+	writeGitHead(t, root, "ref: refs/heads/release/1.2\n")
+
+	c := testChangeRecord("bind", "Bind", TypeFix, SizeSmall)
+	c.BindToHead(root)
+	if c.Branch != "release/1.2" {
+		t.Errorf("Branch = %q, want release/1.2", c.Branch)
+	}
+
+	c.BindToHead(t.TempDir())
+	if c.Branch != "" {
+		t.Errorf("outside git the change should be unbound, got %q", c.Branch)
+	}
+}
+
+func TestReadGitHead_ResolvesBranchCommit(t *testing.T) {
+	root := t.TempDir()
+	writeGitHead(t, root, "ref: refs/heads/main\n")
+	refDir := filepath.Join(root, ".git", "refs", "heads")
+	if err := os.MkdirAll(refDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(refDir, "main"), []byte("1111111111111111111111111111111111111111\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	packed := "# pack-refs with: peeled fully-peeled sorted\n2222222222222222222222222222222222222222 refs/heads/release\n"
+	if err := os.WriteFile(filepath.Join(root, ".git", "packed-refs"), []byte(packed), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	head, err := ReadGitHead(root)
+	if err != nil || head.Commit != "1111111111111111111111111111111111111111" {
+		t.Errorf("loose ref: head = %+v, %v", head, err)
+	}
+
+	writeGitHead(t, root, "ref: refs/heads/release\n")
+	head, err = ReadGitHead(root)
+	if err != nil || head.Commit != "2222222222222222222222222222222222222222" {
+		t.Errorf("packed ref: head = %+v, %v", head, err)
+	}
+}
+
+func TestReadGitHead_WorktreeUsesCommonDir(t *testing.T) {
+	repo := t.TempDir()
+	wtGitDir := filepath.Join(repo, ".git", "worktrees", "wt")
+	if err := os.MkdirAll(wtGitDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	files := map[string]string{
+		filepath.Join(wtGitDir, "HEAD"):                       "ref: refs/heads/topic\n",
+		filepath.Join(wtGitDir, "commondir"):                  "../..\n",
+		filepath.Join(repo, ".git", "refs", "heads", "topic"): "3333333333333333333333333333333333333333\n",
+	}
+	for path, body := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+wtGitDir+"\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	head, err := ReadGitHead(worktree)
+	if err != nil || head.Commit != "3333333333333333333333333333333333333333" {
+		t.Errorf("head = %+v, %v", head, err)
+	}
+}
@@ -0,0 +1,138 @@
+package changes
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Registry holds the change types, stage flows, and artifact filenames
+// the pipeline works with: the built-in tables, optionally extended by a
+// project's docs/hoofy-flows.json. A Registry is immutable once built.
+type Registry struct {
+	types        []ChangeType // built-ins first, then project types by name
+	descriptions map[ChangeType]string
+	bases        map[ChangeType]ChangeType // project type → built-in it extends
+	flows        map[ChangeType]map[ChangeSize][]ChangeStage
+	filenames    map[ChangeStage]string
+}
+
+// builtinDescriptions explains the compiled-in change types.
+var builtinDescriptions = map[ChangeType]string{
+	TypeFeature:     "new capability",
+	TypeFix:         "bug fix",
+	TypeRefactor:    "restructure without behavior change",
+	TypeEnhancement: "improve existing feature",
+}
+
+// DefaultRegistry returns a registry with only the built-in types and flows.
+func DefaultRegistry() *Registry {
+	r := &Registry{
+		types:        append([]ChangeType(nil), builtinTypes...),
+		descriptions: make(map[ChangeType]string, len(builtinDescriptions)),
+		bases:        map[ChangeType]ChangeType{},
+		flows:        make(map[ChangeType]map[ChangeSize][]ChangeStage, len(FlowRegistry)),
+		filenames:    make(map[ChangeStage]string, len(stageFilenames)),
+	}
+	for t, d := range builtinDescriptions {
+		r.descriptions[t] = d
+	}
+	for t, sizes := range FlowRegistry {
+		r.flows[t] = copySizes(sizes)
+	}
+	for s, f := range stageFilenames {
+		r.filenames[s] = f
+	}
+	return r
+}
+
+// Types returns the registered change types: built-ins first, then
+// project-defined types in alphabetical order.
+func (r *Registry) Types() []ChangeType {
+	return append([]ChangeType(nil), r.types...)
+}
+
+// Describe returns the one-line description of a change type, or "".
+func (r *Registry) Describe(t ChangeType) string {
+	return r.descriptions[t]
+}
+
+// Base returns the built-in type t is, or extends. Project types
+// declared without "extends" have no base and return "".
+func (r *Registry) Base(t ChangeType) ChangeType {
+	if _, ok := FlowRegistry[t]; ok {
+		return t
+	}
+	return r.bases[t]
+}
+
+// ValidateType returns an error if the type is not registered.
+func (r *Registry) ValidateType(t ChangeType) error {
+	if _, ok := r.flows[t]; !ok {
+		names := make([]string, len(r.types))
+		for i, rt := range r.types {
+			names[i] = string(rt)
+		}
+		return fmt.Errorf("invalid change type %q: must be one of: %s", t, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// StageFlow returns the ordered list of stages for the given type and size.
+// Returns an error if the combination is not recognized.
+func (r *Registry) StageFlow(t ChangeType, s ChangeSize) ([]ChangeStage, error) {
+	if err := r.ValidateType(t); err != nil {
+		return nil, err
+	}
+	if err := ValidateSize(s); err != nil {
+		return nil, err
+	}
+
+	flow, ok := r.flows[t][s]
+	if !ok {
+		return nil, fmt.Errorf("no flow defined for %s/%s", t, s)
+	}
+
+	// Return a copy to prevent mutation of the registry.
+	result := make([]ChangeStage, len(flow))
+	copy(result, flow)
+	return result, nil
+}
+
+// StageFilename returns the artifact filename for a given stage.
+// Returns empty string for unknown stages.
+func (r *Registry) StageFilename(stage ChangeStage) string {
+	return r.filenames[stage]
+}
+
+// current is the registry behind the package-level ValidateType,
+// StageFlow, and StageFilename.
+var current atomic.Pointer[Registry]
+
+func init() {
+	current.Store(DefaultRegistry())
+}
+
+// CurrentRegistry returns the registry in use by the package-level helpers.
+func CurrentRegistry() *Registry {
+	return current.Load()
+}
+
+// SetRegistry replaces the registry used by the package-level helpers —
+// typically with the result of LoadRegistry for the current project.
+// A nil registry restores the built-in defaults.
+func SetRegistry(r *Registry) {
+	if r == nil {
+		r = DefaultRegistry()
+	}
+	current.Store(r)
+}
+
+// copySizes deep-copies a size → flow table.
+func copySizes(sizes map[ChangeSize][]ChangeStage) map[ChangeSize][]ChangeStage {
+	out := make(map[ChangeSize][]ChangeStage, len(sizes))
+	for s, flow := range sizes {
+		out[s] = append([]ChangeStage(nil), flow...)
+	}
+	return out
+}
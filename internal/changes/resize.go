@@ -0,0 +1,140 @@
+package changes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SizeChange records a resize of an in-flight change. Kept in
+// change.json so the change's history shows why its flow differs from
+// the one its type and size would produce today.
+type SizeChange struct {
+	From      ChangeSize    `json:"from"`
+	To        ChangeSize    `json:"to"`
+	AtStage   ChangeStage   `json:"at_stage"`
+	Added     []ChangeStage `json:"added,omitempty"`
+	Removed   []ChangeStage `json:"removed,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	ResizedAt string        `json:"resized_at"`
+}
+
+// sizeRank orders sizes from small to large.
+var sizeRank = map[ChangeSize]int{SizeSmall: 0, SizeMedium: 1, SizeLarge: 2}
+
+// Resize switches an active change to the flow of another size. Stages
+// already started — completed, in progress, or stale — keep their
+// entries (and so their artifacts), even when growing to a flow that
+// names them differently (a small feature's describe stays next to the
+// medium flow's charter). Stages the new flow adds are inserted after
+// the current stage, or before verify when the change is already there;
+// pending stages it drops are removed. Shrinking is refused when it
+// would drop a stage that has been started.
+func Resize(change *ChangeRecord, size ChangeSize, reason string) (*SizeChange, error) {
+	if change.Status != StatusActive {
+		return nil, fmt.Errorf("change %q is not active (status: %s)", change.ID, change.Status)
+	}
+	if err := ValidateSize(size); err != nil {
+		return nil, err
+	}
+	if size == change.Size {
+		return nil, fmt.Errorf("change %q is already %s", change.ID, size)
+	}
+	current := CurrentStageIndex(change)
+	if current < 0 {
+		return nil, fmt.Errorf("unknown current stage %q in change %q", change.CurrentStage, change.ID)
+	}
+
+	flow, err := StageFlow(change.Type, size)
+	if err != nil {
+		return nil, err
+	}
+
+	growing := sizeRank[size] > sizeRank[change.Size]
+	var started []ChangeStage
+	for _, s := range change.Stages {
+		if s.Status != "pending" && !slices.Contains(flow, s.Name) {
+			started = append(started, s.Name)
+		}
+	}
+	if len(started) > 0 && !growing {
+		return nil, fmt.Errorf("cannot resize change %q to %s: stages already started are not in the %s/%s flow: %s",
+			change.ID, size, change.Type, size, joinStageNames(started))
+	}
+
+	// Everything up to the current stage stays where it is. When the
+	// current stage is the last one (verify), new stages must come
+	// before it, so it goes back to pending behind them.
+	keep := current + 1
+	atEnd := current == len(change.Stages)-1
+	if atEnd {
+		keep = current
+	}
+	stages := append([]StageEntry(nil), change.Stages[:keep]...)
+
+	rec := &SizeChange{From: change.Size, To: size, AtStage: change.CurrentStage, Reason: strings.TrimSpace(reason)}
+	for _, name := range flow {
+		if slices.ContainsFunc(stages, func(e StageEntry) bool { return e.Name == name }) {
+			continue
+		}
+		idx := slices.IndexFunc(change.Stages, func(e StageEntry) bool { return e.Name == name })
+		if idx < 0 {
+			stages = append(stages, StageEntry{Name: name, Status: "pending"})
+			rec.Added = append(rec.Added, name)
+			continue
+		}
+		entry := change.Stages[idx]
+		if atEnd && idx == current && len(rec.Added) > 0 {
+			entry = StageEntry{Name: name, Status: "pending", Revision: entry.Revision}
+		}
+		stages = append(stages, entry)
+	}
+	// Started stages past the current one that the new flow lacks
+	// (growing only — shrinking refused them above) stay before verify.
+	for _, s := range change.Stages[keep:] {
+		if !slices.Contains(flow, s.Name) {
+			if s.Status == "pending" {
+				rec.Removed = append(rec.Removed, s.Name)
+			} else {
+				stages = slices.Insert(stages, len(stages)-1, s)
+			}
+		}
+	}
+
+	now := timeNow().UTC().Format("2006-01-02T15:04:05Z07:00")
+	change.Stages = stages
+	if atEnd && len(rec.Added) > 0 {
+		next := &change.Stages[keep]
+		next.Status = "in_progress"
+		next.StartedAt = now
+		change.CurrentStage = next.Name
+	}
+	change.Size = size
+	change.UpdatedAt = now
+	rec.ResizedAt = now
+	change.Resizes = append(change.Resizes, *rec)
+	return rec, nil
+}
+
+// MatchesFlow reports whether the change's stages fit flow: the same
+// stages in the same order or, for a resized change, every stage of the
+// flow — possibly reordered and next to stages kept from the old size —
+// ending with the flow's final stage.
+func MatchesFlow(change *ChangeRecord, flow []ChangeStage) bool {
+	names := make([]ChangeStage, len(change.Stages))
+	for i, s := range change.Stages {
+		names[i] = s.Name
+	}
+	if slices.Equal(names, flow) {
+		return true
+	}
+	if len(change.Resizes) == 0 || len(flow) == 0 || len(names) == 0 || names[len(names)-1] != flow[len(flow)-1] {
+		return false
+	}
+	for _, stage := range flow {
+		if !slices.Contains(names, stage) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package changes
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// stageNames returns the names of a change's stages.
+func stageNames(change *ChangeRecord) []ChangeStage {
+	names := make([]ChangeStage, len(change.Stages))
+	for i, s := range change.Stages {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func TestResize_GrowInsertsAfterCurrent(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeSmall) // describe, context-check, tasks, verify
+	advanceTo(t, change, StageTasks)
+
+	rec, err := Resize(change, SizeLarge, "needs a design")
+	if err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	want := []ChangeStage{StageDescribe, StageContextCheck, StageTasks, StageSpec, StageDesign, StageVerify}
+	if got := stageNames(change); !slices.Equal(got, want) {
+		t.Errorf("stages = %v, want %v", got, want)
+	}
+	if change.Size != SizeLarge || change.CurrentStage != StageTasks || change.Stages[1].Status != "completed" {
+		t.Errorf("change = %+v", change)
+	}
+	if !slices.Equal(rec.Added, []ChangeStage{StageSpec, StageDesign}) || rec.From != SizeSmall || rec.AtStage != StageTasks ||
+		rec.Reason != "needs a design" || rec.ResizedAt == "" {
+		t.Errorf("record = %+v", rec)
+	}
+	if len(change.Resizes) != 1 || change.Resizes[0].To != SizeLarge {
+		t.Errorf("Resizes = %+v", change.Resizes)
+	}
+
+	flow, _ := StageFlow(TypeFix, SizeLarge)
+	if !MatchesFlow(change, flow) {
+		t.Error("a resized change should match its new flow")
+	}
+}
+
+func TestResize_GrowEarlyKeepsFlowOrder(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeSmall)
+	advanceTo(t, change, StageContextCheck)
+
+	if _, err := Resize(change, SizeMedium, ""); err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	flow, _ := StageFlow(TypeFix, SizeMedium)
+	if got := stageNames(change); !slices.Equal(got, flow) {
+		t.Errorf("stages = %v, want %v", got, flow)
+	}
+}
+
+func TestResize_GrowKeepsStagesOutsideNewFlow(t *testing.T) {
+	change := testActiveChange(TypeFeature, SizeSmall) // describe, context-check, tasks, verify
+	advanceTo(t, change, StageContextCheck)
+
+	rec, err := Resize(change, SizeMedium, "")
+	if err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	want := []ChangeStage{StageDescribe, StageContextCheck, StageCharter, StageSpec, StageTasks, StageVerify}
+	if got := stageNames(change); !slices.Equal(got, want) {
+		t.Errorf("stages = %v, want %v", got, want)
+	}
+	if len(rec.Removed) != 0 {
+		t.Errorf("Removed = %v", rec.Removed)
+	}
+}
+
+func TestResize_AtVerifyReopensBeforeIt(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeSmall)
+	advanceTo(t, change, StageVerify)
+
+	if _, err := Resize(change, SizeMedium, ""); err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	want := []ChangeStage{StageDescribe, StageContextCheck, StageTasks, StageSpec, StageVerify}
+	if got := stageNames(change); !slices.Equal(got, want) {
+		t.Errorf("stages = %v, want %v", got, want)
+	}
+	if change.CurrentStage != StageSpec || change.Stages[3].Status != "in_progress" || change.Stages[4].Status != "pending" {
+		t.Errorf("change = %+v", change.Stages)
+	}
+
+	// The new stage is done before verify again.
+	if err := Advance(change); err != nil || change.CurrentStage != StageVerify {
+		t.Errorf("Advance = %v, current %s", err, change.CurrentStage)
+	}
+}
+
+func TestResize_Shrink(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeLarge) // describe, context-check, spec, design, tasks, verify
+	advanceTo(t, change, StageContextCheck)
+
+	rec, err := Resize(change, SizeSmall, "")
+	if err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	flow, _ := StageFlow(TypeFix, SizeSmall)
+	if got := stageNames(change); !slices.Equal(got, flow) {
+		t.Errorf("stages = %v, want %v", got, flow)
+	}
+	if !slices.Equal(rec.Removed, []ChangeStage{StageSpec, StageDesign}) || len(rec.Added) != 0 {
+		t.Errorf("record = %+v", rec)
+	}
+}
+
+func TestResize_Errors(t *testing.T) {
+	// Shrinking past a completed stage.
+	change := testActiveChange(TypeFix, SizeLarge)
+	advanceTo(t, change, StageDesign)
+	if _, err := Resize(change, SizeSmall, ""); err == nil || !strings.Contains(err.Error(), "spec, design") {
+		t.Errorf("shrink past completed stages: %v", err)
+	}
+	if change.Size != SizeLarge || len(change.Resizes) != 0 {
+		t.Error("a refused resize must not modify the change")
+	}
+
+	if _, err := Resize(change, SizeLarge, ""); err == nil {
+		t.Error("resizing to the same size should fail")
+	}
+	if _, err := Resize(change, "huge", ""); err == nil {
+		t.Error("invalid size should fail")
+	}
+	change.Status = StatusPaused
+	if _, err := Resize(change, SizeMedium, ""); err == nil {
+		t.Error("resizing a paused change should fail")
+	}
+}
+
+func TestMatchesFlow(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeMedium)
+	flow, _ := StageFlow(TypeFix, SizeMedium)
+	if !MatchesFlow(change, flow) {
+		t.Error("a fresh change should match its flow")
+	}
+
+	// Reordered stages only match for resized changes.
+	change.Stages[2], change.Stages[3] = change.Stages[3], change.Stages[2]
+	if MatchesFlow(change, flow) {
+		t.Error("reordered stages without a resize should not match")
+	}
+	change.Resizes = []SizeChange{{From: SizeSmall, To: SizeMedium}}
+	if !MatchesFlow(change, flow) {
+		t.Error("reordered stages of a resized change should match")
+	}
+	change.Stages = change.Stages[1:]
+	if MatchesFlow(change, flow) {
+		t.Error("a missing stage should not match")
+	}
+}
@@ -0,0 +1,110 @@
+package changes
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RevisionsDir is the subdirectory within a change that keeps earlier
+// versions of stage artifacts superseded by a Rewind.
+const RevisionsDir = "revisions"
+
+// SnapshotStage copies a stage's current artifact (<stage>.md) to
+// revisions/<stage>.<n>.md, numbering from 1. It returns the revision
+// path, or "" when the stage has no artifact yet. If the artifact is
+// identical to the latest revision, no new copy is made and the latest
+// revision's path is returned.
+func SnapshotStage(changeDir string, stage ChangeStage) (string, error) {
+	filename := StageFilename(stage)
+	if filename == "" {
+		return "", fmt.Errorf("unknown stage %q — no filename mapping", stage)
+	}
+
+	data, err := os.ReadFile(filepath.Join(changeDir, filename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	existing, err := StageRevisions(changeDir, stage)
+	if err != nil {
+		return "", err
+	}
+	if n := len(existing); n > 0 {
+		latest, err := os.ReadFile(existing[n-1])
+		if err == nil && bytes.Equal(latest, data) {
+			return existing[n-1], nil
+		}
+	}
+
+	revDir := filepath.Join(changeDir, RevisionsDir)
+	if err := os.MkdirAll(revDir, 0o755); err != nil {
+		return "", fmt.Errorf("creating revisions directory: %w", err)
+	}
+
+	path := filepath.Join(revDir, revisionFilename(stage, nextRevision(existing)))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing revision: %w", err)
+	}
+	return path, nil
+}
+
+// StageRevisions returns the saved revisions of a stage's artifact,
+// oldest first.
+func StageRevisions(changeDir string, stage ChangeStage) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(changeDir, RevisionsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading revisions directory: %w", err)
+	}
+
+	type rev struct {
+		n    int
+		path string
+	}
+	var revs []rev
+	prefix := string(stage) + "."
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".md"))
+		if err != nil || n < 1 {
+			continue
+		}
+		revs = append(revs, rev{n, filepath.Join(changeDir, RevisionsDir, name)})
+	}
+
+	sort.Slice(revs, func(i, j int) bool { return revs[i].n < revs[j].n })
+	paths := make([]string, len(revs))
+	for i, r := range revs {
+		paths[i] = r.path
+	}
+	return paths, nil
+}
+
+// revisionFilename returns the file name of revision n of a stage.
+func revisionFilename(stage ChangeStage, n int) string {
+	return fmt.Sprintf("%s.%d.md", stage, n)
+}
+
+// nextRevision returns the number after the highest existing revision.
+func nextRevision(existing []string) int {
+	if len(existing) == 0 {
+		return 1
+	}
+	last := filepath.Base(existing[len(existing)-1])
+	parts := strings.Split(last, ".")
+	n, _ := strconv.Atoi(parts[len(parts)-2])
+	return n + 1
+}
@@ -0,0 +1,78 @@
+package changes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshotStage_NumbersRevisions(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "spec.md"), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	write("spec v1")
+	first, err := SnapshotStage(dir, StageSpec)
+	if err != nil {
+		t.Fatalf("SnapshotStage failed: %v", err)
+	}
+	if filepath.Base(first) != "spec.1.md" {
+		t.Errorf("first revision = %s, want spec.1.md", first)
+	}
+
+	// Unchanged artifact: no duplicate revision.
+	again, err := SnapshotStage(dir, StageSpec)
+	if err != nil || again != first {
+		t.Errorf("unchanged snapshot = %q, %v; want %q", again, err, first)
+	}
+
+	write("spec v2")
+	second, err := SnapshotStage(dir, StageSpec)
+	if err != nil {
+		t.Fatalf("SnapshotStage failed: %v", err)
+	}
+	if filepath.Base(second) != "spec.2.md" {
+		t.Errorf("second revision = %s, want spec.2.md", second)
+	}
+
+	revs, err := StageRevisions(dir, StageSpec)
+	if err != nil || len(revs) != 2 || revs[0] != first || revs[1] != second {
+		t.Errorf("StageRevisions = %v, %v", revs, err)
+	}
+	data, _ := os.ReadFile(first)
+	if string(data) != "spec v1" {
+		t.Errorf("first revision content = %q", data)
+	}
+}
+
+func TestSnapshotStage_NoArtifact(t *testing.T) {
+	path, err := SnapshotStage(t.TempDir(), StageDesign)
+	if err != nil || path != "" {
+		t.Errorf("SnapshotStage without artifact = %q, %v; want \"\", nil", path, err)
+	}
+}
+
+func TestStageRevisions_IgnoresOtherStagesAndNumericOrder(t *testing.T) {
+	dir := t.TempDir()
+	revDir := filepath.Join(dir, RevisionsDir)
+	if err := os.MkdirAll(revDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	for _, name := range []string{"context-check.1.md", "spec.10.md", "spec.2.md", "spec.notes.md"} {
+		if err := os.WriteFile(filepath.Join(revDir, name), nil, 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	revs, err := StageRevisions(dir, StageSpec)
+	if err != nil {
+		t.Fatalf("StageRevisions failed: %v", err)
+	}
+	if len(revs) != 2 || filepath.Base(revs[0]) != "spec.2.md" || filepath.Base(revs[1]) != "spec.10.md" {
+		t.Errorf("StageRevisions = %v", revs)
+	}
+}
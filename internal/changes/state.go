@@ -1,6 +1,9 @@
 package changes
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // --- State machine for the adaptive change pipeline ---
 //
@@ -41,6 +44,13 @@ func CanAdvance(change *ChangeRecord) error {
 		return fmt.Errorf("already at the final stage %q in change %q", change.CurrentStage, change.ID)
 	}
 
+	if idx+1 == len(change.Stages)-1 {
+		if stale := staleBefore(change, idx+1); len(stale) > 0 {
+			return fmt.Errorf("cannot enter %s in change %q: stale stages must be completed again first: %s",
+				change.Stages[idx+1].Name, change.ID, joinStageNames(stale))
+		}
+	}
+
 	return nil
 }
 
@@ -60,6 +70,11 @@ func Advance(change *ChangeRecord) error {
 	change.Stages[idx].CompletedAt = now
 
 	nextIdx := idx + 1
+	// A stale stage is being redone after a Rewind — count the revision.
+	if change.Stages[nextIdx].Status == "stale" {
+		change.Stages[nextIdx].Revision++
+		change.Stages[nextIdx].CompletedAt = ""
+	}
 	// Mark next stage in_progress.
 	change.Stages[nextIdx].Status = "in_progress"
 	change.Stages[nextIdx].StartedAt = now
@@ -91,6 +106,11 @@ func CompleteChange(change *ChangeRecord) error {
 		return fmt.Errorf("cannot complete change %q: not at the final stage (current: %s)", change.ID, change.CurrentStage)
 	}
 
+	if stale := staleBefore(change, idx); len(stale) > 0 {
+		return fmt.Errorf("cannot complete change %q: stale stages must be completed again first: %s",
+			change.ID, joinStageNames(stale))
+	}
+
 	now := timeNow().UTC().Format("2006-01-02T15:04:05Z07:00")
 
 	// Mark final stage completed.
@@ -103,3 +123,93 @@ func CompleteChange(change *ChangeRecord) error {
 
 	return nil
 }
+
+// Rewind reopens an earlier, already-started stage so it can be
+// revised. The target stage goes back in progress with its Revision
+// bumped; every later stage that had been started is marked stale.
+// Advance walks forward through the stale stages again, and neither
+// verify nor completion is reachable while any stage is stale.
+func Rewind(change *ChangeRecord, stage ChangeStage) error {
+	if change.Status != StatusActive {
+		return fmt.Errorf("change %q is not active (status: %s)", change.ID, change.Status)
+	}
+
+	current := CurrentStageIndex(change)
+	if current < 0 {
+		return fmt.Errorf("unknown current stage %q in change %q", change.CurrentStage, change.ID)
+	}
+
+	target := -1
+	for i, entry := range change.Stages {
+		if entry.Name == stage {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return fmt.Errorf("stage %q is not part of change %q's flow", stage, change.ID)
+	}
+	if target >= current {
+		return fmt.Errorf("cannot rewind change %q to %s: only stages before the current stage %s can be reopened",
+			change.ID, stage, change.CurrentStage)
+	}
+
+	now := timeNow().UTC().Format("2006-01-02T15:04:05Z07:00")
+
+	entry := &change.Stages[target]
+	entry.Status = "in_progress"
+	entry.StartedAt = now
+	entry.CompletedAt = ""
+	entry.Revision++
+
+	for i := target + 1; i < len(change.Stages); i++ {
+		if change.Stages[i].Status != "pending" {
+			change.Stages[i].Status = "stale"
+		}
+	}
+
+	change.CurrentStage = stage
+	change.UpdatedAt = now
+	return nil
+}
+
+// StaleStages returns the stages invalidated by a Rewind that have not
+// been completed again yet.
+func StaleStages(change *ChangeRecord) []ChangeStage {
+	return staleBefore(change, len(change.Stages))
+}
+
+// staleBefore returns the stale stages with an index below end.
+func staleBefore(change *ChangeRecord, end int) []ChangeStage {
+	var stale []ChangeStage
+	for i := 0; i < end && i < len(change.Stages); i++ {
+		if change.Stages[i].Status == "stale" {
+			stale = append(stale, change.Stages[i].Name)
+		}
+	}
+	return stale
+}
+
+// joinStageNames renders stages as a comma-separated list.
+func joinStageNames(stages []ChangeStage) string {
+	names := make([]string, len(stages))
+	for i, s := range stages {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// resumeCurrentStage puts the current stage back in progress when a
+// change is reopened. A completed change has every stage completed, so
+// its final stage (verify) is reopened and must be completed again.
+func resumeCurrentStage(change *ChangeRecord) {
+	idx := CurrentStageIndex(change)
+	if idx < 0 || change.Stages[idx].Status == "in_progress" {
+		return
+	}
+	change.Stages[idx].Status = "in_progress"
+	change.Stages[idx].CompletedAt = ""
+	if change.Stages[idx].StartedAt == "" {
+		change.Stages[idx].StartedAt = timeNow().UTC().Format("2006-01-02T15:04:05Z07:00")
+	}
+}
@@ -420,3 +420,116 @@ func TestFullLifecycle_AdvanceThenComplete(t *testing.T) {
 		t.Fatal("Advance on completed change should fail")
 	}
 }
+
+// --- Rewind ---
+
+// advanceTo advances a fresh change until stage is current.
+func advanceTo(t *testing.T, change *ChangeRecord, stage ChangeStage) {
+	t.Helper()
+	for change.CurrentStage != stage {
+		if err := Advance(change); err != nil {
+			t.Fatalf("Advance: %v", err)
+		}
+	}
+}
+
+func TestRewind_ReopensEarlierStageAndMarksDownstreamStale(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeLarge) // describe, context-check, spec, design, tasks, verify
+	advanceTo(t, change, StageTasks)
+
+	if err := Rewind(change, StageSpec); err != nil {
+		t.Fatalf("Rewind: %v", err)
+	}
+
+	if change.CurrentStage != StageSpec {
+		t.Errorf("CurrentStage = %s, want spec", change.CurrentStage)
+	}
+	want := map[ChangeStage]string{
+		StageDescribe:     "completed",
+		StageContextCheck: "completed",
+		StageSpec:         "in_progress",
+		StageDesign:       "stale",
+		StageTasks:        "stale",
+		StageVerify:       "pending",
+	}
+	for _, s := range change.Stages {
+		if s.Status != want[s.Name] {
+			t.Errorf("stage %s status = %q, want %q", s.Name, s.Status, want[s.Name])
+		}
+	}
+	spec := change.Stages[CurrentStageIndex(change)]
+	if spec.Revision != 1 || spec.CompletedAt != "" {
+		t.Errorf("reopened stage = %+v", spec)
+	}
+	if got := StaleStages(change); len(got) != 2 || got[0] != StageDesign || got[1] != StageTasks {
+		t.Errorf("StaleStages = %v", got)
+	}
+}
+
+func TestRewind_StaleStagesRedoneBeforeVerify(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeLarge)
+	advanceTo(t, change, StageVerify)
+
+	if err := Rewind(change, StageDesign); err != nil {
+		t.Fatalf("Rewind: %v", err)
+	}
+
+	// design → tasks (stale, redone) → verify
+	if err := Advance(change); err != nil {
+		t.Fatalf("Advance: %v", err)
+	}
+	tasks := change.Stages[CurrentStageIndex(change)]
+	if tasks.Name != StageTasks || tasks.Status != "in_progress" || tasks.Revision != 1 {
+		t.Errorf("redone stage = %+v", tasks)
+	}
+	if err := Advance(change); err != nil {
+		t.Fatalf("Advance to verify: %v", err)
+	}
+	if len(StaleStages(change)) != 0 {
+		t.Errorf("no stage should be stale at verify, got %v", StaleStages(change))
+	}
+	if err := CompleteChange(change); err != nil {
+		t.Fatalf("CompleteChange: %v", err)
+	}
+}
+
+func TestRewind_GuardsVerifyAndCompletion(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeLarge)
+	advanceTo(t, change, StageTasks)
+	change.Stages[2].Status = "stale" // spec, e.g. after a hand edit
+
+	if err := CanAdvance(change); err == nil {
+		t.Error("CanAdvance into verify should fail while a stage is stale")
+	}
+
+	change.CurrentStage = StageVerify
+	if err := CompleteChange(change); err == nil {
+		t.Error("CompleteChange should fail while a stage is stale")
+	}
+}
+
+func TestRewind_Errors(t *testing.T) {
+	change := testActiveChange(TypeFix, SizeLarge)
+	advanceTo(t, change, StageSpec)
+
+	tests := []struct {
+		name  string
+		stage ChangeStage
+	}{
+		{"current stage", StageSpec},
+		{"later stage", StageTasks},
+		{"not in flow", StageClarify},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Rewind(change, tt.stage); err == nil {
+				t.Errorf("Rewind(%s) should fail", tt.stage)
+			}
+		})
+	}
+
+	change.Status = StatusCompleted
+	if err := Rewind(change, StageDescribe); err == nil {
+		t.Error("Rewind on an inactive change should fail")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/HendryAvila/Hoofy/internal/config"
@@ -27,8 +28,11 @@ type Store interface {
 	Create(projectRoot string, change *ChangeRecord) error
 	Load(projectRoot, changeID string) (*ChangeRecord, error)
 	LoadActive(projectRoot string) (*ChangeRecord, error)
+	ListInFlight(projectRoot string) ([]ChangeRecord, error)
 	Save(projectRoot string, change *ChangeRecord) error
 	Archive(projectRoot, changeID string) error
+	Abandon(projectRoot, changeID, reason string) error
+	Reopen(projectRoot, changeID string) (*ChangeRecord, error)
 	List(projectRoot string) ([]ChangeRecord, error)
 }
 
@@ -108,9 +112,34 @@ func (fs *FileStore) Load(projectRoot, changeID string) (*ChangeRecord, error) {
 	return &change, nil
 }
 
-// LoadActive scans all changes and returns the one with status "active".
-// Returns nil (not an error) if no active change exists.
+// LoadActive returns the active change for the checked-out git branch
+// (read from .git/HEAD): the change bound to that branch, or failing
+// that an unbound active change. Returns nil (not an error) if there
+// is none — changes active on other branches don't count.
 func (fs *FileStore) LoadActive(projectRoot string) (*ChangeRecord, error) {
+	inFlight, err := fs.ListInFlight(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []*ChangeRecord
+	for i := range inFlight {
+		if inFlight[i].Status == StatusActive {
+			active = append(active, &inFlight[i])
+		}
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+
+	// An unreadable HEAD is treated like no git: only unbound changes match.
+	head, _ := ReadGitHead(projectRoot)
+	return resolveActive(active, head.Branch), nil
+}
+
+// ListInFlight returns the active and paused changes under changes/,
+// across all branches.
+func (fs *FileStore) ListInFlight(projectRoot string) ([]ChangeRecord, error) {
 	changesDir := ChangesPath(projectRoot)
 	entries, err := os.ReadDir(changesDir)
 	if err != nil {
@@ -120,6 +149,7 @@ func (fs *FileStore) LoadActive(projectRoot string) (*ChangeRecord, error) {
 		return nil, fmt.Errorf("reading changes directory: %w", err)
 	}
 
+	var result []ChangeRecord
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
@@ -128,12 +158,12 @@ func (fs *FileStore) LoadActive(projectRoot string) (*ChangeRecord, error) {
 		if err != nil {
 			continue // skip unreadable changes
 		}
-		if change.Status == StatusActive {
-			return change, nil
+		if change.Status == StatusActive || change.Status == StatusPaused {
+			result = append(result, *change)
 		}
 	}
 
-	return nil, nil
+	return result, nil
 }
 
 // Save updates an existing change record.
@@ -153,20 +183,96 @@ func (fs *FileStore) Archive(projectRoot, changeID string) error {
 		return fmt.Errorf("cannot archive active change %q — complete it first", changeID)
 	}
 
-	srcDir := ChangePath(projectRoot, changeID)
+	change.Status = StatusArchived
+	change.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	return fs.moveToHistory(projectRoot, change)
+}
+
+// Abandon drops an unfinished change: the reason is recorded in
+// change.json and the change moves to history/ so a new one can start.
+func (fs *FileStore) Abandon(projectRoot, changeID, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("abandoning change %q requires a reason", changeID)
+	}
+
+	change, err := fs.Load(projectRoot, changeID)
+	if err != nil {
+		return err
+	}
+
+	if change.Status == StatusCompleted {
+		return fmt.Errorf("cannot abandon completed change %q — archive it instead", changeID)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	change.Status = StatusAbandoned
+	change.AbandonReason = strings.TrimSpace(reason)
+	change.AbandonedAt = now
+	change.UpdatedAt = now
+	return fs.moveToHistory(projectRoot, change)
+}
+
+// Reopen moves an archived or abandoned change from history/ back to
+// changes/ and binds it to the checked-out branch. It becomes the
+// active change unless another change is already active there, in
+// which case it is reopened as paused.
+func (fs *FileStore) Reopen(projectRoot, changeID string) (*ChangeRecord, error) {
+	srcDir := filepath.Join(HistoryPath(projectRoot), changeID)
+	change, err := readChangeRecord(filepath.Join(srcDir, ChangeConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("change %q not found in history", changeID)
+		}
+		return nil, fmt.Errorf("reading archived change %q: %w", changeID, err)
+	}
+
+	dstDir := ChangePath(projectRoot, changeID)
+	if _, err := os.Stat(dstDir); err == nil {
+		return nil, fmt.Errorf("change %q already exists in changes", changeID)
+	}
+
+	active, err := fs.LoadActive(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Status = StatusActive
+	if active != nil {
+		change.Status = StatusPaused
+	}
+	change.AbandonReason = ""
+	change.AbandonedAt = ""
+	change.BindToHead(projectRoot)
+	resumeCurrentStage(change)
+
+	if err := os.MkdirAll(ChangesPath(projectRoot), 0o755); err != nil {
+		return nil, fmt.Errorf("creating changes directory: %w", err)
+	}
+	if err := os.Rename(srcDir, dstDir); err != nil {
+		return nil, fmt.Errorf("moving change out of history: %w", err)
+	}
+	if err := fs.Save(projectRoot, change); err != nil {
+		return nil, fmt.Errorf("updating change status: %w", err)
+	}
+
+	return change, nil
+}
+
+// moveToHistory writes the (already updated) record and moves the
+// change directory from changes/ to history/.
+func (fs *FileStore) moveToHistory(projectRoot string, change *ChangeRecord) error {
+	srcDir := ChangePath(projectRoot, change.ID)
 	historyDir := HistoryPath(projectRoot)
 	if err := os.MkdirAll(historyDir, 0o755); err != nil {
 		return fmt.Errorf("creating history directory: %w", err)
 	}
 
-	dstDir := filepath.Join(historyDir, changeID)
+	dstDir := filepath.Join(historyDir, change.ID)
 	if _, err := os.Stat(dstDir); err == nil {
-		return fmt.Errorf("change %q already exists in history", changeID)
+		return fmt.Errorf("change %q already exists in history", change.ID)
 	}
 
 	// Update status before moving.
-	change.Status = StatusArchived
-	change.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
 	if err := fs.writeConfig(projectRoot, change); err != nil {
 		return fmt.Errorf("updating change status: %w", err)
 	}
@@ -204,22 +310,30 @@ func (fs *FileStore) List(projectRoot string) ([]ChangeRecord, error) {
 			if !entry.IsDir() {
 				continue
 			}
-			configPath := filepath.Join(historyDir, entry.Name(), ChangeConfigFile)
-			data, err := os.ReadFile(configPath)
+			change, err := readChangeRecord(filepath.Join(historyDir, entry.Name(), ChangeConfigFile))
 			if err != nil {
 				continue
 			}
-			var change ChangeRecord
-			if err := json.Unmarshal(data, &change); err != nil {
-				continue
-			}
-			result = append(result, change)
+			result = append(result, *change)
 		}
 	}
 
 	return result, nil
 }
 
+// readChangeRecord reads and parses a change.json file.
+func readChangeRecord(path string) (*ChangeRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var change ChangeRecord
+	if err := json.Unmarshal(data, &change); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &change, nil
+}
+
 // writeConfig marshals and writes a change record to its change.json.
 func (fs *FileStore) writeConfig(projectRoot string, change *ChangeRecord) error {
 	data, err := json.MarshalIndent(change, "", "  ")
@@ -573,6 +573,162 @@ func TestArchive_CreatesHistoryDirIfMissing(t *testing.T) {
 	}
 }
 
+// --- Abandon ---
+
+func TestAbandon_RecordsReasonAndMovesToHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore()
+	change := testChangeRecord("dead-end", "Dead end", TypeFeature, SizeSmall)
+
+	if err := store.Create(tmpDir, change); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := store.Abandon(tmpDir, "dead-end", "  superseded by upstream fix  "); err != nil {
+		t.Fatalf("Abandon failed: %v", err)
+	}
+
+	if _, err := os.Stat(ChangePath(tmpDir, "dead-end")); !os.IsNotExist(err) {
+		t.Error("abandoned change should be removed from changes/")
+	}
+
+	active, err := store.LoadActive(tmpDir)
+	if err != nil || active != nil {
+		t.Errorf("LoadActive after abandon = %v, %v; want nil", active, err)
+	}
+
+	list, _ := store.List(tmpDir)
+	if len(list) != 1 {
+		t.Fatalf("List returned %d changes, want 1", len(list))
+	}
+	got := list[0]
+	if got.Status != StatusAbandoned || got.AbandonReason != "superseded by upstream fix" || got.AbandonedAt == "" {
+		t.Errorf("abandoned record = %+v", got)
+	}
+}
+
+func TestAbandon_RequiresReason(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore()
+	if err := store.Create(tmpDir, testChangeRecord("c", "C", TypeFix, SizeSmall)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := store.Abandon(tmpDir, "c", "   "); err == nil {
+		t.Fatal("Abandon should require a reason")
+	}
+	if _, err := os.Stat(ChangePath(tmpDir, "c")); err != nil {
+		t.Error("change should stay in changes/ when abandon fails")
+	}
+}
+
+func TestAbandon_RefusesCompletedChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore()
+	change := testChangeRecord("done", "Done", TypeFix, SizeSmall)
+	change.Status = StatusCompleted
+	if err := store.Create(tmpDir, change); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	err := store.Abandon(tmpDir, "done", "no longer needed")
+	if err == nil || !containsStr(err.Error(), "archive it instead") {
+		t.Errorf("Abandon(completed) error = %v", err)
+	}
+}
+
+// --- Reopen ---
+
+func TestReopen_RestoresAbandonedChangeAsActive(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore()
+	if err := store.Create(tmpDir, testChangeRecord("retry", "Retry", TypeFeature, SizeSmall)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := store.Abandon(tmpDir, "retry", "blocked"); err != nil {
+		t.Fatalf("Abandon failed: %v", err)
+	}
+
+	reopened, err := store.Reopen(tmpDir, "retry")
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if reopened.Status != StatusActive || reopened.AbandonReason != "" || reopened.AbandonedAt != "" {
+		t.Errorf("reopened record = %+v", reopened)
+	}
+
+	if _, err := os.Stat(filepath.Join(HistoryPath(tmpDir), "retry")); !os.IsNotExist(err) {
+		t.Error("reopened change should be removed from history/")
+	}
+	active, err := store.LoadActive(tmpDir)
+	if err != nil || active == nil || active.ID != "retry" {
+		t.Errorf("LoadActive after reopen = %v, %v", active, err)
+	}
+}
+
+func TestReopen_CompletedChangeReopensFinalStage(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore()
+	change := testChangeRecord("shipped", "Shipped", TypeFix, SizeSmall)
+	for i := range change.Stages {
+		change.Stages[i].Status = "completed"
+		change.Stages[i].CompletedAt = "2026-01-02T00:00:00Z"
+	}
+	change.CurrentStage = change.Stages[len(change.Stages)-1].Name
+	change.Status = StatusCompleted
+	if err := store.Create(tmpDir, change); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := store.Archive(tmpDir, "shipped"); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	reopened, err := store.Reopen(tmpDir, "shipped")
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	last := reopened.Stages[len(reopened.Stages)-1]
+	if last.Status != "in_progress" || last.CompletedAt != "" || last.StartedAt == "" {
+		t.Errorf("final stage after reopen = %+v", last)
+	}
+}
+
+func TestReopen_PausedWhenAnotherChangeIsActive(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore()
+	if err := store.Create(tmpDir, testChangeRecord("old", "Old", TypeFix, SizeSmall)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := store.Abandon(tmpDir, "old", "later"); err != nil {
+		t.Fatalf("Abandon failed: %v", err)
+	}
+	if err := store.Create(tmpDir, testChangeRecord("current", "Current", TypeFix, SizeSmall)); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	reopened, err := store.Reopen(tmpDir, "old")
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if reopened.Status != StatusPaused {
+		t.Errorf("status = %s, want paused", reopened.Status)
+	}
+	active, _ := store.LoadActive(tmpDir)
+	if active == nil || active.ID != "current" {
+		t.Errorf("active change should stay %q, got %v", "current", active)
+	}
+}
+
+func TestReopen_NotInHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewFileStore()
+
+	_, err := store.Reopen(tmpDir, "ghost")
+	if err == nil || !containsStr(err.Error(), "not found in history") {
+		t.Errorf("Reopen(missing) error = %v", err)
+	}
+}
+
 // --- List ---
 
 func TestList_ReturnsActiveAndCompletedChanges(t *testing.T) {
@@ -28,20 +28,13 @@ const (
 	TypeEnhancement ChangeType = "enhancement"
 )
 
-// validTypes is the set of allowed change types.
-var validTypes = map[ChangeType]bool{
-	TypeFeature:     true,
-	TypeFix:         true,
-	TypeRefactor:    true,
-	TypeEnhancement: true,
-}
+// builtinTypes lists the compiled-in change types in display order.
+// Projects may declare more in docs/hoofy-flows.json.
+var builtinTypes = []ChangeType{TypeFeature, TypeFix, TypeRefactor, TypeEnhancement}
 
-// ValidateType returns an error if the type is not recognized.
+// ValidateType returns an error if the type is not in the current registry.
 func ValidateType(t ChangeType) error {
-	if !validTypes[t] {
-		return fmt.Errorf("invalid change type %q: must be one of: feature, fix, refactor, enhancement", t)
-	}
-	return nil
+	return CurrentRegistry().ValidateType(t)
 }
 
 // --- Change size enum ---
@@ -96,18 +89,38 @@ type ChangeStatus string
 
 const (
 	StatusActive    ChangeStatus = "active"
+	StatusPaused    ChangeStatus = "paused" // in flight, set aside for another change
 	StatusCompleted ChangeStatus = "completed"
 	StatusArchived  ChangeStatus = "archived"
+	StatusAbandoned ChangeStatus = "abandoned" // dropped before completion, kept in history
 )
 
+// validStatuses is the set of allowed change statuses.
+var validStatuses = map[ChangeStatus]bool{
+	StatusActive:    true,
+	StatusPaused:    true,
+	StatusCompleted: true,
+	StatusArchived:  true,
+	StatusAbandoned: true,
+}
+
+// ValidateStatus returns an error if the status is not recognized.
+func ValidateStatus(s ChangeStatus) error {
+	if !validStatuses[s] {
+		return fmt.Errorf("invalid change status %q: must be one of: active, paused, completed, archived, abandoned", s)
+	}
+	return nil
+}
+
 // --- Core data structures ---
 
 // StageEntry tracks progress for a single stage within a change.
 type StageEntry struct {
 	Name        ChangeStage `json:"name"`
-	Status      string      `json:"status"` // pending | in_progress | completed
+	Status      string      `json:"status"` // pending | in_progress | completed | stale
 	StartedAt   string      `json:"started_at,omitempty"`
 	CompletedAt string      `json:"completed_at,omitempty"`
+	Revision    int         `json:"revision,omitempty"` // times the stage was reopened by Rewind
 }
 
 // ChangeRecord is the root data structure for a change, persisted as change.json.
@@ -122,6 +135,34 @@ type ChangeRecord struct {
 	Status       ChangeStatus `json:"status"`
 	CreatedAt    string       `json:"created_at"`
 	UpdatedAt    string       `json:"updated_at"`
+
+	// Git binding. A change bound to a branch is only the active change
+	// while that branch is checked out; unbound changes match any branch.
+	Branch   string `json:"branch,omitempty"`
+	Worktree string `json:"worktree,omitempty"`
+
+	// Commit checked out when the change started, and the code captured
+	// against it when the verify stage completes.
+	BaseCommit string       `json:"base_commit,omitempty"`
+	Code       *CodeSummary `json:"code,omitempty"`
+
+	// Set when the change is abandoned; cleared again on reopen.
+	AbandonReason string `json:"abandon_reason,omitempty"`
+	AbandonedAt   string `json:"abandoned_at,omitempty"`
+
+	// Stages advanced with force despite failing validation.
+	Overrides []ValidationOverride `json:"validation_overrides,omitempty"`
+
+	// The spec delta merged into the project artifacts on completion.
+	SpecMerge *SpecMerge `json:"spec_merge,omitempty"`
+
+	// Size changes made while the change was in flight, oldest first.
+	Resizes []SizeChange `json:"resizes,omitempty"`
+
+	// Ordering links to other changes: DependsOn must land before this
+	// one, Blocks after it. Either side may declare a link.
+	DependsOn []string `json:"depends_on,omitempty"`
+	Blocks    []string `json:"blocks,omitempty"`
 }
 
 // ADR represents an Architecture Decision Record captured during a change.
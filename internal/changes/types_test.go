@@ -53,6 +53,19 @@ func TestValidateSize(t *testing.T) {
 	}
 }
 
+func TestValidateStatus(t *testing.T) {
+	for _, s := range []ChangeStatus{StatusActive, StatusPaused, StatusCompleted, StatusArchived, StatusAbandoned} {
+		if err := ValidateStatus(s); err != nil {
+			t.Errorf("ValidateStatus(%q) = %v, want nil", s, err)
+		}
+	}
+	for _, s := range []ChangeStatus{"", "done", "Active"} {
+		if err := ValidateStatus(s); err == nil {
+			t.Errorf("ValidateStatus(%q) should fail", s)
+		}
+	}
+}
+
 func TestSlugify(t *testing.T) {
 	tests := []struct {
 		name  string
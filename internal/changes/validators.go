@@ -0,0 +1,207 @@
+package changes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
+)
+
+// StageInput is what a StageValidator inspects: the content about to be
+// written for the change's current stage, plus where to find the
+// artifacts of earlier stages.
+type StageInput struct {
+	Change    *ChangeRecord
+	Stage     ChangeStage
+	ChangeDir string
+	Content   string
+}
+
+// Violation is one rule a stage artifact breaks.
+type Violation struct {
+	Rule    string `json:"rule"` // stable identifier, e.g. "tasks.acceptance-criteria"
+	Message string `json:"message"`
+}
+
+// StageValidator checks stage content before sdd_change_advance accepts
+// it. It returns nil when the content is acceptable.
+type StageValidator func(in StageInput) []Violation
+
+var (
+	validatorsMu    sync.RWMutex
+	stageValidators = map[ChangeStage][]StageValidator{
+		StageContextCheck: {validateContextCheck},
+		StageSpec:         {validateSpec},
+		StageTasks:        {validateTasks},
+		StageVerify:       {validateVerify},
+	}
+)
+
+// RegisterValidator adds a validator for a stage. Validators run in
+// registration order, after the built-in ones (OCP: new rules don't
+// touch existing ones).
+func RegisterValidator(stage ChangeStage, v StageValidator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	stageValidators[stage] = append(stageValidators[stage], v)
+}
+
+// ValidateStage runs every validator registered for in.Stage and returns
+// all violations found. Stages without validators always pass.
+func ValidateStage(in StageInput) []Violation {
+	validatorsMu.RLock()
+	validators := append([]StageValidator(nil), stageValidators[in.Stage]...)
+	validatorsMu.RUnlock()
+
+	var violations []Violation
+	for _, v := range validators {
+		violations = append(violations, v(in)...)
+	}
+	return violations
+}
+
+// ValidationOverride records that a stage was advanced with force despite
+// validation failures. Kept in change.json for later review.
+type ValidationOverride struct {
+	Stage      ChangeStage `json:"stage"`
+	Revision   int         `json:"revision,omitempty"`
+	Violations []Violation `json:"violations"`
+	ForcedAt   string      `json:"forced_at"`
+}
+
+// RecordOverride appends a ValidationOverride for the change's current stage.
+func RecordOverride(change *ChangeRecord, violations []Violation) {
+	override := ValidationOverride{
+		Stage:      change.CurrentStage,
+		Violations: violations,
+		ForcedAt:   timeNow().UTC().Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if idx := CurrentStageIndex(change); idx >= 0 {
+		override.Revision = change.Stages[idx].Revision
+	}
+	change.Overrides = append(change.Overrides, override)
+}
+
+// --- Built-in validators ---
+
+// requirementIDPattern matches requirement identifiers like FR-001 or NFR-002.
+var requirementIDPattern = regexp.MustCompile(`\bN?FR-\d{3,}\b`)
+
+// taskIDPattern matches task identifiers like TASK-001, whole: TASK-1000
+// is not a mention of TASK-100.
+var taskIDPattern = regexp.MustCompile(`\bTASK-\d{3,}\b`)
+
+// artifactRefPattern matches a referenced file such as docs/design.md or CLAUDE.md.
+var artifactRefPattern = regexp.MustCompile(`[\w./-]+\.(?:md|json|ya?ml|txt)\b`)
+
+// noArtifactsPattern matches an explicit statement that nothing was found.
+var noArtifactsPattern = regexp.MustCompile(`(?i)\bno (?:sdd |existing )?(?:artifacts|specs)\b`)
+
+// validateContextCheck requires the context check to name the artifacts
+// it read, or to say there were none.
+func validateContextCheck(in StageInput) []Violation {
+	if artifactRefPattern.MatchString(in.Content) || noArtifactsPattern.MatchString(in.Content) {
+		return nil
+	}
+	return []Violation{{
+		Rule: "context-check.artifacts",
+		Message: "List the artifacts you checked (e.g. `docs/business-rules.md`, `CLAUDE.md`), " +
+			"or state \"no artifacts found\" if the project has none.",
+	}}
+}
+
+// validateSpec requires at least one requirement ID.
+func validateSpec(in StageInput) []Violation {
+	if requirementIDPattern.MatchString(in.Content) {
+		return nil
+	}
+	return []Violation{{
+		Rule:    "spec.requirement-ids",
+		Message: "Define requirements with unique IDs (FR-001 for functional, NFR-001 for non-functional).",
+	}}
+}
+
+// validateTasks requires TASK-NNN items, each with acceptance criteria
+// and none defined twice, whose dependencies name defined tasks and
+// don't form a cycle.
+func validateTasks(in StageInput) []Violation {
+	tasks := taskgraph.Parse(in.Content)
+	if len(tasks) == 0 {
+		return []Violation{{
+			Rule:    "tasks.ids",
+			Message: "Define each task as a heading or list item starting with a unique ID, e.g. `### TASK-001: Add login endpoint`.",
+		}}
+	}
+
+	var violations []Violation
+	seen := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		if seen[task.ID] {
+			violations = append(violations, Violation{
+				Rule:    "tasks.duplicate-id",
+				Message: fmt.Sprintf("%s is defined more than once.", task.ID),
+			})
+			continue
+		}
+		seen[task.ID] = true
+		if !strings.Contains(strings.ToLower(task.Body), "acceptance criteria") {
+			violations = append(violations, Violation{
+				Rule:    "tasks.acceptance-criteria",
+				Message: fmt.Sprintf("%s has no acceptance criteria — add an **Acceptance Criteria** list.", task.ID),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return violations
+	}
+
+	for _, task := range tasks {
+		for _, dep := range task.Dependencies {
+			if !seen[dep] || dep == task.ID {
+				violations = append(violations, Violation{
+					Rule:    "tasks.unknown-dependency",
+					Message: fmt.Sprintf("%s depends on %s, which is not a task in this breakdown.", task.ID, dep),
+				})
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return violations
+	}
+	if _, err := taskgraph.Build(tasks); err != nil {
+		violations = append(violations, Violation{
+			Rule:    "tasks.dependency-cycle",
+			Message: fmt.Sprintf("%v — break the cycle so the tasks can be ordered into waves.", err),
+		})
+	}
+	return violations
+}
+
+// validateVerify requires the verification to mention every task defined
+// in the change's tasks artifact. Flows without a tasks stage pass.
+func validateVerify(in StageInput) []Violation {
+	data, err := os.ReadFile(filepath.Join(in.ChangeDir, StageFilename(StageTasks)))
+	if err != nil {
+		return nil
+	}
+
+	mentioned := taskIDPattern.FindAllString(in.Content, -1)
+	var missing []string
+	for _, task := range taskgraph.Parse(string(data)) {
+		if !slices.Contains(mentioned, task.ID) && !slices.Contains(missing, task.ID) {
+			missing = append(missing, task.ID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return []Violation{{
+		Rule:    "verify.tasks-covered",
+		Message: fmt.Sprintf("Report the outcome of every task — missing: %s.", strings.Join(missing, ", ")),
+	}}
+}
@@ -0,0 +1,112 @@
+package changes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// rules returns the Rule of each violation.
+func rules(violations []Violation) []string {
+	out := make([]string, len(violations))
+	for i, v := range violations {
+		out[i] = v.Rule
+	}
+	return out
+}
+
+func TestValidateStage_BuiltIns(t *testing.T) {
+	tests := []struct {
+		name    string
+		stage   ChangeStage
+		content string
+		want    string // comma-joined rules; "" = valid
+	}{
+		{"context-check names artifact", StageContextCheck, "Checked docs/business-rules.md — no conflicts.", ""},
+		{"context-check says none", StageContextCheck, "No SDD artifacts found; nothing to conflict with.", ""},
+		{"context-check vague", StageContextCheck, "All clear.", "context-check.artifacts"},
+		{"spec with IDs", StageSpec, "- **FR-001**: Users can log in\n- **NFR-001**: p95 < 200ms", ""},
+		{"spec without IDs", StageSpec, "Users can log in.", "spec.requirement-ids"},
+		{"tasks valid", StageTasks, "### TASK-001: Model\n**Acceptance Criteria**:\n- [ ] saved\n\n- **TASK-002**: Endpoint\n  Acceptance criteria: 201 on success", ""},
+		{"tasks without IDs", StageTasks, "- [ ] do the thing", "tasks.ids"},
+		{"tasks missing criteria", StageTasks, "### TASK-001: Model\n**Acceptance Criteria**: saved\n### TASK-002: Endpoint\nJust do it.", "tasks.acceptance-criteria"},
+		{"tasks duplicate", StageTasks, "### TASK-001: A\nAcceptance criteria: x\n### TASK-001: B\nAcceptance criteria: y", "tasks.duplicate-id"},
+		{"tasks with dependencies", StageTasks, "### TASK-001: A\nAcceptance criteria: x\n### TASK-002: B\n**Dependencies**: TASK-001\nAcceptance criteria: y", ""},
+		{"tasks unknown dependency", StageTasks, "### TASK-001: A\n**Dependencies**: TASK-009\nAcceptance criteria: x", "tasks.unknown-dependency"},
+		{"tasks dependency cycle", StageTasks, "### TASK-001: A\n**Dependencies**: TASK-002\nAcceptance criteria: x\n### TASK-002: B\n**Dependencies**: TASK-001\nAcceptance criteria: y", "tasks.dependency-cycle"},
+		{"ID mentioned mid-line is not a task", StageTasks, "See TASK-001 in the old plan.", "tasks.ids"},
+		{"describe has no validators", StageDescribe, "anything", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(rules(ValidateStage(StageInput{Stage: tt.stage, ChangeDir: t.TempDir(), Content: tt.content})), ",")
+			if got != tt.want {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateStage_VerifyCoversTasks(t *testing.T) {
+	dir := t.TempDir()
+	in := StageInput{Stage: StageVerify, ChangeDir: dir, Content: "TASK-001 passes."}
+
+	// No tasks.md (flow without a tasks stage): nothing to cover.
+	if v := ValidateStage(in); len(v) != 0 {
+		t.Errorf("without tasks.md verify should pass, got %+v", v)
+	}
+
+	tasks := "### TASK-001: A\nAcceptance criteria: x\n### TASK-002: B\nAcceptance criteria: y\n### TASK-003: C\nAcceptance criteria: z\n"
+	if err := os.WriteFile(filepath.Join(dir, StageFilename(StageTasks)), []byte(tasks), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	v := ValidateStage(in)
+	if len(v) != 1 || v[0].Rule != "verify.tasks-covered" || !strings.Contains(v[0].Message, "TASK-002, TASK-003") {
+		t.Errorf("violations = %+v, want missing TASK-002, TASK-003", v)
+	}
+
+	in.Content = "TASK-001, TASK-002 and TASK-003 all pass."
+	if v := ValidateStage(in); len(v) != 0 {
+		t.Errorf("all tasks mentioned, got %+v", v)
+	}
+
+	// A longer ID sharing the prefix is not a mention.
+	in.Content = "TASK-001 and TASK-0020 pass; TASK-003x too, and TASK-003 as well."
+	v = ValidateStage(in)
+	if len(v) != 1 || !strings.Contains(v[0].Message, "missing: TASK-002.") {
+		t.Errorf("violations = %+v, want missing TASK-002", v)
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+	stage := ChangeStage("test-register-validator")
+	RegisterValidator(stage, func(in StageInput) []Violation {
+		if !strings.Contains(in.Content, "rollback") {
+			return []Violation{{Rule: "custom.rollback", Message: "describe the rollback"}}
+		}
+		return nil
+	})
+
+	if v := ValidateStage(StageInput{Stage: stage, Content: "deploy"}); len(v) != 1 || v[0].Rule != "custom.rollback" {
+		t.Errorf("custom validator not run: %+v", v)
+	}
+	if v := ValidateStage(StageInput{Stage: stage, Content: "rollback: redeploy v1"}); len(v) != 0 {
+		t.Errorf("custom validator should pass: %+v", v)
+	}
+}
+
+func TestRecordOverride(t *testing.T) {
+	change := testChangeRecord("forced", "Forced", TypeFix, SizeSmall)
+	change.CurrentStage = StageTasks
+	change.Stages[2].Revision = 1
+
+	RecordOverride(change, []Violation{{Rule: "tasks.ids", Message: "m"}})
+	if len(change.Overrides) != 1 {
+		t.Fatalf("Overrides = %+v", change.Overrides)
+	}
+	o := change.Overrides[0]
+	if o.Stage != StageTasks || o.Revision != 1 || o.ForcedAt == "" || o.Violations[0].Rule != "tasks.ids" {
+		t.Errorf("override = %+v", o)
+	}
+}
@@ -0,0 +1,145 @@
+// Package check runs deterministic spec gates over a project's artifacts.
+//
+// Everything else in Hoofy validates through an AI calling MCP tools, so
+// nothing stops a commit that drops a requirement on the floor. These
+// gates read the markdown artifacts directly and apply fixed rules —
+// same input, same verdict — so `hoofy check` can run in CI:
+//
+//   - coverage:   every FR/NFR in requirements.md is referenced by a TASK
+//     (for one a change's delta added, a task in that change counts)
+//   - references: tasks.md only references FR/NFR/TASK IDs that exist
+//   - clarity:    the clarity score meets pipeline.ClarityThreshold
+//   - changes:    no active change is stuck mid-flow
+//
+// Gates whose inputs don't exist yet (e.g. no tasks.md) are skipped, not
+// failed, so the command is safe to add to CI on day one.
+package check
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status is the verdict of a single result.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// Gate names.
+const (
+	GateCoverage   = "coverage"
+	GateReferences = "references"
+	GateClarity    = "clarity"
+	GateChanges    = "changes"
+)
+
+// Gates lists every gate in report order.
+var Gates = []string{GateCoverage, GateReferences, GateClarity, GateChanges}
+
+// Result is one checked item — a requirement, a task reference, a change.
+type Result struct {
+	Gate    string `json:"gate"`
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report holds every result from a run.
+type Report struct {
+	ProjectRoot string   `json:"project_root"`
+	Results     []Result `json:"results"`
+}
+
+// DefaultStaleAfter is how long an active change may go without an
+// update before the changes gate reports it as stuck.
+const DefaultStaleAfter = 72 * time.Hour
+
+// Options tunes the gates.
+type Options struct {
+	// StaleAfter limits the changes gate to active changes not updated
+	// within this duration, so work in flight on a feature branch passes.
+	// Zero means DefaultStaleAfter.
+	StaleAfter time.Duration
+	// Now is the reference time for StaleAfter (defaults to time.Now).
+	Now func() time.Time
+}
+
+// Run executes every gate against the project at root.
+func Run(root string, opts Options) (*Report, error) {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = DefaultStaleAfter
+	}
+
+	r := &Report{ProjectRoot: root}
+
+	art, err := loadArtifacts(root)
+	if err != nil {
+		return nil, err
+	}
+	r.add(checkCoverage(art)...)
+	r.add(checkReferences(art)...)
+
+	clarity, err := checkClarity(root)
+	if err != nil {
+		return nil, err
+	}
+	r.add(clarity...)
+
+	changeResults, err := checkChanges(root, opts)
+	if err != nil {
+		return nil, err
+	}
+	r.add(changeResults...)
+
+	return r, nil
+}
+
+func (r *Report) add(results ...Result) {
+	r.Results = append(r.Results, results...)
+}
+
+// Count returns how many results have the given status.
+func (r *Report) Count(s Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed reports whether any gate failed.
+func (r *Report) Failed() bool {
+	return r.Count(StatusFail) > 0
+}
+
+// ByGate returns the results for one gate, in order.
+func (r *Report) ByGate(gate string) []Result {
+	var out []Result
+	for _, res := range r.Results {
+		if res.Gate == gate {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+func pass(gate, name, msg string) Result {
+	return Result{Gate: gate, Name: name, Status: StatusPass, Message: msg}
+}
+
+func failf(gate, name, format string, args ...any) Result {
+	return Result{Gate: gate, Name: name, Status: StatusFail, Message: fmt.Sprintf(format, args...)}
+}
+
+func skip(gate, msg string) Result {
+	return Result{Gate: gate, Name: gate, Status: StatusSkip, Message: msg}
+}
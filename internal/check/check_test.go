@@ -0,0 +1,391 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+const requirementsMD = `# Demo — Requirements
+
+## Functional Requirements
+
+### Must Have
+
+- **FR-001**: Users can sign up
+- **FR-002**: Users can log in
+
+### Won't Have (this version)
+
+- **FR-009**: Social login
+
+## Non-Functional Requirements
+
+- **NFR-001**: Login responds in under 200ms
+`
+
+const tasksMD = `# Demo — Implementation Tasks
+
+## Tasks
+
+### TASK-001: Sign-up endpoint
+Covers FR-001.
+
+### TASK-002: Login endpoint
+Covers FR-002 and NFR-001. Depends on TASK-001.
+
+## Dependency Graph
+
+TASK-001 → TASK-002
+`
+
+func newProject(t *testing.T, stage config.Stage, score int) string {
+	t.Helper()
+	root := t.TempDir()
+	cfg := config.NewProjectConfig("demo", "demo", config.ModeGuided)
+	cfg.CurrentStage = stage
+	cfg.ClarityScore = score
+	if err := config.NewFileStore().Save(root, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func writeArtifact(t *testing.T, root string, stage config.Stage, content string) {
+	t.Helper()
+	if err := os.WriteFile(config.StagePath(root, stage), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findResult(results []Result, name string) *Result {
+	for i := range results {
+		if results[i].Name == name {
+			return &results[i]
+		}
+	}
+	return nil
+}
+
+func TestRun_AllGatesPass(t *testing.T) {
+	root := newProject(t, config.StageTasks, 80)
+	writeArtifact(t, root, config.StageSpecify, requirementsMD)
+	writeArtifact(t, root, config.StageTasks, tasksMD)
+
+	r, err := Run(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Failed() {
+		t.Fatalf("expected pass, got %+v", r.Results)
+	}
+
+	coverage := r.ByGate(GateCoverage)
+	if len(coverage) != 3 {
+		t.Fatalf("coverage results = %d, want 3 (FR-009 is out of scope): %+v", len(coverage), coverage)
+	}
+	if res := findResult(coverage, "NFR-001"); res == nil || !strings.Contains(res.Message, "TASK-002") {
+		t.Errorf("NFR-001 coverage = %+v", res)
+	}
+}
+
+func TestRun_UncoveredRequirementFails(t *testing.T) {
+	root := newProject(t, config.StageTasks, 80)
+	writeArtifact(t, root, config.StageSpecify, requirementsMD+"- **FR-003**: Password reset\n")
+	writeArtifact(t, root, config.StageTasks, tasksMD)
+
+	r, err := Run(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := findResult(r.ByGate(GateCoverage), "FR-003")
+	if res == nil || res.Status != StatusFail {
+		t.Fatalf("expected FR-003 to fail coverage, got %+v", res)
+	}
+	if !r.Failed() {
+		t.Error("report should fail")
+	}
+}
+
+func TestRun_RemovedRequirementNeedsNoTask(t *testing.T) {
+	root := newProject(t, config.StageTasks, 80)
+	writeArtifact(t, root, config.StageSpecify, requirementsMD+"- ~~**FR-003**: Magic link login~~ _(removed by `add-sso`)_\n")
+	writeArtifact(t, root, config.StageTasks, tasksMD)
+
+	r, err := Run(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := findResult(r.ByGate(GateCoverage), "FR-003"); res != nil {
+		t.Errorf("struck-through FR-003 should not need coverage: %+v", res)
+	}
+	if r.Failed() {
+		t.Errorf("expected pass, got %+v", r.Results)
+	}
+}
+
+func TestRun_DependencyGraphDoesNotCountAsCoverage(t *testing.T) {
+	root := newProject(t, config.StageTasks, 80)
+	writeArtifact(t, root, config.StageSpecify, requirementsMD)
+	writeArtifact(t, root, config.StageTasks, strings.Replace(tasksMD, "Covers FR-001.", "Scaffolding.", 1)+"\nFR-001 is handled somewhere.\n")
+
+	r, err := Run(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := findResult(r.ByGate(GateCoverage), "FR-001"); res == nil || res.Status != StatusFail {
+		t.Fatalf("FR-001 mentioned outside a task block should not be covered: %+v", res)
+	}
+}
+
+func TestRun_PlainListItemTasks(t *testing.T) {
+	root := newProject(t, config.StageTasks, 80)
+	writeArtifact(t, root, config.StageSpecify, requirementsMD)
+	writeArtifact(t, root, config.StageTasks, "# Tasks\n\n- TASK-001: Sign-up endpoint (FR-001)\n- TASK-002: Login endpoint (FR-002, NFR-001)\n")
+
+	r, err := Run(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Failed() {
+		t.Fatalf("plain list-item tasks should count, got %+v", r.Results)
+	}
+	if res := findResult(r.ByGate(GateCoverage), "FR-002"); res == nil || res.Message != "covered by TASK-002" {
+		t.Errorf("FR-002 coverage = %+v", res)
+	}
+}
+
+func TestRun_MergedRequirementCoveredByChangeTasks(t *testing.T) {
+	root := newProject(t, config.StageTasks, 80)
+	writeArtifact(t, root, config.StageSpecify, requirementsMD)
+	writeArtifact(t, root, config.StageTasks, tasksMD)
+
+	store := changes.NewFileStore()
+	rec := &changes.ChangeRecord{ID: "add-export", Type: changes.TypeFeature, Size: changes.SizeMedium, Status: changes.StatusCompleted}
+	if err := store.Create(root, rec); err != nil {
+		t.Fatal(err)
+	}
+	dir := changes.ChangePath(root, rec.ID)
+	for name, content := range map[string]string{
+		changes.StageFilename(changes.StageSpec):  "## ADDED Requirements\n\n- **FR-001**: Users can export reports as CSV\n- **FR-002**: Users can export reports as PDF\n",
+		changes.StageFilename(changes.StageTasks): "## Tasks\n\n### TASK-001: CSV export\nImplements FR-001.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plan, err := changes.PlanSpecDelta(root, rec)
+	if err != nil || plan == nil {
+		t.Fatalf("PlanSpecDelta = %v, %v", plan, err)
+	}
+	if err := plan.Apply(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(root, rec); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func() {
+		t.Helper()
+		r, err := Run(root, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		coverage := r.ByGate(GateCoverage)
+		// The change's local FR-001 became the project's FR-010.
+		if res := findResult(coverage, "FR-010"); res == nil || res.Status != StatusPass || res.Message != "covered by TASK-001 (add-export)" {
+			t.Errorf("FR-010 coverage = %+v", res)
+		}
+		// No task of the change implements its FR-002 (now FR-011).
+		if res := findResult(coverage, "FR-011"); res == nil || res.Status != StatusFail || !strings.Contains(res.Message, "add-export") {
+			t.Errorf("FR-011 coverage = %+v", res)
+		}
+	}
+	check()
+
+	// Archived changes still count.
+	if err := store.Archive(root, rec.ID); err != nil {
+		t.Fatal(err)
+	}
+	check()
+}
+
+func TestRun_UndefinedReferencesFail(t *testing.T) {
+	root := newProject(t, config.StageTasks, 80)
+	writeArtifact(t, root, config.StageSpecify, requirementsMD)
+	writeArtifact(t, root, config.StageTasks, tasksMD+"\nTASK-002 → TASK-007\n\n### TASK-003: Audit log\nCovers FR-042.\n")
+
+	r, err := Run(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := r.ByGate(GateReferences)
+	for _, id := range []string{"FR-042", "TASK-007"} {
+		if res := findResult(refs, id); res == nil || res.Status != StatusFail {
+			t.Errorf("expected %s to fail references, got %+v", id, refs)
+		}
+	}
+	if findResult(refs, "TASK-003") != nil {
+		t.Error("TASK-003 is defined and should not be reported")
+	}
+}
+
+func TestRun_ClarityGate(t *testing.T) {
+	tests := []struct {
+		name  string
+		stage config.Stage
+		score int
+		want  Status
+	}{
+		{"before clarify", config.StageSpecify, 0, StatusSkip},
+		{"below threshold", config.StageDesign, 40, StatusFail},
+		{"meets threshold", config.StageDesign, 70, StatusPass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Run(newProject(t, tt.stage, tt.score), Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := r.ByGate(GateClarity)
+			if len(got) != 1 || got[0].Status != tt.want {
+				t.Errorf("clarity = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_MissingArtifactsSkip(t *testing.T) {
+	r, err := Run(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Failed() {
+		t.Fatalf("empty project should not fail: %+v", r.Results)
+	}
+	for _, gate := range []string{GateCoverage, GateReferences, GateClarity} {
+		if got := r.ByGate(gate); len(got) != 1 || got[0].Status != StatusSkip {
+			t.Errorf("%s = %+v, want skip", gate, got)
+		}
+	}
+}
+
+func TestRun_StuckChangeFails(t *testing.T) {
+	root := newProject(t, config.StageInit, 0)
+	flow, _ := changes.StageFlow(changes.TypeFix, changes.SizeSmall)
+	rec := &changes.ChangeRecord{
+		ID: "fix-login", Type: changes.TypeFix, Size: changes.SizeSmall,
+		Status: changes.StatusActive, CurrentStage: flow[1],
+	}
+	for _, s := range flow {
+		rec.Stages = append(rec.Stages, changes.StageEntry{Name: s, Status: "pending"})
+	}
+	if err := changes.NewFileStore().Save(root, rec); err != nil {
+		t.Fatal(err)
+	}
+
+	// A change in flight on a feature branch is not stuck.
+	r, err := Run(root, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := findResult(r.ByGate(GateChanges), "fix-login"); res == nil || res.Status != StatusPass {
+		t.Fatalf("expected fresh change to pass by default, got %+v", r.ByGate(GateChanges))
+	}
+
+	// Idle past DefaultStaleAfter: stuck.
+	later := func() time.Time { return time.Now().Add(DefaultStaleAfter + time.Hour) }
+	r, err = Run(root, Options{Now: later})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := findResult(r.ByGate(GateChanges), "fix-login"); res == nil || res.Status != StatusFail || !strings.Contains(res.Message, "stuck at stage") {
+		t.Fatalf("expected idle change to fail, got %+v", r.ByGate(GateChanges))
+	}
+
+	// A shorter --stale catches it sooner.
+	soon := func() time.Time { return time.Now().Add(2 * time.Hour) }
+	r, err = Run(root, Options{StaleAfter: time.Hour, Now: soon})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := findResult(r.ByGate(GateChanges), "fix-login"); res == nil || res.Status != StatusFail {
+		t.Fatalf("expected change idle past StaleAfter to fail, got %+v", r.ByGate(GateChanges))
+	}
+}
+
+// --- Output formats ---
+
+func sampleReport() *Report {
+	return &Report{ProjectRoot: "/p", Results: []Result{
+		pass(GateCoverage, "FR-001", "covered by TASK-001"),
+		failf(GateCoverage, "FR-002", "FR-002 is not referenced by any task in tasks.md"),
+		skip(GateClarity, "no hoofy.json"),
+	}}
+}
+
+func TestWrite_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().Write(&buf, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Passed  bool
+		Summary struct{ Pass, Fail, Skip int }
+		Results []Result
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if out.Passed || out.Summary.Fail != 1 || out.Summary.Pass != 1 || len(out.Results) != 3 {
+		t.Errorf("unexpected JSON: %s", buf.String())
+	}
+}
+
+func TestWrite_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().Write(&buf, FormatJUnit); err != nil {
+		t.Fatal(err)
+	}
+	var doc junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 3 || doc.Failures != 1 || doc.Skipped != 1 || len(doc.Suites) != 2 {
+		t.Errorf("unexpected totals: %+v", doc)
+	}
+	cov := doc.Suites[0]
+	if cov.Name != GateCoverage || cov.Cases[1].Failure == nil || cov.Cases[0].Failure != nil {
+		t.Errorf("unexpected coverage suite: %+v", cov)
+	}
+}
+
+func TestWrite_Text(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().Write(&buf, FormatText); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"❌ FR-002", "1 passed, 1 failed, 1 skipped"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(""); err != nil || f != FormatText {
+		t.Errorf("ParseFormat(\"\") = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,324 @@
+package check
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/pipeline"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
+)
+
+var (
+	// requirementIDPattern matches FR-NNN and NFR-NNN (same as sdd_audit).
+	requirementIDPattern = regexp.MustCompile(`\b((?:FR|NFR)-\d{3,4})\b`)
+	// taskIDPattern matches any TASK-NNN reference (same as taskgraph).
+	taskIDPattern  = regexp.MustCompile(`\bTASK-\d{3,}\b`)
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	// outOfScopePattern marks requirement sections that need no tasks.
+	outOfScopePattern = regexp.MustCompile(`(?i)won['’]?t\s+have|out\s+of\s+scope`)
+	// removedPattern marks a requirement struck through by a change's
+	// REMOVED delta ("- ~~**FR-003**: ...~~").
+	removedPattern = regexp.MustCompile(`^\s*[-*]\s+~~`)
+)
+
+// artifacts is the parsed content of requirements.md and tasks.md.
+type artifacts struct {
+	requirementsPath string
+	tasksPath        string
+	hasRequirements  bool
+	hasTasks         bool
+
+	// requirements are in-scope IDs in first-seen order.
+	requirements []string
+	// defined holds every FR/NFR ID in requirements.md, including out-of-scope ones.
+	defined map[string]bool
+
+	// tasks maps TASK ID → FR/NFR IDs referenced in its block.
+	tasks     map[string][]string
+	taskOrder []string
+	// reqRefs and taskRefs are every FR/NFR and TASK ID mentioned anywhere in tasks.md.
+	reqRefs  []string
+	taskRefs []string
+
+	// changeTasks maps a requirement a change's delta ADDED to the
+	// project → the change's tasks that reference its local ID, as
+	// "TASK-002 (add-export)". Those tasks live in the change's own
+	// tasks.md, not the project's.
+	changeTasks map[string][]string
+	// addedBy maps a merged requirement → the change that added it and
+	// its local ID there.
+	addedBy map[string]addition
+}
+
+// addition is where a merged requirement came from.
+type addition struct {
+	change, local string
+}
+
+func loadArtifacts(root string) (*artifacts, error) {
+	a := &artifacts{
+		requirementsPath: config.StagePath(root, config.StageSpecify),
+		tasksPath:        config.StagePath(root, config.StageTasks),
+		defined:          make(map[string]bool),
+		tasks:            make(map[string][]string),
+		changeTasks:      make(map[string][]string),
+		addedBy:          make(map[string]addition),
+	}
+
+	reqs, err := readOptional(a.requirementsPath)
+	if err != nil {
+		return nil, err
+	}
+	if reqs != "" {
+		a.hasRequirements = true
+		a.parseRequirements(reqs)
+	}
+
+	tasks, err := readOptional(a.tasksPath)
+	if err != nil {
+		return nil, err
+	}
+	if tasks != "" {
+		a.hasTasks = true
+		a.parseTasks(tasks)
+	}
+
+	if err := a.loadChangeTasks(root); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// loadChangeTasks credits requirements merged by a change's spec delta
+// to that change's tasks. IDs under ADDED are local to the change, so
+// its tasks.md references them by the local ID recorded in SpecMerge.
+func (a *artifacts) loadChangeTasks(root string) error {
+	all, err := changes.NewFileStore().List(root)
+	if err != nil {
+		return err
+	}
+	for i := range all {
+		c := &all[i]
+		if c.SpecMerge == nil || len(c.SpecMerge.Added) == 0 {
+			continue
+		}
+		content, err := readOptional(filepath.Join(changes.ChangePath(root, c.ID), changes.StageFilename(changes.StageTasks)))
+		if err != nil {
+			return err
+		}
+		if content == "" {
+			content, err = readOptional(filepath.Join(changes.HistoryPath(root), c.ID, changes.StageFilename(changes.StageTasks)))
+			if err != nil {
+				return err
+			}
+		}
+		tasks := taskgraph.Parse(content)
+		for _, m := range c.SpecMerge.Added {
+			a.addedBy[m.Project] = addition{change: c.ID, local: m.Local}
+			for _, t := range tasks {
+				label := fmt.Sprintf("%s (%s)", t.ID, c.ID)
+				if slices.Contains(requirementIDPattern.FindAllString(t.Body, -1), m.Local) && !slices.Contains(a.changeTasks[m.Project], label) {
+					a.changeTasks[m.Project] = append(a.changeTasks[m.Project], label)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseRequirements collects FR/NFR IDs, skipping those that only appear
+// under a "Won't Have" / "Out of scope" heading or are struck through.
+func (a *artifacts) parseRequirements(content string) {
+	outOfScopeLevel := 0 // heading level that opened an out-of-scope section; 0 = in scope
+	for _, line := range strings.Split(content, "\n") {
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			level := len(m[1])
+			if outOfScopeLevel > 0 && level <= outOfScopeLevel {
+				outOfScopeLevel = 0
+			}
+			if outOfScopePattern.MatchString(m[2]) {
+				outOfScopeLevel = level
+			}
+			continue
+		}
+		removed := removedPattern.MatchString(line)
+		for _, id := range requirementIDPattern.FindAllString(line, -1) {
+			if a.defined[id] {
+				continue
+			}
+			a.defined[id] = true
+			if outOfScopeLevel == 0 && !removed {
+				a.requirements = append(a.requirements, id)
+			}
+		}
+	}
+}
+
+// parseTasks splits tasks.md into task blocks with taskgraph.Parse, so
+// the gates and sdd_task agree on what a task is. A block ends at the
+// next heading at its level or above ("## Dependency Graph"), so
+// references in summary sections don't count as coverage.
+func (a *artifacts) parseTasks(content string) {
+	for _, t := range taskgraph.Parse(content) {
+		if _, seen := a.tasks[t.ID]; !seen {
+			a.taskOrder = append(a.taskOrder, t.ID)
+		}
+		a.tasks[t.ID] = append(a.tasks[t.ID], requirementIDPattern.FindAllString(t.Body, -1)...)
+	}
+	a.reqRefs = requirementIDPattern.FindAllString(content, -1)
+	a.taskRefs = taskIDPattern.FindAllString(content, -1)
+}
+
+// checkCoverage fails every in-scope requirement no task references —
+// in tasks.md or, for a requirement a change added, in the change's tasks.md.
+func checkCoverage(a *artifacts) []Result {
+	switch {
+	case !a.hasRequirements:
+		return []Result{skip(GateCoverage, "requirements.md not found")}
+	case !a.hasTasks:
+		return []Result{skip(GateCoverage, "tasks.md not found")}
+	case len(a.requirements) == 0:
+		return []Result{skip(GateCoverage, "no FR/NFR IDs in requirements.md")}
+	case len(a.taskOrder) == 0:
+		return []Result{failf(GateCoverage, "tasks.md", "no task definitions found (expected headings like \"### TASK-001: ...\" or list items like \"- TASK-001: ...\")")}
+	}
+
+	coveredBy := make(map[string][]string)
+	for _, task := range a.taskOrder {
+		for _, id := range a.tasks[task] {
+			if !slices.Contains(coveredBy[id], task) {
+				coveredBy[id] = append(coveredBy[id], task)
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(a.requirements))
+	for _, id := range a.requirements {
+		if tasks := append(coveredBy[id], a.changeTasks[id]...); len(tasks) > 0 {
+			results = append(results, pass(GateCoverage, id, "covered by "+strings.Join(tasks, ", ")))
+		} else if added, ok := a.addedBy[id]; ok {
+			results = append(results, failf(GateCoverage, id, "%s is not referenced by any task in tasks.md or in the tasks of %s, which added it as %s", id, added.change, added.local))
+		} else {
+			results = append(results, failf(GateCoverage, id, "%s is not referenced by any task in tasks.md", id))
+		}
+	}
+	return results
+}
+
+// checkReferences fails every ID tasks.md mentions that isn't defined.
+func checkReferences(a *artifacts) []Result {
+	if !a.hasTasks {
+		return []Result{skip(GateReferences, "tasks.md not found")}
+	}
+
+	var results []Result
+	seen := make(map[string]bool)
+	for _, id := range a.reqRefs {
+		if seen[id] || a.defined[id] {
+			continue
+		}
+		seen[id] = true
+		if !a.hasRequirements {
+			continue
+		}
+		results = append(results, failf(GateReferences, id, "%s is referenced in tasks.md but not defined in requirements.md", id))
+	}
+	for _, id := range a.taskRefs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, ok := a.tasks[id]; !ok {
+			results = append(results, failf(GateReferences, id, "%s is referenced in tasks.md but never defined", id))
+		}
+	}
+
+	if len(results) == 0 {
+		return []Result{pass(GateReferences, "tasks.md", fmt.Sprintf("%d task(s), all references resolve", len(a.taskOrder)))}
+	}
+	return results
+}
+
+// checkClarity applies the Clarity Gate once the pipeline has reached it.
+func checkClarity(root string) ([]Result, error) {
+	if !config.Exists(root) {
+		return []Result{skip(GateClarity, "no hoofy.json")}, nil
+	}
+	cfg, err := config.NewFileStore().Load(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if st := cfg.StageStatus[config.StageClarify]; st.Status == "skipped" {
+		return []Result{skip(GateClarity, "clarify stage was skipped")}, nil
+	}
+	if pipeline.StageIndex(cfg.CurrentStage) < pipeline.StageIndex(config.StageClarify) {
+		return []Result{skip(GateClarity, fmt.Sprintf("pipeline has not reached clarify (current stage: %s)", cfg.CurrentStage))}, nil
+	}
+
+	threshold := pipeline.ClarityThreshold(cfg.Mode)
+	if cfg.ClarityScore < threshold {
+		return []Result{failf(GateClarity, "clarity_score", "clarity score %d is below the %s-mode threshold of %d", cfg.ClarityScore, cfg.Mode, threshold)}, nil
+	}
+	return []Result{pass(GateClarity, "clarity_score", fmt.Sprintf("score %d meets the %s-mode threshold of %d", cfg.ClarityScore, cfg.Mode, threshold))}, nil
+}
+
+// checkChanges fails active changes stuck mid-flow: not updated within
+// StaleAfter (or with no readable update time). Recently updated
+// changes are work in progress and pass.
+func checkChanges(root string, opts Options) ([]Result, error) {
+	all, err := changes.NewFileStore().List(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for i := range all {
+		c := &all[i]
+		if c.Status != changes.StatusActive {
+			continue
+		}
+		idx := changes.CurrentStageIndex(c)
+		if idx < 0 {
+			results = append(results, failf(GateChanges, c.ID, "active change has unknown current stage %q", c.CurrentStage))
+			continue
+		}
+		if updated, err := time.Parse(time.RFC3339, c.UpdatedAt); err == nil && opts.Now().Sub(updated) < opts.StaleAfter {
+			results = append(results, pass(GateChanges, c.ID, fmt.Sprintf("active at %s, updated %s", c.CurrentStage, c.UpdatedAt)))
+			continue
+		}
+		results = append(results, failf(GateChanges, c.ID,
+			"active change is stuck at stage %s (%d/%d), last updated %s — advance it with sdd_change_advance until it completes",
+			c.CurrentStage, idx+1, len(c.Stages), orDash(c.UpdatedAt)))
+	}
+
+	if len(results) == 0 {
+		return []Result{pass(GateChanges, "changes", "no active changes stuck mid-flow")}, nil
+	}
+	return results, nil
+}
+
+func readOptional(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
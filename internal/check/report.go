@@ -0,0 +1,183 @@
+package check
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Format selects how a report is written.
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+)
+
+// ParseFormat validates an output format name.
+func ParseFormat(v string) (Format, error) {
+	switch f := Format(v); f {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON, FormatJUnit:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown format %q: expected text, json, or junit", v)
+	}
+}
+
+// Write renders the report in the given format.
+func (r *Report) Write(w io.Writer, f Format) error {
+	switch f {
+	case FormatJSON:
+		return r.writeJSON(w)
+	case FormatJUnit:
+		return r.writeJUnit(w)
+	default:
+		return r.writeText(w)
+	}
+}
+
+func (r *Report) writeText(w io.Writer) error {
+	for _, gate := range Gates {
+		results := r.ByGate(gate)
+		if len(results) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", gate); err != nil {
+			return err
+		}
+		for _, res := range results {
+			// Passing items are summarized; only problems are listed.
+			if res.Status == StatusPass && len(results) > 1 {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "  %s %s: %s\n", statusIcon(res.Status), res.Name, res.Message); err != nil {
+				return err
+			}
+		}
+		if n := countStatus(results, StatusPass); n > 1 {
+			if _, err := fmt.Fprintf(w, "  ✅ %d passed\n", n); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n",
+		r.Count(StatusPass), r.Count(StatusFail), r.Count(StatusSkip))
+	return err
+}
+
+func (r *Report) writeJSON(w io.Writer) error {
+	out := struct {
+		*Report
+		Passed  bool `json:"passed"`
+		Summary struct {
+			Pass int `json:"pass"`
+			Fail int `json:"fail"`
+			Skip int `json:"skip"`
+		} `json:"summary"`
+	}{Report: r, Passed: !r.Failed()}
+	out.Summary.Pass = r.Count(StatusPass)
+	out.Summary.Fail = r.Count(StatusFail)
+	out.Summary.Skip = r.Count(StatusSkip)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// JUnit XML: one <testsuite> per gate, one <testcase> per result, so
+// CI systems show each uncovered requirement as its own failing test.
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	doc := junitSuites{
+		Name:     "hoofy check",
+		Tests:    len(r.Results),
+		Failures: r.Count(StatusFail),
+		Skipped:  r.Count(StatusSkip),
+	}
+	for _, gate := range Gates {
+		results := r.ByGate(gate)
+		if len(results) == 0 {
+			continue
+		}
+		suite := junitSuite{
+			Name:     gate,
+			Tests:    len(results),
+			Failures: countStatus(results, StatusFail),
+			Skipped:  countStatus(results, StatusSkip),
+		}
+		for _, res := range results {
+			tc := junitCase{Name: res.Name, ClassName: "hoofy." + gate}
+			switch res.Status {
+			case StatusFail:
+				tc.Failure = &junitMessage{Message: res.Message}
+			case StatusSkip:
+				tc.Skipped = &junitMessage{Message: res.Message}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func countStatus(results []Result, s Status) int {
+	n := 0
+	for _, res := range results {
+		if res.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+func statusIcon(s Status) string {
+	switch s {
+	case StatusPass:
+		return "✅"
+	case StatusFail:
+		return "❌"
+	default:
+		return "➖"
+	}
+}
@@ -137,11 +137,32 @@ func NewProjectConfig(name, description string, mode Mode) *ProjectConfig {
 
 // --- Path helpers ---
 
+// defaultDocsDir is where NEW projects put their artifacts. Existing
+// projects are always detected from disk (see ResolveDocsDir).
+var defaultDocsDir = DocsDir
+
+// SetDefaultDocsDir changes where new projects are initialized.
+// Only "docs" and "docs/specs" are accepted — those are the two
+// locations ResolveDocsDir (and project-root discovery) know about.
+// Intended to be called once at startup from user configuration.
+func SetDefaultDocsDir(dir string) error {
+	switch filepath.ToSlash(filepath.Clean(dir)) {
+	case DocsDir:
+		defaultDocsDir = DocsDir
+	case DocsDir + "/" + DocsDirFallback:
+		defaultDocsDir = filepath.Join(DocsDir, DocsDirFallback)
+	default:
+		return fmt.Errorf("invalid docs dir %q: must be %q or %q", dir, DocsDir, DocsDir+"/"+DocsDirFallback)
+	}
+	return nil
+}
+
 // ResolveDocsDir determines the docs directory relative to projectRoot.
 // Resolution algorithm:
 //  1. If docs/hoofy.json exists → "docs"
 //  2. If docs/specs/hoofy.json exists → "docs/specs"
-//  3. Neither exists → default to "docs" (for new projects)
+//  3. Neither exists → the default for new projects ("docs" unless
+//     changed via SetDefaultDocsDir)
 func ResolveDocsDir(projectRoot string) string {
 	primary := filepath.Join(projectRoot, DocsDir, ConfigFile)
 	if _, err := os.Stat(primary); err == nil {
@@ -153,7 +174,27 @@ func ResolveDocsDir(projectRoot string) string {
 		return filepath.Join(DocsDir, DocsDirFallback)
 	}
 
-	return DocsDir
+	return defaultDocsDir
+}
+
+// FindProjectRoot walks up from start looking for docs/hoofy.json (or
+// the docs/specs/hoofy.json fallback). It returns the directory that
+// contains the project and true, or start and false if none is found.
+func FindProjectRoot(start string) (string, bool) {
+	current := start
+	for {
+		if _, err := os.Stat(filepath.Join(current, DocsDir, ConfigFile)); err == nil {
+			return current, true
+		}
+		if _, err := os.Stat(filepath.Join(current, DocsDir, DocsDirFallback, ConfigFile)); err == nil {
+			return current, true
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return start, false
+		}
+		current = parent
+	}
 }
 
 // DocsPath returns the absolute path to the resolved docs directory.
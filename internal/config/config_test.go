@@ -116,6 +116,59 @@ func TestResolveDocsDir_DefaultDocs(t *testing.T) {
 	}
 }
 
+func TestSetDefaultDocsDir(t *testing.T) {
+	t.Cleanup(func() { _ = SetDefaultDocsDir(DocsDir) })
+
+	if err := SetDefaultDocsDir("docs/specs"); err != nil {
+		t.Fatalf("SetDefaultDocsDir: %v", err)
+	}
+	want := filepath.Join(DocsDir, DocsDirFallback)
+	if got := ResolveDocsDir(t.TempDir()); got != want {
+		t.Errorf("ResolveDocsDir = %s, want %s", got, want)
+	}
+
+	// Existing projects still win over the default.
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, DocsDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, DocsDir, ConfigFile), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := ResolveDocsDir(tmpDir); got != DocsDir {
+		t.Errorf("ResolveDocsDir with existing project = %s, want %s", got, DocsDir)
+	}
+
+	if err := SetDefaultDocsDir("artifacts"); err == nil {
+		t.Error("expected error for unsupported docs dir")
+	}
+}
+
+func TestFindProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, DocsDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, DocsDir, ConfigFile), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "internal", "pkg")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := FindProjectRoot(sub)
+	if !ok || got != root {
+		t.Errorf("FindProjectRoot(sub) = %q, %v; want %q, true", got, ok, root)
+	}
+
+	empty := t.TempDir()
+	got, ok = FindProjectRoot(empty)
+	if ok || got != empty {
+		t.Errorf("FindProjectRoot(empty) = %q, %v; want %q, false", got, ok, empty)
+	}
+}
+
 func TestResolveDocsDir_PrimaryPath(t *testing.T) {
 	tmpDir := t.TempDir()
 	// Create docs/hoofy.json
@@ -0,0 +1,100 @@
+// Package doctor diagnoses a Hoofy installation and project.
+//
+// Problems in Hoofy tend to fail quietly: if the memory database can't be
+// opened the server just drops the mem_* tools, and a hand-edited
+// hoofy.json or change.json surfaces only when a tool call trips over it.
+// Each check here returns Findings — what was inspected, how bad it is,
+// and what the user should do about it — so `hoofy doctor` can print one
+// actionable report.
+//
+// Checks never modify anything unless explicitly asked to fix
+// (see Options.Fix).
+package doctor
+
+// Severity ranks a finding.
+type Severity string
+
+const (
+	SeverityOK   Severity = "ok"
+	SeveritySkip Severity = "skip" // check not applicable here
+	SeverityWarn Severity = "warn"
+	SeverityFail Severity = "fail"
+)
+
+// Finding is the result of a single check.
+type Finding struct {
+	// Section groups findings in the report ("config", "memory", "project", "changes", "templates").
+	Section  string   `json:"section"`
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Fix is an actionable suggestion; empty for OK findings.
+	Fix string `json:"fix,omitempty"`
+}
+
+// Report collects findings from every check.
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Add appends findings to the report.
+func (r *Report) Add(findings ...Finding) {
+	r.Findings = append(r.Findings, findings...)
+}
+
+// Count returns how many findings have the given severity.
+func (r *Report) Count(sev Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == sev {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed reports whether any check failed.
+func (r *Report) Failed() bool {
+	return r.Count(SeverityFail) > 0
+}
+
+// Options controls which checks run and whether safe repairs are applied.
+type Options struct {
+	// ProjectRoot is the directory containing docs/hoofy.json.
+	// Empty skips the project, change, and template checks.
+	ProjectRoot string
+	// Fix applies safe, non-destructive repairs (currently: rebuilding
+	// out-of-sync full-text indexes).
+	Fix bool
+}
+
+// Run executes every check and returns the combined report.
+// configPath is passed to userconfig.Load (empty means the default).
+func Run(configPath string, opts Options) *Report {
+	r := &Report{}
+
+	cfg, findings := CheckConfig(configPath)
+	r.Add(findings...)
+	r.Add(CheckMemory(cfg, opts.Fix)...)
+	r.Add(CheckProject(opts.ProjectRoot)...)
+	r.Add(CheckChanges(opts.ProjectRoot)...)
+	r.Add(CheckTemplates(opts.ProjectRoot)...)
+
+	return r
+}
+
+func ok(section, check, msg string) Finding {
+	return Finding{Section: section, Check: check, Severity: SeverityOK, Message: msg}
+}
+
+func skip(section, check, msg string) Finding {
+	return Finding{Section: section, Check: check, Severity: SeveritySkip, Message: msg}
+}
+
+func warn(section, check, msg, fix string) Finding {
+	return Finding{Section: section, Check: check, Severity: SeverityWarn, Message: msg, Fix: fix}
+}
+
+func fail(section, check, msg, fix string) Finding {
+	return Finding{Section: section, Check: check, Severity: SeverityFail, Message: msg, Fix: fix}
+}
@@ -0,0 +1,419 @@
+package doctor
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/memory"
+	"github.com/HendryAvila/Hoofy/internal/templates"
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
+)
+
+// --- helpers ---
+
+func newProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	cfg := config.NewProjectConfig("demo", "demo project", config.ModeGuided)
+	if err := config.NewFileStore().Save(root, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func writeFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newChange(t *testing.T, root, id string, typ changes.ChangeType, size changes.ChangeSize, status changes.ChangeStatus) {
+	t.Helper()
+	flow, err := changes.StageFlow(typ, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &changes.ChangeRecord{ID: id, Type: typ, Size: size, Status: status, CurrentStage: flow[0]}
+	for _, s := range flow {
+		rec.Stages = append(rec.Stages, changes.StageEntry{Name: s, Status: "pending"})
+	}
+	if err := changes.NewFileStore().Create(root, rec); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func worst(findings []Finding) Severity {
+	rank := map[Severity]int{SeveritySkip: 0, SeverityOK: 1, SeverityWarn: 2, SeverityFail: 3}
+	w := SeveritySkip
+	for _, f := range findings {
+		if rank[f.Severity] > rank[w] {
+			w = f.Severity
+		}
+	}
+	return w
+}
+
+func hasMessage(findings []Finding, substr string) bool {
+	for _, f := range findings {
+		if strings.Contains(f.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// --- CheckProject ---
+
+func TestCheckProject_Valid(t *testing.T) {
+	findings := CheckProject(newProject(t))
+	if worst(findings) != SeverityOK {
+		t.Fatalf("expected OK, got %+v", findings)
+	}
+}
+
+func TestCheckProject_NoProjectSkips(t *testing.T) {
+	findings := CheckProject(t.TempDir())
+	if len(findings) != 1 || findings[0].Severity != SeveritySkip {
+		t.Fatalf("expected single skip, got %+v", findings)
+	}
+}
+
+func TestCheckProject_InvalidJSON(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, config.ConfigPath(root), `{"name": `)
+
+	findings := CheckProject(root)
+	if worst(findings) != SeverityFail || findings[0].Fix == "" {
+		t.Fatalf("expected failure with fix, got %+v", findings)
+	}
+}
+
+func TestCheckProject_UnknownStages(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, config.ConfigPath(root), `{
+		"name": "demo", "mode": "guided", "current_stage": "deploy",
+		"stage_status": {"init": {"status": "completed"}, "deploy": {"status": "done"}}
+	}`)
+
+	findings := CheckProject(root)
+	if worst(findings) != SeverityFail {
+		t.Fatalf("expected failure, got %+v", findings)
+	}
+	for _, want := range []string{`current_stage "deploy"`, "unknown stages: deploy", "missing stages:"} {
+		if !hasMessage(findings, want) {
+			t.Errorf("missing finding %q in %+v", want, findings)
+		}
+	}
+}
+
+// --- CheckChanges ---
+
+func TestCheckChanges_Valid(t *testing.T) {
+	root := newProject(t)
+	newChange(t, root, "add-login", changes.TypeFeature, changes.SizeMedium, changes.StatusActive)
+	newChange(t, root, "old-fix", changes.TypeFix, changes.SizeSmall, changes.StatusCompleted)
+
+	findings := CheckChanges(root)
+	if worst(findings) != SeverityOK || !hasMessage(findings, "2 change(s) valid") {
+		t.Fatalf("expected 2 valid changes, got %+v", findings)
+	}
+}
+
+func TestCheckChanges_FlowMismatchAndBadJSON(t *testing.T) {
+	root := newProject(t)
+	newChange(t, root, "resize-me", changes.TypeFix, changes.SizeSmall, changes.StatusActive)
+	// Claim "large" without the extra stages.
+	path := changes.ChangeConfigPath(root, "resize-me")
+	data, _ := os.ReadFile(path)
+	writeFile(t, path, strings.Replace(string(data), `"size": "small"`, `"size": "large"`, 1))
+	writeFile(t, changes.ChangeConfigPath(root, "broken"), `{nope`)
+
+	findings := CheckChanges(root)
+	if !hasMessage(findings, "do not match the fix/large flow") {
+		t.Errorf("expected flow mismatch, got %+v", findings)
+	}
+	if !hasMessage(findings, "invalid JSON") {
+		t.Errorf("expected invalid JSON finding, got %+v", findings)
+	}
+	for _, f := range findings {
+		if f.Severity == SeverityFail && f.Fix == "" {
+			t.Errorf("failure without fix: %+v", f)
+		}
+	}
+}
+
+func TestCheckChanges_ProjectDefinedFlows(t *testing.T) {
+	root := newProject(t)
+	writeFile(t, changes.FlowsPath(root), `{"types": {"hotfix": {"extends": "fix",
+  "flows": {"small": ["describe", "context-check", "verify"]}}}}`)
+
+	reg, err := changes.LoadRegistry(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes.SetRegistry(reg)
+	t.Cleanup(func() { changes.SetRegistry(nil) })
+	newChange(t, root, "prod-outage", "hotfix", changes.SizeSmall, changes.StatusActive)
+	changes.SetRegistry(nil)
+
+	// Doctor reads the flows file itself rather than relying on whatever
+	// registry the process has installed.
+	findings := CheckChanges(root)
+	if worst(findings) != SeverityOK {
+		t.Fatalf("hotfix change should validate against docs/hoofy-flows.json, got %+v", findings)
+	}
+
+	writeFile(t, changes.FlowsPath(root), `{"types": {"hotfix": {"extends": "fix", "flows": {"small": ["describe", "verify"]}}}}`)
+	findings = CheckChanges(root)
+	if !hasMessage(findings, "must include context-check") {
+		t.Errorf("expected flows file failure, got %+v", findings)
+	}
+}
+
+func TestCheckChanges_MultipleActive(t *testing.T) {
+	root := newProject(t)
+	newChange(t, root, "one", changes.TypeFix, changes.SizeSmall, changes.StatusActive)
+	newChange(t, root, "two", changes.TypeRefactor, changes.SizeSmall, changes.StatusActive)
+
+	if findings := CheckChanges(root); !hasMessage(findings, "2 changes are active") {
+		t.Fatalf("expected multiple-active failure, got %+v", findings)
+	}
+}
+
+func TestCheckChanges_ActiveOnDifferentBranches(t *testing.T) {
+	root := newProject(t)
+	newChange(t, root, "one", changes.TypeFix, changes.SizeSmall, changes.StatusActive)
+	newChange(t, root, "two", changes.TypeFix, changes.SizeSmall, changes.StatusActive)
+	newChange(t, root, "three", changes.TypeFix, changes.SizeSmall, changes.StatusPaused)
+	store := changes.NewFileStore()
+	for id, branch := range map[string]string{"one": "main", "two": "feature/x", "three": "main"} {
+		rec, err := store.Load(root, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec.Branch = branch
+		if err := store.Save(root, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if findings := CheckChanges(root); worst(findings) != SeverityOK {
+		t.Fatalf("one active change per branch should pass, got %+v", findings)
+	}
+}
+
+func TestCheckChanges_BrokenLinks(t *testing.T) {
+	root := newProject(t)
+	newChange(t, root, "api", changes.TypeFeature, changes.SizeSmall, changes.StatusActive)
+	newChange(t, root, "schema", changes.TypeFeature, changes.SizeSmall, changes.StatusPaused)
+	store := changes.NewFileStore()
+	for id, deps := range map[string][]string{"api": {"schema"}, "schema": {"api", "gone"}} {
+		rec, err := store.Load(root, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec.DependsOn = deps
+		if err := store.Save(root, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings := CheckChanges(root)
+	if !hasMessage(findings, "schema depends on gone, which does not exist") {
+		t.Errorf("expected dangling link warning, got %+v", findings)
+	}
+	cycles := 0
+	for _, f := range findings {
+		if strings.Contains(f.Message, "dependency cycle") {
+			cycles++
+		}
+	}
+	if cycles != 1 {
+		t.Errorf("expected the cycle reported once, got %+v", findings)
+	}
+}
+
+func TestCheckChanges_WithoutHoofyJSON(t *testing.T) {
+	root := t.TempDir()
+	newChange(t, root, "standalone", changes.TypeFix, changes.SizeSmall, changes.StatusActive)
+
+	if findings := CheckChanges(root); worst(findings) != SeverityOK {
+		t.Fatalf("expected OK, got %+v", findings)
+	}
+}
+
+// --- CheckTemplates ---
+
+func TestCheckTemplates(t *testing.T) {
+	root := newProject(t)
+	if findings := CheckTemplates(root); worst(findings) != SeveritySkip {
+		t.Fatalf("no overrides should skip, got %+v", findings)
+	}
+
+	dir := templates.OverridesPath(root)
+	writeFile(t, filepath.Join(dir, templates.Requirements), "# {{ .Name }}\n\n{{ .MustHave }}\n")
+	if findings := CheckTemplates(root); worst(findings) != SeverityOK || !hasMessage(findings, "1 override(s) valid: requirements.md.tmpl") {
+		t.Fatalf("expected OK, got %+v", findings)
+	}
+
+	writeFile(t, filepath.Join(dir, templates.Charter), "# {{ .ProjectName }}\n")
+	findings := CheckTemplates(root)
+	if worst(findings) != SeverityFail || !hasMessage(findings, "charter.md.tmpl does not render") {
+		t.Fatalf("expected charter failure, got %+v", findings)
+	}
+}
+
+// --- CheckMemory / CheckConfig ---
+
+func testConfig(t *testing.T) userconfig.Config {
+	t.Helper()
+	cfg := userconfig.Default()
+	cfg.Memory.DataDir = t.TempDir()
+	return cfg
+}
+
+func TestCheckMemory_MissingDataDirIsNotCreated(t *testing.T) {
+	cfg := userconfig.Default()
+	cfg.Memory.DataDir = filepath.Join(t.TempDir(), "not", "yet")
+
+	if findings := CheckMemory(cfg, false); worst(findings) != SeverityWarn {
+		t.Fatalf("expected warning for a creatable data dir, got %+v", findings)
+	}
+	if _, err := os.Stat(cfg.Memory.DataDir); !os.IsNotExist(err) {
+		t.Errorf("doctor created %s (stat err = %v)", cfg.Memory.DataDir, err)
+	}
+
+	// Under a regular file the directory can never be created.
+	file := filepath.Join(t.TempDir(), "file")
+	writeFile(t, file, "x")
+	cfg.Memory.DataDir = filepath.Join(file, "memory")
+	if findings := CheckMemory(cfg, false); worst(findings) != SeverityFail || !hasMessage(findings, "not a directory") {
+		t.Fatalf("expected failure under a file, got %+v", findings)
+	}
+}
+
+func TestCheckMemory_MissingDatabaseWarns(t *testing.T) {
+	cfg := testConfig(t)
+	findings := CheckMemory(cfg, false)
+	if worst(findings) != SeverityWarn {
+		t.Fatalf("expected warning, got %+v", findings)
+	}
+	if _, err := os.Stat(memory.DBPath(cfg.MemoryStoreConfig())); !os.IsNotExist(err) {
+		t.Error("doctor must not create the database")
+	}
+}
+
+func TestCheckMemory_Disabled(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Memory.Disabled = true
+	if findings := CheckMemory(cfg, false); worst(findings) != SeveritySkip {
+		t.Fatalf("expected skip, got %+v", findings)
+	}
+}
+
+func TestCheckMemory_DriftedFTSAndFix(t *testing.T) {
+	cfg := testConfig(t)
+	store, err := memory.New(cfg.MemoryStoreConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateSession("s1", "p", "/tmp"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddPrompt(memory.AddPromptParams{SessionID: "s1", Content: "hello", Project: "p"}); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	if findings := CheckMemory(cfg, false); worst(findings) != SeverityOK {
+		t.Fatalf("healthy db: expected OK, got %+v", findings)
+	}
+
+	// Wipe the index behind the content table's back.
+	db, err := sql.Open("sqlite", memory.DBPath(cfg.MemoryStoreConfig()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO prompts_fts(prompts_fts) VALUES('delete-all')"); err != nil {
+		t.Fatal(err)
+	}
+	_ = db.Close()
+
+	findings := CheckMemory(cfg, false)
+	if !hasMessage(findings, "out of sync with user_prompts") {
+		t.Fatalf("expected drift, got %+v", findings)
+	}
+
+	if findings := CheckMemory(cfg, true); !hasMessage(findings, "rebuilt full-text indexes") {
+		t.Fatalf("expected rebuild, got %+v", findings)
+	}
+	if findings := CheckMemory(cfg, false); worst(findings) != SeverityOK {
+		t.Fatalf("after fix: expected OK, got %+v", findings)
+	}
+}
+
+func TestCheckMemory_DoesNotModifyDatabase(t *testing.T) {
+	cfg := testConfig(t)
+	store, err := memory.New(cfg.MemoryStoreConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	dbPath := memory.DBPath(cfg.MemoryStoreConfig())
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	if _, err := db.Exec("PRAGMA journal_mode = DELETE"); err != nil {
+		t.Fatal(err)
+	}
+
+	findings := CheckMemory(cfg, false)
+	if !hasMessage(findings, `journal mode is "delete"`) {
+		t.Fatalf("expected journal mode warning, got %+v", findings)
+	}
+	// Opening a Store would have switched the database to WAL.
+	var mode string
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatal(err)
+	}
+	if mode != "delete" {
+		t.Errorf("journal mode = %q after doctor, want delete", mode)
+	}
+}
+
+func TestCheckConfig_InvalidFallsBackToDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeFile(t, path, `{"memory": {"max_search_results": -1}}`)
+
+	cfg, findings := CheckConfig(path)
+	if worst(findings) != SeverityFail {
+		t.Fatalf("expected failure, got %+v", findings)
+	}
+	if cfg.Memory.MaxSearchResults != userconfig.Default().Memory.MaxSearchResults {
+		t.Error("expected defaults after invalid config")
+	}
+}
+
+func TestReport_Failed(t *testing.T) {
+	r := &Report{}
+	r.Add(ok("memory", "db", "fine"), warn("project", "x", "meh", "do y"))
+	if r.Failed() {
+		t.Error("warnings alone should not fail")
+	}
+	r.Add(fail("changes", "z", "bad", "fix z"))
+	if !r.Failed() || r.Count(SeverityFail) != 1 {
+		t.Error("expected one failure")
+	}
+}
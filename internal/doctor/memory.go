@@ -0,0 +1,175 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/memory"
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
+)
+
+// largeWAL is the -wal size above which we suggest a checkpoint. SQLite
+// checkpoints automatically, so a WAL this big usually means a reader
+// (often a stale hoofy process) is holding it open.
+const largeWAL = 64 << 20
+
+// CheckConfig loads the user configuration. When it is invalid the
+// failure is reported and built-in defaults are returned so the other
+// checks can still run.
+func CheckConfig(path string) (userconfig.Config, []Finding) {
+	shown := path
+	if shown == "" {
+		shown = userconfig.DefaultPath()
+	}
+
+	cfg, err := userconfig.Load(path)
+	if err != nil {
+		return userconfig.Default(), []Finding{fail("config", "user config",
+			err.Error(),
+			fmt.Sprintf("Edit %s (or the HOOFY_* environment variables) and fix the values listed above. Hoofy refuses to start until they are valid.", shown))}
+	}
+
+	if _, statErr := os.Stat(shown); statErr != nil {
+		return cfg, []Finding{ok("config", "user config", fmt.Sprintf("no file at %s — using defaults", shown))}
+	}
+	return cfg, []Finding{ok("config", "user config", "loaded "+shown)}
+}
+
+// CheckMemory verifies the memory database: that it opens, runs in WAL
+// mode, passes PRAGMA integrity_check, and that its FTS5 indexes match
+// their content tables. The checks change nothing on disk; with fix,
+// drifted indexes are rebuilt.
+func CheckMemory(cfg userconfig.Config, fix bool) []Finding {
+	const section = "memory"
+
+	if cfg.Memory.Disabled {
+		return []Finding{skip(section, "database", "memory is disabled in user configuration (memory.disabled)")}
+	}
+
+	mc := cfg.MemoryStoreConfig()
+	dbPath := memory.DBPath(mc)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		if err := checkWritable(mc.DataDir); err != nil {
+			return []Finding{fail(section, "database",
+				fmt.Sprintf("%s does not exist and %s is not writable: %v", dbPath, mc.DataDir, err),
+				fmt.Sprintf("Fix permissions on %s or set memory.data_dir to a writable directory.", mc.DataDir))}
+		}
+		return []Finding{warn(section, "database",
+			dbPath+" does not exist yet",
+			"It is created the first time `hoofy serve` starts — nothing to do unless you expected existing memories here (check memory.data_dir / HOOFY_DATA_DIR).")}
+	}
+
+	// Inspect read-only: opening a Store would set WAL mode and migrate
+	// a database that a running server may be using.
+	h, err := memory.Inspect(dbPath)
+	if err != nil {
+		return []Finding{fail(section, "database",
+			fmt.Sprintf("cannot inspect %s: %v", dbPath, err),
+			fmt.Sprintf("While this fails, `hoofy serve` may run without any mem_* tools. Check file permissions and that no other program locks the file. If it is corrupt, move it aside (mv %s %s.bak) and restore from a `hoofy memory export` backup.", dbPath, dbPath))}
+	}
+
+	findings := []Finding{ok(section, "database", "opened "+dbPath)}
+
+	switch {
+	case h.JournalMode != "wal":
+		findings = append(findings, warn(section, "journal mode",
+			fmt.Sprintf("journal mode is %q, expected \"wal\"", h.JournalMode),
+			"SQLite could not enable WAL — this usually means memory.data_dir is on a network or synced filesystem. Move it to a local disk."))
+	case h.WALBytes > largeWAL:
+		findings = append(findings, warn(section, "journal mode",
+			fmt.Sprintf("WAL file is %s", formatBytes(h.WALBytes)),
+			"A large WAL means it can't be checkpointed. Stop stale `hoofy serve` processes; the next clean open will shrink it."))
+	default:
+		findings = append(findings, ok(section, "journal mode", fmt.Sprintf("wal (%s pending)", formatBytes(h.WALBytes))))
+	}
+
+	if h.IntegrityOK() {
+		findings = append(findings, ok(section, "integrity", "PRAGMA integrity_check: ok"))
+	} else {
+		findings = append(findings, fail(section, "integrity",
+			"PRAGMA integrity_check: "+summarize(h.Integrity, 3),
+			fmt.Sprintf("Export what is readable (`hoofy memory export --output backup.json`), move %s aside, then `hoofy memory import backup.json`.", dbPath)))
+	}
+
+	drifted := false
+	for _, f := range h.FTS {
+		check := f.Table
+		if f.InSync {
+			findings = append(findings, ok(section, check, fmt.Sprintf("in sync with %s (%d rows)", f.ContentTable, f.Rows)))
+			continue
+		}
+		drifted = true
+		if !fix {
+			findings = append(findings, fail(section, check,
+				fmt.Sprintf("out of sync with %s (%d rows) — searches may miss memories", f.ContentTable, f.Rows),
+				"Run `hoofy doctor --fix` to rebuild the search index (no data is lost)."))
+		}
+	}
+
+	if drifted && fix {
+		findings = append(findings, rebuildFTS(mc))
+	}
+
+	return findings
+}
+
+// rebuildFTS opens the store for writing — only --fix does — and
+// rebuilds its full-text indexes.
+func rebuildFTS(mc memory.Config) Finding {
+	const hint = "Rebuild failed — back up with `hoofy memory export` and re-import into a fresh database."
+	store, err := memory.New(mc)
+	if err != nil {
+		return fail("memory", "fts rebuild", err.Error(), hint)
+	}
+	defer func() { _ = store.Close() }()
+	if err := store.RebuildFTS(); err != nil {
+		return fail("memory", "fts rebuild", err.Error(), hint)
+	}
+	return ok("memory", "fts rebuild", "rebuilt full-text indexes")
+}
+
+// checkWritable reports whether dir, or the nearest existing directory
+// it would be created under, looks writable. It only inspects
+// permission bits — doctor creates nothing unless asked to fix — so
+// ACLs and read-only mounts can still make the first write fail.
+func checkWritable(dir string) error {
+	current := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(current)
+		switch {
+		case err == nil && !info.IsDir():
+			return fmt.Errorf("%s is not a directory", current)
+		case err == nil && info.Mode().Perm()&0o222 == 0:
+			return fmt.Errorf("%s is read-only", current)
+		case err == nil:
+			return nil
+		case !os.IsNotExist(err):
+			return err
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return fmt.Errorf("no existing parent directory for %s", dir)
+		}
+		current = parent
+	}
+}
+
+func summarize(lines []string, max int) string {
+	if len(lines) <= max {
+		return strings.Join(lines, "; ")
+	}
+	return fmt.Sprintf("%s; … (%d more)", strings.Join(lines[:max], "; "), len(lines)-max)
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
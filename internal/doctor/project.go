@@ -0,0 +1,315 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/pipeline"
+	"github.com/HendryAvila/Hoofy/internal/templates"
+)
+
+// validStageStatuses are the values StageStatus.Status / StageEntry.Status may hold.
+var validStageStatuses = []string{"pending", "in_progress", "completed", "skipped", "stale"}
+
+// CheckProject validates docs/hoofy.json against the known pipeline stages.
+func CheckProject(projectRoot string) []Finding {
+	const section = "project"
+
+	if projectRoot == "" || !config.Exists(projectRoot) {
+		return []Finding{skip(section, "hoofy.json", "no Hoofy project found (run doctor from inside a project to check it)")}
+	}
+
+	path := config.ConfigPath(projectRoot)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []Finding{fail(section, "hoofy.json", err.Error(), "Check file permissions on "+path+".")}
+	}
+	var cfg config.ProjectConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return []Finding{fail(section, "hoofy.json",
+			fmt.Sprintf("%s is not valid JSON: %v", path, err),
+			"Fix the syntax by hand (git diff usually shows the bad edit) — every sdd_* tool fails until it parses.")}
+	}
+
+	var findings []Finding
+	add := func(f Finding) { findings = append(findings, f) }
+
+	if cfg.Mode != config.ModeGuided && cfg.Mode != config.ModeExpert {
+		add(fail(section, "mode",
+			fmt.Sprintf("mode is %q", cfg.Mode),
+			fmt.Sprintf(`Set "mode" to %q or %q in %s.`, config.ModeGuided, config.ModeExpert, path)))
+	}
+
+	if _, known := config.Stages[cfg.CurrentStage]; !known {
+		add(fail(section, "current_stage",
+			fmt.Sprintf("current_stage %q is not a pipeline stage", cfg.CurrentStage),
+			fmt.Sprintf(`Set "current_stage" to one of: %s.`, stageList())))
+	}
+
+	var unknown, badStatus, missing []string
+	for stage, st := range cfg.StageStatus {
+		if _, known := config.Stages[stage]; !known {
+			unknown = append(unknown, string(stage))
+			continue
+		}
+		if !slices.Contains(validStageStatuses, st.Status) {
+			badStatus = append(badStatus, fmt.Sprintf("%s=%q", stage, st.Status))
+		}
+	}
+	for _, stage := range config.StageOrder {
+		if _, present := cfg.StageStatus[stage]; !present {
+			missing = append(missing, string(stage))
+		}
+	}
+	slices.Sort(unknown)
+	slices.Sort(badStatus)
+
+	if len(unknown) > 0 {
+		add(fail(section, "stage_status",
+			"unknown stages: "+strings.Join(unknown, ", "),
+			fmt.Sprintf("Remove or rename them in %s. Known stages: %s.", path, stageList())))
+	}
+	if len(badStatus) > 0 {
+		add(fail(section, "stage_status",
+			"invalid statuses: "+strings.Join(badStatus, ", "),
+			"Each status must be one of: "+strings.Join(validStageStatuses, ", ")+"."))
+	}
+	if len(missing) > 0 {
+		add(warn(section, "stage_status",
+			"missing stages: "+strings.Join(missing, ", "),
+			`Add them to "stage_status" with {"status": "pending"} — tools treat missing stages as never started.`))
+	}
+
+	// Stages before the current one should not still be pending.
+	if idx := pipeline.StageIndex(cfg.CurrentStage); idx > 0 {
+		var behind []string
+		for _, stage := range config.StageOrder[:idx] {
+			if st, present := cfg.StageStatus[stage]; present && st.Status == "pending" {
+				behind = append(behind, string(stage))
+			}
+		}
+		if len(behind) > 0 {
+			add(warn(section, "progress",
+				fmt.Sprintf("current stage is %s but earlier stages are still pending: %s", cfg.CurrentStage, strings.Join(behind, ", ")),
+				"This usually means hoofy.json was edited by hand. Mark them completed/skipped or move current_stage back."))
+		}
+	}
+
+	if len(findings) == 0 {
+		add(ok(section, "hoofy.json", fmt.Sprintf("%s valid (stage: %s)", relTo(projectRoot, path), cfg.CurrentStage)))
+	}
+	return findings
+}
+
+// CheckChanges verifies that every change.json under docs/changes (and
+// docs/history) parses, has a known type/size, and follows its flow.
+// Types and flows declared in docs/hoofy-flows.json are checked too.
+func CheckChanges(projectRoot string) []Finding {
+	const section = "changes"
+
+	// Changes don't require hoofy.json — the change pipeline works in
+	// projects that never ran sdd_init_project.
+	if projectRoot == "" {
+		return []Finding{skip(section, "change.json", "no project directory")}
+	}
+
+	var findings []Finding
+	reg, err := changes.LoadRegistry(projectRoot)
+	if err != nil {
+		findings = append(findings, fail(section, relTo(projectRoot, changes.FlowsPath(projectRoot)), err.Error(),
+			"Fix the file — sdd_change* tools refuse to run until it is valid. Every flow needs context-check and must end with verify."))
+		reg = changes.DefaultRegistry()
+	}
+	// Active changes grouped by git branch ("" = unbound).
+	active := map[string][]string{}
+	checked := 0
+	var records []changes.ChangeRecord
+
+	for _, dir := range []string{changes.ChangesPath(projectRoot), changes.HistoryPath(projectRoot)} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				findings = append(findings, fail(section, relTo(projectRoot, dir), err.Error(), "Check directory permissions."))
+			}
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name(), changes.ChangeConfigFile)
+			checked++
+			rec, problems := checkChangeFile(path, entry.Name(), reg)
+			for _, p := range problems {
+				p.Section = section
+				p.Check = relTo(projectRoot, path)
+				findings = append(findings, p)
+			}
+			if rec != nil {
+				records = append(records, *rec)
+				if rec.Status == changes.StatusActive {
+					active[rec.Branch] = append(active[rec.Branch], rec.ID)
+				}
+			}
+		}
+	}
+
+	branches := make([]string, 0, len(active))
+	for branch := range active {
+		branches = append(branches, branch)
+	}
+	slices.Sort(branches)
+	for _, branch := range branches {
+		ids := active[branch]
+		if len(ids) < 2 {
+			continue
+		}
+		where := "without a branch"
+		if branch != "" {
+			where = fmt.Sprintf("on branch %q", branch)
+		}
+		findings = append(findings, fail(section, "active changes",
+			fmt.Sprintf("%d changes are active %s: %s", len(ids), where, strings.Join(ids, ", ")),
+			"Only one change may be active per branch; tools pick whichever they find first. Pause or abandon the others with sdd_change_manage."))
+	}
+
+	// depends_on / blocks links must resolve and stay acyclic. A cycle
+	// shows up on every change in it; report it once.
+	reported := map[string]bool{}
+	for i := range records {
+		if err := changes.ValidateLinks(&records[i], records); err != nil && !reported[err.Error()] {
+			reported[err.Error()] = true
+			findings = append(findings, warn(section, "change links", fmt.Sprintf("%s: %s", records[i].ID, err),
+				`Fix "depends_on" / "blocks" in change.json — sdd_change_status can't order the change queue.`))
+		}
+	}
+
+	if len(findings) == 0 {
+		if checked == 0 {
+			return []Finding{skip(section, "change.json", "no changes yet")}
+		}
+		return []Finding{ok(section, "change.json", fmt.Sprintf("%d change(s) valid", checked))}
+	}
+	return findings
+}
+
+// checkChangeFile validates a single change.json. It returns the parsed
+// record (nil if unreadable) and any problems found. Flows come from reg,
+// so project-defined types are recognized.
+func checkChangeFile(path, dirName string, reg *changes.Registry) (*changes.ChangeRecord, []Finding) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, []Finding{warn("", "", "directory has no change.json",
+				"Delete the stray directory or restore change.json from git.")}
+		}
+		return nil, []Finding{fail("", "", err.Error(), "Check file permissions.")}
+	}
+
+	var rec changes.ChangeRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, []Finding{fail("", "", "invalid JSON: "+err.Error(),
+			"Fix the syntax by hand — the change is invisible to sdd_change* tools until it parses.")}
+	}
+
+	var problems []Finding
+	if rec.ID != dirName {
+		problems = append(problems, warn("", "",
+			fmt.Sprintf("id %q does not match directory name %q", rec.ID, dirName),
+			"Rename the directory or fix the id — tools load changes by directory name."))
+	}
+
+	if err := changes.ValidateStatus(rec.Status); err != nil {
+		problems = append(problems, fail("", "",
+			fmt.Sprintf("status %q is not valid", rec.Status),
+			`Set "status" to active, paused, completed, archived, or abandoned.`))
+	}
+
+	flow, err := reg.StageFlow(rec.Type, rec.Size)
+	if err != nil {
+		return &rec, append(problems, fail("", "", err.Error(),
+			`Fix "type"/"size" so the change maps to a known flow.`))
+	}
+
+	names := make([]changes.ChangeStage, len(rec.Stages))
+	for i, s := range rec.Stages {
+		names[i] = s.Name
+		if !slices.Contains(validStageStatuses, s.Status) {
+			problems = append(problems, fail("", "",
+				fmt.Sprintf("stage %s has invalid status %q", s.Name, s.Status),
+				"Each status must be one of: "+strings.Join(validStageStatuses, ", ")+"."))
+		}
+	}
+	if !changes.MatchesFlow(&rec, flow) {
+		problems = append(problems, fail("", "",
+			fmt.Sprintf("stages [%s] do not match the %s/%s flow [%s]", joinStages(names), rec.Type, rec.Size, joinStages(flow)),
+			"Restore the stage list from git, or create a new change with the intended type/size."))
+	} else if rec.Status == changes.StatusActive && !slices.Contains(flow, rec.CurrentStage) {
+		problems = append(problems, fail("", "",
+			fmt.Sprintf("current_stage %q is not in its flow", rec.CurrentStage),
+			fmt.Sprintf(`Set "current_stage" to one of: %s.`, joinStages(flow))))
+	}
+
+	return &rec, problems
+}
+
+func stageList() string {
+	names := make([]string, len(config.StageOrder))
+	for i, s := range config.StageOrder {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinStages(stages []changes.ChangeStage) string {
+	names := make([]string, len(stages))
+	for i, s := range stages {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// relTo shortens path for display; it falls back to path unchanged.
+func relTo(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// CheckTemplates verifies that the project's template overrides
+// (docs/.hoofy/templates) parse and render against their data. The
+// server falls back to the built-in templates when any of them is broken.
+func CheckTemplates(projectRoot string) []Finding {
+	const section = "templates"
+
+	if projectRoot == "" {
+		return []Finding{skip(section, "overrides", "no project directory")}
+	}
+	dir := templates.OverridesPath(projectRoot)
+	check := relTo(projectRoot, dir)
+	names, problems, err := templates.ValidateOverrides(dir)
+	if err != nil {
+		return []Finding{fail(section, check, err.Error(), "Check file permissions on "+dir+".")}
+	}
+
+	var findings []Finding
+	for _, p := range problems {
+		findings = append(findings, fail(section, check, p.Error(),
+			"Fix or delete the file — until then Hoofy renders every artifact with the built-in templates. "+
+				"'hoofy templates eject <name> --force' restores the default."))
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+	if len(names) == 0 {
+		return []Finding{skip(section, "overrides", "no template overrides (see 'hoofy templates eject')")}
+	}
+	return []Finding{ok(section, check, fmt.Sprintf("%d override(s) valid: %s", len(names), strings.Join(names, ", ")))}
+}
@@ -0,0 +1,417 @@
+// Package install registers "hoofy serve" in the MCP configuration of
+// supported AI clients (Claude Code, Cursor, VS Code, OpenCode, Gemini
+// CLI), replacing the hand-edited JSON snippets in the README.
+//
+// Config files are merged, never overwritten: other keys and other
+// servers keep their values and order, and re-running an install that
+// is already in place leaves the file byte-for-byte untouched.
+package install
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Scope selects which config file a client entry goes into.
+type Scope string
+
+const (
+	// ScopeUser is the machine-wide config in the user's home directory.
+	ScopeUser Scope = "user"
+	// ScopeProject is the config checked into the project directory.
+	ScopeProject Scope = "project"
+)
+
+// ParseScope validates a scope name. Empty means ScopeUser.
+func ParseScope(v string) (Scope, error) {
+	switch s := Scope(v); s {
+	case "":
+		return ScopeUser, nil
+	case ScopeUser, ScopeProject:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown scope %q: expected user or project", v)
+	}
+}
+
+// Env holds the directories config paths are resolved against, so
+// tests can point every client at a temporary directory.
+type Env struct {
+	Home string
+	// ConfigDir is os.UserConfigDir (VS Code keeps user settings here).
+	ConfigDir string
+	// XDGConfigHome is $XDG_CONFIG_HOME or ~/.config (OpenCode uses it on every OS).
+	XDGConfigHome string
+	// ProjectRoot is required for ScopeProject.
+	ProjectRoot string
+}
+
+// DefaultEnv resolves Env for the current user.
+func DefaultEnv(projectRoot string) (Env, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Env{}, fmt.Errorf("finding home directory: %w", err)
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = filepath.Join(home, ".config")
+	}
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if xdg == "" {
+		xdg = filepath.Join(home, ".config")
+	}
+	return Env{Home: home, ConfigDir: configDir, XDGConfigHome: xdg, ProjectRoot: projectRoot}, nil
+}
+
+// Server is the MCP server entry to register.
+type Server struct {
+	Name    string
+	Command string
+	Args    []string
+}
+
+// DefaultServer is the entry the README documents: "hoofy serve".
+func DefaultServer() Server {
+	return Server{Name: "hoofy", Command: "hoofy", Args: []string{"serve"}}
+}
+
+// Client describes where an AI client keeps its MCP servers and what
+// an entry looks like.
+type Client struct {
+	Name    string
+	Title   string
+	Aliases []string
+	// Key is the top-level object that holds server entries.
+	Key string
+	// Schema, when set, is written as "$schema" into newly created files.
+	Schema string
+
+	userPath    func(Env) string
+	projectPath func(Env) string
+	// detect lists paths whose existence means the client is installed.
+	detect func(Env) []string
+	entry  func(Server) any
+}
+
+// stdioEntry is the common {"command", "args"} shape; Type is only
+// written by clients that require it.
+type stdioEntry struct {
+	Type    string   `json:"type,omitempty"`
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+type openCodeEntry struct {
+	Type    string   `json:"type"`
+	Command []string `json:"command"`
+	Enabled bool     `json:"enabled"`
+}
+
+// Clients lists every supported client in display order.
+var Clients = []*Client{
+	{
+		Name:        "claude",
+		Title:       "Claude Code",
+		Aliases:     []string{"claude-code"},
+		Key:         "mcpServers",
+		userPath:    func(e Env) string { return filepath.Join(e.Home, ".claude.json") },
+		projectPath: func(e Env) string { return filepath.Join(e.ProjectRoot, ".mcp.json") },
+		detect: func(e Env) []string {
+			return []string{filepath.Join(e.Home, ".claude.json"), filepath.Join(e.Home, ".claude")}
+		},
+		entry: func(s Server) any { return stdioEntry{Type: "stdio", Command: s.Command, Args: s.Args} },
+	},
+	{
+		Name:        "cursor",
+		Title:       "Cursor",
+		Key:         "mcpServers",
+		userPath:    func(e Env) string { return filepath.Join(e.Home, ".cursor", "mcp.json") },
+		projectPath: func(e Env) string { return filepath.Join(e.ProjectRoot, ".cursor", "mcp.json") },
+		detect:      func(e Env) []string { return []string{filepath.Join(e.Home, ".cursor")} },
+		entry:       func(s Server) any { return stdioEntry{Command: s.Command, Args: s.Args} },
+	},
+	{
+		Name:        "vscode",
+		Title:       "VS Code",
+		Aliases:     []string{"code", "copilot"},
+		Key:         "servers",
+		userPath:    func(e Env) string { return filepath.Join(e.ConfigDir, "Code", "User", "mcp.json") },
+		projectPath: func(e Env) string { return filepath.Join(e.ProjectRoot, ".vscode", "mcp.json") },
+		detect:      func(e Env) []string { return []string{filepath.Join(e.ConfigDir, "Code", "User")} },
+		entry:       func(s Server) any { return stdioEntry{Type: "stdio", Command: s.Command, Args: s.Args} },
+	},
+	{
+		Name:        "opencode",
+		Title:       "OpenCode",
+		Key:         "mcp",
+		Schema:      "https://opencode.ai/config.json",
+		userPath:    func(e Env) string { return filepath.Join(e.XDGConfigHome, "opencode", "opencode.json") },
+		projectPath: func(e Env) string { return filepath.Join(e.ProjectRoot, "opencode.json") },
+		detect:      func(e Env) []string { return []string{filepath.Join(e.XDGConfigHome, "opencode")} },
+		entry: func(s Server) any {
+			return openCodeEntry{Type: "local", Command: append([]string{s.Command}, s.Args...), Enabled: true}
+		},
+	},
+	{
+		Name:        "gemini",
+		Title:       "Gemini CLI",
+		Aliases:     []string{"gemini-cli"},
+		Key:         "mcpServers",
+		userPath:    func(e Env) string { return filepath.Join(e.Home, ".gemini", "settings.json") },
+		projectPath: func(e Env) string { return filepath.Join(e.ProjectRoot, ".gemini", "settings.json") },
+		detect:      func(e Env) []string { return []string{filepath.Join(e.Home, ".gemini")} },
+		entry:       func(s Server) any { return stdioEntry{Command: s.Command, Args: s.Args} },
+	},
+}
+
+// Names returns the canonical client names.
+func Names() []string {
+	names := make([]string, len(Clients))
+	for i, c := range Clients {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Lookup finds a client by name or alias.
+func Lookup(name string) (*Client, error) {
+	name = strings.ToLower(name)
+	for _, c := range Clients {
+		if c.Name == name {
+			return c, nil
+		}
+		for _, a := range c.Aliases {
+			if a == name {
+				return c, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown client %q: expected one of %s", name, strings.Join(Names(), ", "))
+}
+
+// Path returns the config file for the given scope.
+func (c *Client) Path(env Env, scope Scope) (string, error) {
+	if scope == ScopeProject {
+		if env.ProjectRoot == "" {
+			return "", errors.New("project scope needs a project directory")
+		}
+		return c.projectPath(env), nil
+	}
+	return c.userPath(env), nil
+}
+
+// Detected reports whether the client appears to be installed for
+// this user (its config file or directory exists).
+func (c *Client) Detected(env Env) bool {
+	for _, p := range c.detect(env) {
+		if _, err := os.Stat(p); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Snippet returns the JSON to merge by hand — what Install would add.
+func (c *Client) Snippet(s Server) ([]byte, error) {
+	entry, err := marshalNoEscape(c.entry(s))
+	if err != nil {
+		return nil, err
+	}
+	servers := newObject()
+	servers.set(s.Name, entry)
+	raw, err := servers.marshal()
+	if err != nil {
+		return nil, err
+	}
+	root := newObject()
+	root.set(c.Key, raw)
+	return root.indent()
+}
+
+// Action is what Install or Uninstall did to a config file.
+type Action string
+
+const (
+	ActionAdded     Action = "added"
+	ActionUpdated   Action = "updated"
+	ActionUnchanged Action = "unchanged"
+	ActionRemoved   Action = "removed"
+	ActionNotFound  Action = "not_found"
+)
+
+// Result describes the outcome for one client config file.
+type Result struct {
+	Client string `json:"client"`
+	Scope  Scope  `json:"scope"`
+	Path   string `json:"path"`
+	Action Action `json:"action"`
+}
+
+// Install merges the server entry into the client's config file,
+// creating the file (and its directory) if needed. Fields the user
+// added to an existing entry, such as "env", are kept.
+func Install(c *Client, env Env, scope Scope, s Server) (Result, error) {
+	path, err := c.Path(env, scope)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{Client: c.Name, Scope: scope, Path: path}
+
+	root, mode, exists, err := readConfig(path)
+	if err != nil {
+		return res, err
+	}
+	servers, err := serversObject(root, c.Key, path)
+	if err != nil {
+		return res, err
+	}
+
+	want, err := marshalNoEscape(c.entry(s))
+	if err != nil {
+		return res, err
+	}
+	merged := want
+	res.Action = ActionAdded
+	if current, ok := servers.get(s.Name); ok {
+		res.Action = ActionUpdated
+		if merged, err = overlay(current, want); err != nil {
+			return res, fmt.Errorf("%s: %s.%s: %w", path, c.Key, s.Name, err)
+		}
+		if jsonEqual(current, merged) {
+			res.Action = ActionUnchanged
+			return res, nil
+		}
+	}
+
+	servers.set(s.Name, merged)
+	raw, err := servers.marshal()
+	if err != nil {
+		return res, err
+	}
+	if !exists && c.Schema != "" {
+		schema, _ := marshalNoEscape(c.Schema)
+		root.set("$schema", schema)
+	}
+	root.set(c.Key, raw)
+	return res, writeConfig(path, root, mode)
+}
+
+// Uninstall removes the server entry from the client's config file.
+// A missing file or entry is not an error (ActionNotFound).
+func Uninstall(c *Client, env Env, scope Scope, name string) (Result, error) {
+	path, err := c.Path(env, scope)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{Client: c.Name, Scope: scope, Path: path, Action: ActionNotFound}
+
+	root, mode, exists, err := readConfig(path)
+	if err != nil || !exists {
+		return res, err
+	}
+	servers, err := serversObject(root, c.Key, path)
+	if err != nil {
+		return res, err
+	}
+	if !servers.delete(name) {
+		return res, nil
+	}
+
+	raw, err := servers.marshal()
+	if err != nil {
+		return res, err
+	}
+	root.set(c.Key, raw)
+	res.Action = ActionRemoved
+	return res, writeConfig(path, root, mode)
+}
+
+// readConfig loads a config file. A missing file is an empty object.
+func readConfig(path string) (root *object, mode os.FileMode, exists bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newObject(), 0o644, false, nil
+	}
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	root, err = parseObject(data)
+	if err != nil {
+		// JSONC (comments, trailing commas) lands here too; rewriting it
+		// would drop the comments, so leave the file to the user.
+		return nil, 0, true, fmt.Errorf("parsing %s: %w (fix the file or merge the entry by hand with --print)", path, err)
+	}
+	return root, info.Mode().Perm(), true, nil
+}
+
+// serversObject returns the object under key, or an empty one.
+func serversObject(root *object, key, path string) (*object, error) {
+	raw, ok := root.get(key)
+	if !ok || string(raw) == "null" {
+		return newObject(), nil
+	}
+	servers, err := parseObject(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %q must be an object: %w", path, key, err)
+	}
+	return servers, nil
+}
+
+// overlay sets every field of want on top of current, keeping the
+// other fields of current in place.
+func overlay(current, want json.RawMessage) (json.RawMessage, error) {
+	entry, err := parseObject(current)
+	if err != nil {
+		return nil, fmt.Errorf("existing entry must be an object: %w", err)
+	}
+	fields, err := parseObject(want)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range fields.keys {
+		entry.set(k, fields.values[k])
+	}
+	return entry.marshal()
+}
+
+// writeConfig replaces path atomically so a crash never leaves a
+// client with a truncated config.
+func writeConfig(path string, root *object, mode os.FileMode) error {
+	data, err := root.indent()
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", path, err)
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+package install
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testEnv(t *testing.T) Env {
+	t.Helper()
+	home := t.TempDir()
+	return Env{
+		Home:          home,
+		ConfigDir:     filepath.Join(home, "config"),
+		XDGConfigHome: filepath.Join(home, "xdg"),
+		ProjectRoot:   t.TempDir(),
+	}
+}
+
+// placeFixture copies testdata/<fixture> to the client's config path.
+func placeFixture(t *testing.T, c *Client, env Env, scope Scope, fixture string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := c.Path(env, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func mustLookup(t *testing.T, name string) *Client {
+	t.Helper()
+	c, err := Lookup(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func readJSON(t *testing.T, path string) map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("%s is not valid JSON: %v\n%s", path, err, data)
+	}
+	return v
+}
+
+func TestLookup(t *testing.T) {
+	for name, want := range map[string]string{
+		"claude": "claude", "Claude-Code": "claude", "code": "vscode",
+		"opencode": "opencode", "gemini-cli": "gemini", "cursor": "cursor",
+	} {
+		c, err := Lookup(name)
+		if err != nil {
+			t.Errorf("Lookup(%q): %v", name, err)
+			continue
+		}
+		if c.Name != want {
+			t.Errorf("Lookup(%q) = %s, want %s", name, c.Name, want)
+		}
+	}
+	if _, err := Lookup("emacs"); err == nil || !strings.Contains(err.Error(), "claude, cursor") {
+		t.Errorf("Lookup(emacs) error = %v, want list of clients", err)
+	}
+}
+
+func TestPath_PerScope(t *testing.T) {
+	env := testEnv(t)
+	tests := []struct {
+		client  string
+		user    string
+		project string
+	}{
+		{"claude", filepath.Join(env.Home, ".claude.json"), filepath.Join(env.ProjectRoot, ".mcp.json")},
+		{"cursor", filepath.Join(env.Home, ".cursor", "mcp.json"), filepath.Join(env.ProjectRoot, ".cursor", "mcp.json")},
+		{"vscode", filepath.Join(env.ConfigDir, "Code", "User", "mcp.json"), filepath.Join(env.ProjectRoot, ".vscode", "mcp.json")},
+		{"opencode", filepath.Join(env.XDGConfigHome, "opencode", "opencode.json"), filepath.Join(env.ProjectRoot, "opencode.json")},
+		{"gemini", filepath.Join(env.Home, ".gemini", "settings.json"), filepath.Join(env.ProjectRoot, ".gemini", "settings.json")},
+	}
+	for _, tt := range tests {
+		c := mustLookup(t, tt.client)
+		if got, _ := c.Path(env, ScopeUser); got != tt.user {
+			t.Errorf("%s user path = %s, want %s", tt.client, got, tt.user)
+		}
+		if got, _ := c.Path(env, ScopeProject); got != tt.project {
+			t.Errorf("%s project path = %s, want %s", tt.client, got, tt.project)
+		}
+	}
+
+	env.ProjectRoot = ""
+	if _, err := mustLookup(t, "claude").Path(env, ScopeProject); err == nil {
+		t.Error("expected error for project scope without a project root")
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	if s, err := ParseScope(""); err != nil || s != ScopeUser {
+		t.Errorf("ParseScope(\"\") = %q, %v", s, err)
+	}
+	if _, err := ParseScope("global"); err == nil {
+		t.Error("expected error for unknown scope")
+	}
+}
+
+func TestInstall_CreatesMissingFile(t *testing.T) {
+	env := testEnv(t)
+	for _, c := range Clients {
+		res, err := Install(c, env, ScopeProject, DefaultServer())
+		if err != nil {
+			t.Fatalf("%s: %v", c.Name, err)
+		}
+		if res.Action != ActionAdded {
+			t.Errorf("%s: action = %s, want added", c.Name, res.Action)
+		}
+		servers, _ := readJSON(t, res.Path)[c.Key].(map[string]any)
+		if _, ok := servers["hoofy"]; !ok {
+			t.Errorf("%s: %s has no %s.hoofy", c.Name, res.Path, c.Key)
+		}
+	}
+
+	v := readJSON(t, filepath.Join(env.ProjectRoot, "opencode.json"))
+	if v["$schema"] != "https://opencode.ai/config.json" {
+		t.Errorf("new opencode.json $schema = %v", v["$schema"])
+	}
+	entry := v["mcp"].(map[string]any)["hoofy"].(map[string]any)
+	if cmd, _ := json.Marshal(entry["command"]); string(cmd) != `["hoofy","serve"]` {
+		t.Errorf("opencode command = %s", cmd)
+	}
+}
+
+func TestInstall_PreservesOtherKeysAndOrder(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "claude")
+	path := placeFixture(t, c, env, ScopeUser, "claude.json")
+
+	res, err := Install(c, env, ScopeUser, DefaultServer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ActionAdded {
+		t.Errorf("action = %s, want added", res.Action)
+	}
+
+	data, _ := os.ReadFile(path)
+	text := string(data)
+	order := []string{`"numStartups"`, `"theme"`, `"mcpServers"`, `"github"`, `"hoofy"`, `"projects"`}
+	last := -1
+	for _, key := range order {
+		i := strings.Index(text, key)
+		if i < last {
+			t.Fatalf("key %s out of order in:\n%s", key, text)
+		}
+		last = i
+	}
+
+	v := readJSON(t, path)
+	if v["numStartups"] != float64(42) {
+		t.Errorf("numStartups = %v", v["numStartups"])
+	}
+	servers := v["mcpServers"].(map[string]any)
+	if _, ok := servers["github"]; !ok {
+		t.Error("existing github server was dropped")
+	}
+	hoofy := servers["hoofy"].(map[string]any)
+	if hoofy["type"] != "stdio" || hoofy["command"] != "hoofy" {
+		t.Errorf("hoofy entry = %v", hoofy)
+	}
+
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, want 0600 preserved", info.Mode().Perm())
+	}
+}
+
+func TestInstall_Idempotent(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "claude")
+	path := placeFixture(t, c, env, ScopeUser, "claude.json")
+
+	if _, err := Install(c, env, ScopeUser, DefaultServer()); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.ReadFile(path)
+
+	res, err := Install(c, env, ScopeUser, DefaultServer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ActionUnchanged {
+		t.Errorf("second install action = %s, want unchanged", res.Action)
+	}
+	second, _ := os.ReadFile(path)
+	if string(first) != string(second) {
+		t.Errorf("second install rewrote the file:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestInstall_AlreadyConfiguredFixtureIsUnchanged(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "opencode")
+	path := placeFixture(t, c, env, ScopeUser, "opencode.json")
+	before, _ := os.ReadFile(path)
+
+	res, err := Install(c, env, ScopeUser, DefaultServer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ActionUnchanged {
+		t.Errorf("action = %s, want unchanged", res.Action)
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("unchanged install must not touch the file")
+	}
+}
+
+func TestInstall_UpdatesEntryKeepingUserFields(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "vscode")
+	path := placeFixture(t, c, env, ScopeProject, "vscode.json")
+
+	res, err := Install(c, env, ScopeProject, DefaultServer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ActionUpdated {
+		t.Errorf("action = %s, want updated", res.Action)
+	}
+
+	hoofy := readJSON(t, path)["servers"].(map[string]any)["hoofy"].(map[string]any)
+	if hoofy["command"] != "hoofy" {
+		t.Errorf("command = %v, want hoofy", hoofy["command"])
+	}
+	env2, _ := hoofy["env"].(map[string]any)
+	if env2["HOOFY_CONFIG"] != "/etc/hoofy.json" {
+		t.Errorf("user env was dropped: %v", hoofy)
+	}
+}
+
+func TestInstall_CustomCommand(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "gemini")
+	s := DefaultServer()
+	s.Command = "/opt/hoofy & co/hoofy"
+
+	res, err := Install(c, env, ScopeUser, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(res.Path)
+	if !strings.Contains(string(data), `"/opt/hoofy & co/hoofy"`) {
+		t.Errorf("command not written verbatim:\n%s", data)
+	}
+}
+
+func TestInstall_RefusesUnparseableFiles(t *testing.T) {
+	tests := []struct {
+		client, fixture string
+	}{
+		{"gemini", "gemini-jsonc.json"},
+		{"cursor", "cursor-bad-servers.json"},
+	}
+	for _, tt := range tests {
+		env := testEnv(t)
+		c := mustLookup(t, tt.client)
+		path := placeFixture(t, c, env, ScopeUser, tt.fixture)
+		before, _ := os.ReadFile(path)
+
+		if _, err := Install(c, env, ScopeUser, DefaultServer()); err == nil {
+			t.Errorf("%s: expected error", tt.fixture)
+		}
+		after, _ := os.ReadFile(path)
+		if string(before) != string(after) {
+			t.Errorf("%s: file modified after failed install", tt.fixture)
+		}
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "opencode")
+	path := placeFixture(t, c, env, ScopeUser, "opencode.json")
+
+	res, err := Uninstall(c, env, ScopeUser, "hoofy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ActionRemoved {
+		t.Errorf("action = %s, want removed", res.Action)
+	}
+	v := readJSON(t, path)
+	servers := v["mcp"].(map[string]any)
+	if _, ok := servers["hoofy"]; ok {
+		t.Error("hoofy entry still present")
+	}
+	if _, ok := servers["context7"]; !ok {
+		t.Error("other server was dropped")
+	}
+	if v["theme"] != "opencode" {
+		t.Errorf("theme = %v", v["theme"])
+	}
+
+	res, err = Uninstall(c, env, ScopeUser, "hoofy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ActionNotFound {
+		t.Errorf("second uninstall action = %s, want not_found", res.Action)
+	}
+}
+
+func TestUninstall_MissingFile(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "cursor")
+	res, err := Uninstall(c, env, ScopeUser, "hoofy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != ActionNotFound {
+		t.Errorf("action = %s, want not_found", res.Action)
+	}
+	if _, err := os.Stat(res.Path); !os.IsNotExist(err) {
+		t.Error("uninstall must not create the config file")
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	got, err := mustLookup(t, "vscode").Snippet(DefaultServer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "servers": {
+    "hoofy": {
+      "type": "stdio",
+      "command": "hoofy",
+      "args": [
+        "serve"
+      ]
+    }
+  }
+}
+`
+	if string(got) != want {
+		t.Errorf("Snippet =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDetected(t *testing.T) {
+	env := testEnv(t)
+	c := mustLookup(t, "cursor")
+	if c.Detected(env) {
+		t.Error("cursor detected in an empty home")
+	}
+	if err := os.MkdirAll(filepath.Join(env.Home, ".cursor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Detected(env) {
+		t.Error("cursor not detected after creating ~/.cursor")
+	}
+}
@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ─── Backend ─────────────────────────────────────────────────────────────────
+
+// ErrNotFound is returned by GetObservation and GetSession when the
+// record does not exist (or is soft-deleted). It is sql.ErrNoRows so
+// callers written against *Store keep working with any Backend.
+var ErrNotFound = sql.ErrNoRows
+
+// SessionStore persists coding sessions.
+type SessionStore interface {
+	CreateSession(id, project, directory string) error
+	EndSession(id string, summary string) error
+	GetSession(id string) (*Session, error)
+	RecentSessions(project string, limit int) ([]SessionSummary, error)
+}
+
+// ObservationStore persists observations. AddObservation applies the
+// topic_key upsert and the dedupe window; deletes are soft unless
+// hardDelete is set.
+type ObservationStore interface {
+	AddObservation(p AddObservationParams) (int64, error)
+	GetObservation(id int64) (*Observation, error)
+	UpdateObservation(id int64, p UpdateObservationParams) (*Observation, error)
+	DeleteObservation(id int64, hardDelete bool) error
+	RecentObservations(project, scope, namespace string, limit int) ([]Observation, error)
+	CountObservations(project, scope, namespace string) (int, error)
+	FindByTopicKey(topicKey, project, scope string) (*Observation, error)
+	Timeline(observationID int64, before, after int) (*TimelineResult, error)
+}
+
+// RelationStore persists the knowledge graph between observations.
+type RelationStore interface {
+	AddRelation(p AddRelationParams) ([]int64, error)
+	RemoveRelation(id int64) error
+	GetRelations(observationID int64) ([]Relation, error)
+	BuildContext(observationID int64, maxDepth int) (*ContextResult, error)
+}
+
+// PromptStore persists user prompts.
+type PromptStore interface {
+	AddPrompt(p AddPromptParams) (int64, error)
+	RecentPrompts(project string, limit int) ([]Prompt, error)
+	SearchPrompts(query string, project string, limit int) ([]Prompt, error)
+}
+
+// Searcher runs full-text search over observations. Lower Rank is a
+// better match; an empty query falls back to the most recent entries.
+type Searcher interface {
+	Search(query string, opts SearchOptions) ([]SearchResult, error)
+	CountSearchResults(query string, opts SearchOptions) (int, error)
+}
+
+// Compactor finds and consolidates stale observations.
+type Compactor interface {
+	FindStaleObservations(project, scope, namespace string, olderThanDays, limit int) ([]Observation, error)
+	CompactObservations(p CompactParams) (*CompactResult, error)
+}
+
+// Backend is the storage contract the memory tools and the SDD bridge
+// depend on. *Store (SQLite + FTS5) is the production implementation;
+// MemoryBackend keeps everything in process. Both pass the same
+// conformance suite (backend_test.go).
+type Backend interface {
+	SessionStore
+	ObservationStore
+	RelationStore
+	PromptStore
+	Searcher
+	Compactor
+
+	Stats() (*Stats, error)
+	FormatContextDetailed(project, scope string, opts ContextFormatOptions) (string, error)
+	PassiveCapture(p PassiveCaptureParams) (*PassiveCaptureResult, error)
+	Close() error
+}
+
+// Compile-time interface checks.
+var (
+	_ Backend = (*Store)(nil)
+	_ Backend = (*MemoryBackend)(nil)
+)
+
+// ─── Shared behavior ─────────────────────────────────────────────────────────
+//
+// Context formatting and passive capture are defined once on top of
+// the storage primitives so every backend renders and dedupes alike.
+
+// contextSource is the read side FormatContextDetailed needs.
+type contextSource interface {
+	RecentSessions(project string, limit int) ([]SessionSummary, error)
+	RecentObservations(project, scope, namespace string, limit int) ([]Observation, error)
+	RecentPrompts(project string, limit int) ([]Prompt, error)
+}
+
+// formatContextDetailed implements FormatContextDetailed for any backend.
+func formatContextDetailed(src contextSource, defaultLimit int, project, scope string, opts ContextFormatOptions) (string, error) {
+	detail := ParseDetailLevel(opts.DetailLevel)
+
+	obsLimit := defaultLimit
+	if opts.Limit > 0 {
+		obsLimit = opts.Limit
+	}
+
+	sessions, err := src.RecentSessions(project, 5)
+	if err != nil {
+		return "", err
+	}
+
+	observations, err := src.RecentObservations(project, scope, opts.Namespace, obsLimit)
+	if err != nil {
+		return "", err
+	}
+
+	prompts, err := src.RecentPrompts(project, 10)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sessions) == 0 && len(observations) == 0 && len(prompts) == 0 {
+		return "", nil
+	}
+
+	switch detail {
+	case DetailSummary:
+		return formatContextSummary(sessions, observations, prompts, opts.MaxTokens), nil
+	case DetailFull:
+		return formatContextFull(sessions, observations, prompts, opts.MaxTokens), nil
+	default:
+		return formatContextStandard(sessions, observations, prompts, opts.MaxTokens), nil
+	}
+}
+
+// formatContextStandard is the original behavior — truncated snippets.
+// When maxTokens > 0, stops adding observations once the budget would be exceeded.
+func formatContextStandard(sessions []SessionSummary, observations []Observation, prompts []Prompt, maxTokens int) string {
+	var b strings.Builder
+	b.WriteString("## Memory from Previous Sessions\n\n")
+
+	if len(sessions) > 0 {
+		b.WriteString("### Recent Sessions\n")
+		for _, sess := range sessions {
+			summary := ""
+			if sess.Summary != nil {
+				summary = fmt.Sprintf(": %s", Truncate(*sess.Summary, 200))
+			}
+			fmt.Fprintf(&b, "- **%s** (%s)%s [%d observations]\n",
+				sess.Project, sess.StartedAt, summary, sess.ObservationCount)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(prompts) > 0 {
+		b.WriteString("### Recent User Prompts\n")
+		for _, p := range prompts {
+			fmt.Fprintf(&b, "- %s: %s\n", p.CreatedAt, Truncate(p.Content, 200))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(observations) > 0 {
+		b.WriteString("### Recent Observations\n")
+		shown := 0
+		for _, obs := range observations {
+			entry := fmt.Sprintf("- [%s] **%s**: %s\n",
+				obs.Type, obs.Title, Truncate(obs.Content, 300))
+			if maxTokens > 0 && EstimateTokens(b.String()+entry) > maxTokens {
+				b.WriteString(BudgetFooter(EstimateTokens(b.String()), maxTokens, shown, len(observations)))
+				return b.String()
+			}
+			b.WriteString(entry)
+			shown++
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatContextSummary returns minimal metadata — session names, observation
+// titles, and prompt timestamps. No content snippets. Minimal tokens.
+// When maxTokens > 0, stops adding observations once the budget would be exceeded.
+func formatContextSummary(sessions []SessionSummary, observations []Observation, prompts []Prompt, maxTokens int) string {
+	var b strings.Builder
+	b.WriteString("## Memory Context (summary)\n\n")
+
+	if len(sessions) > 0 {
+		b.WriteString("### Sessions\n")
+		for _, sess := range sessions {
+			fmt.Fprintf(&b, "- %s (%s) [%d obs]\n",
+				sess.Project, sess.StartedAt, sess.ObservationCount)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(prompts) > 0 {
+		b.WriteString("### Prompts\n")
+		for _, p := range prompts {
+			fmt.Fprintf(&b, "- %s: %s\n", p.CreatedAt, Truncate(p.Content, 80))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(observations) > 0 {
+		b.WriteString("### Observations\n")
+		shown := 0
+		for _, obs := range observations {
+			entry := fmt.Sprintf("- #%d [%s] %s\n", obs.ID, obs.Type, obs.Title)
+			if maxTokens > 0 && EstimateTokens(b.String()+entry) > maxTokens {
+				b.WriteString(BudgetFooter(EstimateTokens(b.String()), maxTokens, shown, len(observations)))
+				return b.String()
+			}
+			b.WriteString(entry)
+			shown++
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatContextFull returns complete untruncated content for deep analysis.
+// When maxTokens > 0, stops adding observations once the budget would be exceeded.
+func formatContextFull(sessions []SessionSummary, observations []Observation, prompts []Prompt, maxTokens int) string {
+	var b strings.Builder
+	b.WriteString("## Memory from Previous Sessions (full)\n\n")
+
+	if len(sessions) > 0 {
+		b.WriteString("### Recent Sessions\n")
+		for _, sess := range sessions {
+			summary := ""
+			if sess.Summary != nil {
+				summary = fmt.Sprintf(": %s", *sess.Summary) // untruncated
+			}
+			fmt.Fprintf(&b, "- **%s** (%s)%s [%d observations]\n",
+				sess.Project, sess.StartedAt, summary, sess.ObservationCount)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(prompts) > 0 {
+		b.WriteString("### Recent User Prompts\n")
+		for _, p := range prompts {
+			fmt.Fprintf(&b, "- %s: %s\n", p.CreatedAt, p.Content) // untruncated
+		}
+		b.WriteString("\n")
+	}
+
+	if len(observations) > 0 {
+		b.WriteString("### Recent Observations\n")
+		shown := 0
+		for _, obs := range observations {
+			entry := fmt.Sprintf("#### [%s] %s (ID: %d)\n%s\n\n",
+				obs.Type, obs.Title, obs.ID, obs.Content)
+			if maxTokens > 0 && EstimateTokens(b.String()+entry) > maxTokens {
+				b.WriteString(BudgetFooter(EstimateTokens(b.String()), maxTokens, shown, len(observations)))
+				return b.String()
+			}
+			b.WriteString(entry)
+			shown++
+		}
+	}
+
+	return b.String()
+}
+
+// captureSink is the write side PassiveCapture needs: hasContentHash
+// reports whether a live observation in the project already holds
+// content with the given normalized hash.
+type captureSink interface {
+	AddObservation(p AddObservationParams) (int64, error)
+	hasContentHash(normHash, project string) (bool, error)
+}
+
+// passiveCapture implements PassiveCapture for any backend.
+func passiveCapture(sink captureSink, p PassiveCaptureParams) (*PassiveCaptureResult, error) {
+	result := &PassiveCaptureResult{}
+
+	learnings := ExtractLearnings(p.Content)
+	result.Extracted = len(learnings)
+
+	if len(learnings) == 0 {
+		return result, nil
+	}
+
+	for _, learning := range learnings {
+		exists, err := sink.hasContentHash(hashNormalized(learning), p.Project)
+		if err != nil {
+			return result, fmt.Errorf("passive capture dedupe: %w", err)
+		}
+		if exists {
+			result.Duplicates++
+			continue
+		}
+
+		title := learning
+		if len(title) > 60 {
+			title = title[:60] + "..."
+		}
+
+		_, err = sink.AddObservation(AddObservationParams{
+			SessionID: p.SessionID,
+			Type:      "passive",
+			Title:     title,
+			Content:   learning,
+			Project:   p.Project,
+			Scope:     "project",
+			ToolName:  p.Source,
+		})
+		if err != nil {
+			return result, fmt.Errorf("passive capture save: %w", err)
+		}
+		result.Saved++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,712 @@
+package memory_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/memory"
+)
+
+// ─── Backend conformance suite ───────────────────────────────────────────────
+//
+// Every memory.Backend implementation must pass the same behavioral
+// contract. Add new backends to conformanceTargets.
+
+type conformanceTarget struct {
+	name string
+	new  func(t *testing.T) memory.Backend
+	// age backdates an observation's created_at by the given number of days.
+	age func(t *testing.T, b memory.Backend, id int64, days int)
+}
+
+var conformanceTargets = []conformanceTarget{
+	{
+		name: "sqlite",
+		new:  func(t *testing.T) memory.Backend { return newTestStore(t) },
+		age: func(t *testing.T, b memory.Backend, id int64, days int) {
+			ageObservation(t, b.(*memory.Store), id, days)
+		},
+	},
+	{
+		name: "in-memory",
+		new: func(t *testing.T) memory.Backend {
+			return memory.NewMemoryBackend(memory.Config{
+				MaxObservationLength: 2000,
+				MaxContextResults:    20,
+				MaxSearchResults:     20,
+				DedupeWindow:         15 * time.Minute,
+			})
+		},
+		age: func(t *testing.T, b memory.Backend, id int64, days int) {
+			b.(*memory.MemoryBackend).Backdate(id, time.Duration(days)*24*time.Hour)
+		},
+	},
+}
+
+var conformanceCases = []struct {
+	name string
+	run  func(t *testing.T, b memory.Backend, target conformanceTarget)
+}{
+	{"Sessions", conformSessions},
+	{"ObservationRequiresSession", conformObservationRequiresSession},
+	{"AddAndGet", conformAddAndGet},
+	{"Dedupe", conformDedupe},
+	{"TopicKeyUpsert", conformTopicKeyUpsert},
+	{"Update", conformUpdate},
+	{"Delete", conformDelete},
+	{"RecentAndCountFilters", conformRecentAndCountFilters},
+	{"Search", conformSearch},
+	{"Relations", conformRelations},
+	{"BuildContext", conformBuildContext},
+	{"Prompts", conformPrompts},
+	{"Timeline", conformTimeline},
+	{"Compaction", conformCompaction},
+	{"StatsAndContext", conformStatsAndContext},
+	{"PassiveCapture", conformPassiveCapture},
+}
+
+func TestBackendConformance(t *testing.T) {
+	for _, target := range conformanceTargets {
+		t.Run(target.name, func(t *testing.T) {
+			for _, c := range conformanceCases {
+				t.Run(c.name, func(t *testing.T) {
+					c.run(t, target.new(t), target)
+				})
+			}
+		})
+	}
+}
+
+// ─── Helpers ─────────────────────────────────────────────────────────────────
+
+func mustSession(t *testing.T, b memory.Backend, id, project string) {
+	t.Helper()
+	if err := b.CreateSession(id, project, "/tmp/"+project); err != nil {
+		t.Fatalf("CreateSession(%q): %v", id, err)
+	}
+}
+
+func mustObs(t *testing.T, b memory.Backend, p memory.AddObservationParams) int64 {
+	t.Helper()
+	if p.Type == "" {
+		p.Type = "manual"
+	}
+	id, err := b.AddObservation(p)
+	if err != nil {
+		t.Fatalf("AddObservation(%q): %v", p.Title, err)
+	}
+	return id
+}
+
+func mustGet(t *testing.T, b memory.Backend, id int64) *memory.Observation {
+	t.Helper()
+	obs, err := b.GetObservation(id)
+	if err != nil {
+		t.Fatalf("GetObservation(%d): %v", id, err)
+	}
+	return obs
+}
+
+func observationIDs(obs []memory.Observation) map[int64]bool {
+	ids := make(map[int64]bool, len(obs))
+	for _, o := range obs {
+		ids[o.ID] = true
+	}
+	return ids
+}
+
+func strPtr(s string) *string { return &s }
+
+// ─── Cases ───────────────────────────────────────────────────────────────────
+
+func conformSessions(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "alpha")
+	mustSession(t, b, "s1", "ignored") // idempotent: first write wins
+	mustSession(t, b, "s2", "beta")
+
+	sess, err := b.GetSession("s1")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if sess.Project != "alpha" || sess.EndedAt != nil || sess.StartedAt == "" {
+		t.Errorf("session = %+v", sess)
+	}
+
+	if err := b.EndSession("s1", "wrapped up"); err != nil {
+		t.Fatalf("EndSession: %v", err)
+	}
+	sess, _ = b.GetSession("s1")
+	if sess.EndedAt == nil || sess.Summary == nil || *sess.Summary != "wrapped up" {
+		t.Errorf("ended session = %+v", sess)
+	}
+
+	if _, err := b.GetSession("nope"); !errors.Is(err, memory.ErrNotFound) || !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetSession(missing) error = %v, want ErrNotFound", err)
+	}
+
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "one", Content: "first", Project: "alpha"})
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "two", Content: "second", Project: "alpha"})
+
+	all, err := b.RecentSessions("", 10)
+	if err != nil {
+		t.Fatalf("RecentSessions: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("RecentSessions returned %d sessions, want 2", len(all))
+	}
+	alpha, err := b.RecentSessions("alpha", 10)
+	if err != nil {
+		t.Fatalf("RecentSessions(alpha): %v", err)
+	}
+	if len(alpha) != 1 || alpha[0].ID != "s1" || alpha[0].ObservationCount != 2 {
+		t.Errorf("RecentSessions(alpha) = %+v", alpha)
+	}
+}
+
+func conformObservationRequiresSession(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	if _, err := b.AddObservation(memory.AddObservationParams{
+		SessionID: "ghost", Type: "manual", Title: "t", Content: "c",
+	}); err == nil {
+		t.Error("AddObservation with unknown session should fail")
+	}
+	if _, err := b.AddPrompt(memory.AddPromptParams{SessionID: "ghost", Content: "hi"}); err == nil {
+		t.Error("AddPrompt with unknown session should fail")
+	}
+}
+
+func conformAddAndGet(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	id := mustObs(t, b, memory.AddObservationParams{
+		SessionID: "s1",
+		Type:      "decision",
+		Title:     "Use JWT",
+		Content:   "Token is <private>hunter2</private> rotated daily",
+		ToolName:  "edit",
+		Project:   "proj",
+		Namespace: "agent-a",
+	})
+
+	obs := mustGet(t, b, id)
+	if obs.Type != "decision" || obs.Title != "Use JWT" || obs.SessionID != "s1" {
+		t.Errorf("observation = %+v", obs)
+	}
+	if strings.Contains(obs.Content, "hunter2") || !strings.Contains(obs.Content, "[REDACTED]") {
+		t.Errorf("private content not redacted: %q", obs.Content)
+	}
+	if obs.Scope != "project" {
+		t.Errorf("Scope = %q, want default project", obs.Scope)
+	}
+	if obs.Project == nil || *obs.Project != "proj" || obs.Namespace == nil || *obs.Namespace != "agent-a" {
+		t.Errorf("project/namespace = %v/%v", obs.Project, obs.Namespace)
+	}
+	if obs.ToolName == nil || *obs.ToolName != "edit" {
+		t.Errorf("ToolName = %v", obs.ToolName)
+	}
+	if obs.RevisionCount != 1 || obs.DuplicateCount != 1 || obs.CreatedAt == "" || obs.UpdatedAt == "" {
+		t.Errorf("counters/timestamps = %+v", obs)
+	}
+
+	long := mustGet(t, b, mustObs(t, b, memory.AddObservationParams{
+		SessionID: "s1", Title: "long", Content: strings.Repeat("x", 2500),
+	}))
+	if !strings.HasSuffix(long.Content, "... [truncated]") || len(long.Content) != 2000+len("... [truncated]") {
+		t.Errorf("long content not truncated to limit: len=%d", len(long.Content))
+	}
+
+	if _, err := b.GetObservation(9999); !errors.Is(err, memory.ErrNotFound) {
+		t.Errorf("GetObservation(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func conformDedupe(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	p := memory.AddObservationParams{SessionID: "s1", Title: "Same", Content: "Same   content", Project: "proj"}
+	first := mustObs(t, b, p)
+
+	p.Content = "same content" // whitespace and case are normalized
+	second := mustObs(t, b, p)
+	if first != second {
+		t.Fatalf("duplicate created new ID %d (first %d)", second, first)
+	}
+	if got := mustGet(t, b, first).DuplicateCount; got != 2 {
+		t.Errorf("DuplicateCount = %d, want 2", got)
+	}
+
+	p.Namespace = "agent-b"
+	if other := mustObs(t, b, p); other == first {
+		t.Error("different namespace must not dedupe")
+	}
+	p.Namespace = ""
+	p.Title = "Different title"
+	if other := mustObs(t, b, p); other == first {
+		t.Error("different title must not dedupe")
+	}
+}
+
+func conformTopicKeyUpsert(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	first := mustObs(t, b, memory.AddObservationParams{
+		SessionID: "s1", Type: "architecture", Title: "Auth v1", Content: "sessions", Project: "proj", TopicKey: "architecture/auth",
+	})
+	second := mustObs(t, b, memory.AddObservationParams{
+		SessionID: "s1", Type: "architecture", Title: "Auth v2", Content: "JWT", Project: "proj", TopicKey: "Architecture/Auth",
+	})
+	if first != second {
+		t.Fatalf("topic key upsert created new ID %d (first %d)", second, first)
+	}
+	obs := mustGet(t, b, first)
+	if obs.Title != "Auth v2" || obs.Content != "JWT" || obs.RevisionCount != 2 {
+		t.Errorf("upserted observation = %+v", obs)
+	}
+
+	personal := mustObs(t, b, memory.AddObservationParams{
+		SessionID: "s1", Type: "architecture", Title: "Auth", Content: "mine", Project: "proj", Scope: "personal", TopicKey: "architecture/auth",
+	})
+	if personal == first {
+		t.Error("different scope must not upsert")
+	}
+
+	found, err := b.FindByTopicKey("architecture/auth", "proj", "project")
+	if err != nil || found == nil || found.ID != first {
+		t.Errorf("FindByTopicKey = %+v, %v; want #%d", found, err, first)
+	}
+	if found, err := b.FindByTopicKey("nope/none", "proj", "project"); err != nil || found != nil {
+		t.Errorf("FindByTopicKey(missing) = %+v, %v; want nil, nil", found, err)
+	}
+	if found, err := b.FindByTopicKey("", "proj", "project"); err != nil || found != nil {
+		t.Errorf("FindByTopicKey(\"\") = %+v, %v; want nil, nil", found, err)
+	}
+}
+
+func conformUpdate(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	id := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "Old", Content: "old body", Project: "proj"})
+
+	obs, err := b.UpdateObservation(id, memory.UpdateObservationParams{
+		Title: strPtr("New"),
+		Scope: strPtr("PERSONAL"),
+	})
+	if err != nil {
+		t.Fatalf("UpdateObservation: %v", err)
+	}
+	if obs.Title != "New" || obs.Content != "old body" || obs.Scope != "personal" || obs.RevisionCount != 2 {
+		t.Errorf("updated observation = %+v", obs)
+	}
+
+	obs, err = b.UpdateObservation(id, memory.UpdateObservationParams{Project: strPtr("")})
+	if err != nil {
+		t.Fatalf("UpdateObservation: %v", err)
+	}
+	if obs.Project != nil {
+		t.Errorf("empty project should clear it, got %q", *obs.Project)
+	}
+
+	if _, err := b.UpdateObservation(9999, memory.UpdateObservationParams{Title: strPtr("x")}); !errors.Is(err, memory.ErrNotFound) {
+		t.Errorf("UpdateObservation(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func conformDelete(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	a := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "A", Content: "a", Project: "proj"})
+	c := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "C", Content: "c", Project: "proj"})
+	d := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "D", Content: "d", Project: "proj"})
+	if _, err := b.AddRelation(memory.AddRelationParams{FromID: c, ToID: d}); err != nil {
+		t.Fatalf("AddRelation: %v", err)
+	}
+
+	if err := b.DeleteObservation(a, false); err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	if _, err := b.GetObservation(a); !errors.Is(err, memory.ErrNotFound) {
+		t.Errorf("soft-deleted observation still visible: %v", err)
+	}
+	if n, _ := b.CountObservations("proj", "", ""); n != 2 {
+		t.Errorf("CountObservations after soft delete = %d, want 2", n)
+	}
+	if err := b.DeleteObservation(a, false); err != nil {
+		t.Errorf("deleting twice should be a no-op, got %v", err)
+	}
+
+	if err := b.DeleteObservation(c, true); err != nil {
+		t.Fatalf("hard delete: %v", err)
+	}
+	if rels, _ := b.GetRelations(d); len(rels) != 0 {
+		t.Errorf("hard delete left %d relation(s) behind", len(rels))
+	}
+	if err := b.DeleteObservation(9999, true); err != nil {
+		t.Errorf("deleting a missing ID should be a no-op, got %v", err)
+	}
+}
+
+func conformRecentAndCountFilters(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "p1", Content: "1", Project: "proj"})
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "p2", Content: "2", Project: "proj", Namespace: "agent"})
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "me", Content: "3", Project: "proj", Scope: "personal"})
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "other", Content: "4", Project: "other"})
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "none", Content: "5"})
+
+	tests := []struct {
+		project, scope, namespace string
+		want                      int
+	}{
+		{"", "", "", 5},
+		{"proj", "", "", 3},
+		{"proj", "project", "", 2},
+		{"proj", "personal", "", 1},
+		{"", "", "agent", 1},
+		{"missing", "", "", 0},
+	}
+	for _, tt := range tests {
+		n, err := b.CountObservations(tt.project, tt.scope, tt.namespace)
+		if err != nil {
+			t.Fatalf("CountObservations: %v", err)
+		}
+		recent, err := b.RecentObservations(tt.project, tt.scope, tt.namespace, 50)
+		if err != nil {
+			t.Fatalf("RecentObservations: %v", err)
+		}
+		if n != tt.want || len(recent) != tt.want {
+			t.Errorf("filters %q/%q/%q: count=%d recent=%d, want %d", tt.project, tt.scope, tt.namespace, n, len(recent), tt.want)
+		}
+	}
+
+	if recent, _ := b.RecentObservations("", "", "", 2); len(recent) != 2 {
+		t.Errorf("RecentObservations limit 2 returned %d", len(recent))
+	}
+}
+
+func conformSearch(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	jwt := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Type: "decision", Title: "Auth design", Content: "Use JWT auth flow with refresh tokens", Project: "proj"})
+	cache := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Type: "pattern", Title: "Caching", Content: "cache cache cache everywhere", Project: "proj"})
+	once := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Type: "bugfix", Title: "Stale read", Content: "invalidate the cache", Project: "other"})
+	gone := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Type: "decision", Title: "Old auth", Content: "basic auth", Project: "proj"})
+	if err := b.DeleteObservation(gone, false); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(q string, opts memory.SearchOptions) []memory.SearchResult {
+		t.Helper()
+		res, err := b.Search(q, opts)
+		if err != nil {
+			t.Fatalf("Search(%q): %v", q, err)
+		}
+		n, err := b.CountSearchResults(q, opts)
+		if err != nil {
+			t.Fatalf("CountSearchResults(%q): %v", q, err)
+		}
+		if n < len(res) {
+			t.Errorf("CountSearchResults(%q) = %d < %d results", q, n, len(res))
+		}
+		return res
+	}
+	ids := func(res []memory.SearchResult) []int64 {
+		var out []int64
+		for _, r := range res {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	if got := ids(search("jwt", memory.SearchOptions{})); len(got) != 1 || got[0] != jwt {
+		t.Errorf("Search(jwt) = %v, want [%d] (case-insensitive, deleted excluded)", got, jwt)
+	}
+	if got := ids(search("auth-flow", memory.SearchOptions{})); len(got) != 1 || got[0] != jwt {
+		t.Errorf("Search(auth-flow) = %v, want [%d] (hyphenated word is a phrase)", got, jwt)
+	}
+	if got := search("refresh missingword", memory.SearchOptions{}); len(got) != 0 {
+		t.Errorf("all words must match, got %v", ids(got))
+	}
+	if got := ids(search("design", memory.SearchOptions{})); len(got) != 1 || got[0] != jwt {
+		t.Errorf("Search(design) should match titles, got %v", got)
+	}
+	if got := ids(search("bugfix", memory.SearchOptions{})); len(got) != 1 || got[0] != once {
+		t.Errorf("Search(bugfix) should match the type field, got %v", got)
+	}
+
+	res := search("cache", memory.SearchOptions{})
+	if got := ids(res); len(got) != 2 || got[0] != cache {
+		t.Errorf("Search(cache) = %v, want %d ranked first", got, cache)
+	}
+	for i := 1; i < len(res); i++ {
+		if res[i].Rank < res[i-1].Rank {
+			t.Errorf("results not ordered by rank: %v", res)
+		}
+	}
+	if got := ids(search("cache", memory.SearchOptions{Project: "other"})); len(got) != 1 || got[0] != once {
+		t.Errorf("project filter: %v", got)
+	}
+	if got := ids(search("cache", memory.SearchOptions{Type: "pattern"})); len(got) != 1 || got[0] != cache {
+		t.Errorf("type filter: %v", got)
+	}
+
+	if got := search("   ", memory.SearchOptions{}); len(got) != 3 {
+		t.Errorf("empty query should return recent live observations, got %d", len(got))
+	}
+	if got := search("", memory.SearchOptions{Limit: 2}); len(got) != 2 {
+		t.Errorf("limit 2 returned %d", len(got))
+	}
+	if n, _ := b.CountSearchResults("", memory.SearchOptions{Project: "proj"}); n != 2 {
+		t.Errorf("CountSearchResults(empty, proj) = %d, want 2", n)
+	}
+}
+
+func conformRelations(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	a := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "A", Content: "a"})
+	c := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "C", Content: "c"})
+	d := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "D", Content: "d"})
+
+	ids, err := b.AddRelation(memory.AddRelationParams{FromID: a, ToID: c, Note: "why"})
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("AddRelation = %v, %v", ids, err)
+	}
+	rels, _ := b.GetRelations(c)
+	if len(rels) != 1 || rels[0].Type != "relates_to" || rels[0].Note != "why" || rels[0].FromID != a {
+		t.Errorf("GetRelations = %+v", rels)
+	}
+
+	if _, err := b.AddRelation(memory.AddRelationParams{FromID: a, ToID: c}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("duplicate relation error = %v", err)
+	}
+	if _, err := b.AddRelation(memory.AddRelationParams{FromID: a, ToID: a}); err == nil {
+		t.Error("self relation should fail")
+	}
+	if _, err := b.AddRelation(memory.AddRelationParams{FromID: a, ToID: 9999}); err == nil {
+		t.Error("relation to a missing observation should fail")
+	}
+
+	both, err := b.AddRelation(memory.AddRelationParams{FromID: c, ToID: d, Type: "depends_on", Bidirectional: true})
+	if err != nil || len(both) != 2 {
+		t.Fatalf("bidirectional AddRelation = %v, %v", both, err)
+	}
+	if _, err := b.AddRelation(memory.AddRelationParams{FromID: d, ToID: c, Type: "depends_on", Bidirectional: true}); err == nil {
+		t.Error("bidirectional duplicate should fail")
+	}
+	if rels, _ := b.GetRelations(c); len(rels) != 3 {
+		t.Errorf("GetRelations(c) = %d relations, want 3", len(rels))
+	}
+
+	if err := b.RemoveRelation(ids[0]); err != nil {
+		t.Fatalf("RemoveRelation: %v", err)
+	}
+	if err := b.RemoveRelation(ids[0]); err == nil {
+		t.Error("removing a missing relation should fail")
+	}
+	if rels, _ := b.GetRelations(a); len(rels) != 0 {
+		t.Errorf("relation still present after removal: %+v", rels)
+	}
+}
+
+func conformBuildContext(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	a := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "A", Content: "a"})
+	c := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "C", Content: "c"})
+	d := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "D", Content: "d"})
+	for _, r := range []memory.AddRelationParams{{FromID: a, ToID: c, Type: "causes"}, {FromID: d, ToID: c, Type: "blocks"}} {
+		if _, err := b.AddRelation(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	shallow, err := b.BuildContext(a, 1)
+	if err != nil {
+		t.Fatalf("BuildContext: %v", err)
+	}
+	if shallow.Root.ID != a || shallow.TotalNodes != 1 || shallow.MaxDepth != 1 {
+		t.Errorf("depth 1 = %+v", shallow)
+	}
+
+	deep, err := b.BuildContext(a, 3)
+	if err != nil {
+		t.Fatalf("BuildContext: %v", err)
+	}
+	if deep.TotalNodes != 2 || deep.MaxDepth != 2 {
+		t.Fatalf("depth 3 = %+v", deep)
+	}
+	byID := map[int64]memory.ContextNode{}
+	for _, n := range deep.Connected {
+		byID[n.ID] = n
+	}
+	if n := byID[c]; n.Direction != "outgoing" || n.RelationType != "causes" || n.Depth != 1 {
+		t.Errorf("node C = %+v", n)
+	}
+	if n := byID[d]; n.Direction != "incoming" || n.RelationType != "blocks" || n.Depth != 2 {
+		t.Errorf("node D = %+v", n)
+	}
+
+	if _, err := b.BuildContext(9999, 2); err == nil {
+		t.Error("BuildContext on a missing root should fail")
+	}
+}
+
+func conformPrompts(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	if _, err := b.AddPrompt(memory.AddPromptParams{SessionID: "s1", Content: "Add OAuth login <private>key</private>", Project: "proj", Namespace: "agent"}); err != nil {
+		t.Fatalf("AddPrompt: %v", err)
+	}
+	if _, err := b.AddPrompt(memory.AddPromptParams{SessionID: "s1", Content: "Fix the flaky test", Project: "other"}); err != nil {
+		t.Fatalf("AddPrompt: %v", err)
+	}
+
+	all, err := b.RecentPrompts("", 10)
+	if err != nil || len(all) != 2 {
+		t.Fatalf("RecentPrompts = %d, %v", len(all), err)
+	}
+	proj, _ := b.RecentPrompts("proj", 10)
+	if len(proj) != 1 || proj[0].Namespace != "agent" || strings.Contains(proj[0].Content, "key") {
+		t.Errorf("RecentPrompts(proj) = %+v", proj)
+	}
+
+	found, err := b.SearchPrompts("oauth", "", 10)
+	if err != nil || len(found) != 1 || found[0].Project != "proj" {
+		t.Errorf("SearchPrompts(oauth) = %+v, %v", found, err)
+	}
+	if found, _ := b.SearchPrompts("flaky", "proj", 10); len(found) != 0 {
+		t.Errorf("SearchPrompts project filter leaked %+v", found)
+	}
+}
+
+func conformTimeline(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	mustSession(t, b, "s2", "proj")
+	var ids []int64
+	for i := 0; i < 5; i++ {
+		ids = append(ids, mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: fmt.Sprintf("step %d", i), Content: fmt.Sprintf("content %d", i)}))
+	}
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s2", Title: "elsewhere", Content: "other session"})
+
+	tl, err := b.Timeline(ids[2], 1, 5)
+	if err != nil {
+		t.Fatalf("Timeline: %v", err)
+	}
+	if tl.Focus.ID != ids[2] || tl.SessionInfo == nil || tl.SessionInfo.ID != "s1" || tl.TotalInRange != 5 {
+		t.Errorf("timeline = %+v", tl)
+	}
+	if len(tl.Before) != 1 || tl.Before[0].ID != ids[1] {
+		t.Errorf("Before = %+v, want [#%d]", tl.Before, ids[1])
+	}
+	if len(tl.After) != 2 || tl.After[0].ID != ids[3] || tl.After[1].ID != ids[4] {
+		t.Errorf("After = %+v, want #%d, #%d", tl.After, ids[3], ids[4])
+	}
+
+	if _, err := b.Timeline(9999, 1, 1); err == nil {
+		t.Error("Timeline on a missing observation should fail")
+	}
+}
+
+func conformCompaction(t *testing.T, b memory.Backend, target conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	old1 := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "old one", Content: "1", Project: "proj"})
+	old2 := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "old two", Content: "2", Project: "proj"})
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "fresh", Content: "3", Project: "proj"})
+	target.age(t, b, old1, 60)
+	target.age(t, b, old2, 45)
+
+	stale, err := b.FindStaleObservations("proj", "", "", 30, 10)
+	if err != nil {
+		t.Fatalf("FindStaleObservations: %v", err)
+	}
+	if len(stale) != 2 || stale[0].ID != old1 || stale[1].ID != old2 {
+		t.Errorf("stale = %v, want #%d then #%d (oldest first)", observationIDs(stale), old1, old2)
+	}
+	if _, err := b.FindStaleObservations("proj", "", "", 0, 10); err == nil {
+		t.Error("olderThanDays 0 should fail")
+	}
+
+	// The summary needs a session; without one nothing may change.
+	if _, err := b.CompactObservations(memory.CompactParams{
+		IDs: []int64{old1, old2}, SummaryTitle: "Summary", SummaryContent: "both", Project: "proj", SessionID: "ghost",
+	}); err == nil {
+		t.Fatal("compaction with an unknown summary session should fail")
+	}
+	if n, _ := b.CountObservations("proj", "", ""); n != 3 {
+		t.Fatalf("failed compaction changed data: %d observations, want 3", n)
+	}
+
+	res, err := b.CompactObservations(memory.CompactParams{
+		IDs: []int64{old1, old2, 9999}, SummaryTitle: "Summary", SummaryContent: "both", Project: "proj", SessionID: "s1",
+	})
+	if err != nil {
+		t.Fatalf("CompactObservations: %v", err)
+	}
+	if res.DeletedCount != 2 || res.TotalBefore != 3 || res.TotalAfter != 2 || res.SummaryID == nil {
+		t.Errorf("compact result = %+v", res)
+	}
+	summary := mustGet(t, b, *res.SummaryID)
+	if summary.Type != "compaction_summary" || summary.Title != "Summary" {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	for _, bad := range []memory.CompactParams{
+		{},
+		{IDs: []int64{1}, SummaryContent: "no title"},
+	} {
+		if _, err := b.CompactObservations(bad); err == nil {
+			t.Errorf("CompactObservations(%+v) should fail", bad)
+		}
+	}
+}
+
+func conformStatsAndContext(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	if ctx, err := b.FormatContextDetailed("", "", memory.ContextFormatOptions{}); err != nil || ctx != "" {
+		t.Errorf("empty FormatContextDetailed = %q, %v", ctx, err)
+	}
+
+	mustSession(t, b, "s1", "proj")
+	id := mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Type: "decision", Title: "Pick SQLite", Content: "embedded", Project: "proj"})
+	mustObs(t, b, memory.AddObservationParams{SessionID: "s1", Title: "Other", Content: "x", Project: "side"})
+	if _, err := b.AddPrompt(memory.AddPromptParams{SessionID: "s1", Content: "hello", Project: "proj"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := b.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.TotalSessions != 1 || stats.TotalObservations != 2 || stats.TotalPrompts != 1 || len(stats.Projects) != 2 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	standard, err := b.FormatContextDetailed("proj", "", memory.ContextFormatOptions{})
+	if err != nil {
+		t.Fatalf("FormatContextDetailed: %v", err)
+	}
+	if !strings.Contains(standard, "Pick SQLite") || strings.Contains(standard, "Other") {
+		t.Errorf("standard context:\n%s", standard)
+	}
+	summary, _ := b.FormatContextDetailed("proj", "", memory.ContextFormatOptions{DetailLevel: memory.DetailSummary})
+	if !strings.Contains(summary, fmt.Sprintf("#%d [decision] Pick SQLite", id)) {
+		t.Errorf("summary context:\n%s", summary)
+	}
+}
+
+func conformPassiveCapture(t *testing.T, b memory.Backend, _ conformanceTarget) {
+	mustSession(t, b, "s1", "proj")
+	text := "Done.\n\n## Key Learnings:\n1. FTS5 external content tables need rebuilds after drift\n2. Topic keys keep one observation per evolving subject\n"
+
+	res, err := b.PassiveCapture(memory.PassiveCaptureParams{SessionID: "s1", Content: text, Project: "proj", Source: "test"})
+	if err != nil {
+		t.Fatalf("PassiveCapture: %v", err)
+	}
+	if res.Extracted != 2 || res.Saved != 2 || res.Duplicates != 0 {
+		t.Errorf("first capture = %+v", res)
+	}
+
+	res, err = b.PassiveCapture(memory.PassiveCaptureParams{SessionID: "s1", Content: text, Project: "proj"})
+	if err != nil {
+		t.Fatalf("PassiveCapture: %v", err)
+	}
+	if res.Saved != 0 || res.Duplicates != 2 {
+		t.Errorf("second capture = %+v", res)
+	}
+	if n, _ := b.CountObservations("proj", "", ""); n != 2 {
+		t.Errorf("CountObservations = %d, want 2", n)
+	}
+}
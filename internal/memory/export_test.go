@@ -1,9 +1,22 @@
 package memory
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+)
 
 // DB exposes the internal *sql.DB for test helpers in memory_test.
 // This file only compiles during `go test`.
 func (s *Store) DB() *sql.DB {
 	return s.db
 }
+
+// Backdate moves an observation's created_at into the past, like
+// ageObservation does for Store through DB().
+func (m *MemoryBackend) Backdate(id int64, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if o, ok := m.observations[id]; ok {
+		o.CreatedAt = m.stampBefore(d)
+	}
+}
@@ -0,0 +1,152 @@
+// health.go exposes low-level database diagnostics used by `hoofy doctor`.
+//
+// These checks look below the Store API: journal mode, SQLite's own
+// integrity check, and whether the external-content FTS5 indexes still
+// match the tables they index. A drifted FTS index does not lose data,
+// but searches silently miss (or return stale) observations.
+package memory
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DBFileName is the SQLite database filename inside Config.DataDir.
+const DBFileName = "memory.db"
+
+// DBPath returns the database location for a configuration.
+func DBPath(cfg Config) string {
+	return filepath.Join(cfg.DataDir, DBFileName)
+}
+
+// FTSHealth describes one full-text index.
+type FTSHealth struct {
+	Table        string `json:"table"`
+	ContentTable string `json:"content_table"`
+	Rows         int    `json:"rows"`
+	InSync       bool   `json:"in_sync"`
+	Detail       string `json:"detail,omitempty"`
+}
+
+// Health is a snapshot of the database's physical state.
+type Health struct {
+	Path        string `json:"path"`
+	JournalMode string `json:"journal_mode"`
+	// WALBytes is the size of the -wal file (0 if absent).
+	WALBytes int64 `json:"wal_bytes"`
+	// Integrity holds the rows of PRAGMA integrity_check; a healthy
+	// database returns exactly ["ok"].
+	Integrity []string    `json:"integrity"`
+	FTS       []FTSHealth `json:"fts"`
+}
+
+// IntegrityOK reports whether PRAGMA integrity_check found no problems.
+func (h *Health) IntegrityOK() bool {
+	return len(h.Integrity) == 1 && h.Integrity[0] == "ok"
+}
+
+// ftsIndexes lists every FTS5 index with the table it mirrors.
+var ftsIndexes = []struct{ fts, content string }{
+	{"observations_fts", "observations"},
+	{"prompts_fts", "user_prompts"},
+}
+
+// Health inspects the database. Errors are returned only when a check
+// cannot run at all; problems it finds are reported in the result.
+func (s *Store) Health() (*Health, error) {
+	return s.health(DBPath(s.cfg), s.db, s.db)
+}
+
+// Inspect runs the Health checks on the database at path without opening
+// a Store: nothing is created, no pragma is set, and no migration runs,
+// so it is safe on a database another process is using. Reads go
+// through a read-only connection. FTS5's integrity-check command is an
+// INSERT, which SQLite refuses on a read-only connection, so it runs on
+// a read-write one inside a transaction that is rolled back.
+func Inspect(path string) (*Health, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("memory: %w", err)
+	}
+
+	ro, err := openDB("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("memory: open database: %w", err)
+	}
+	defer func() { _ = ro.Close() }()
+
+	// mode=rw never creates the file; busy_timeout only applies to
+	// this connection.
+	rw, err := openDB("sqlite", "file:"+path+"?mode=rw&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("memory: open database: %w", err)
+	}
+	defer func() { _ = rw.Close() }()
+	tx, err := rw.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("memory: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	s := &Store{db: ro, hooks: defaultStoreHooks()}
+	return s.health(path, ro, tx)
+}
+
+// health runs the checks: reads on db, FTS5 integrity-check commands on fts.
+func (s *Store) health(path string, db *sql.DB, fts execer) (*Health, error) {
+	h := &Health{Path: path}
+
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&h.JournalMode); err != nil {
+		return nil, fmt.Errorf("memory: journal mode: %w", err)
+	}
+	if info, err := os.Stat(h.Path + "-wal"); err == nil {
+		h.WALBytes = info.Size()
+	}
+
+	rows, err := s.queryItHook(db, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("memory: integrity check: %w", err)
+	}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("memory: integrity check: %w", err)
+		}
+		h.Integrity = append(h.Integrity, line)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("memory: integrity check: %w", err)
+	}
+	_ = rows.Close()
+
+	for _, idx := range ftsIndexes {
+		fh := FTSHealth{Table: idx.fts, ContentTable: idx.content}
+		if err := db.QueryRow("SELECT COUNT(*) FROM " + idx.content).Scan(&fh.Rows); err != nil {
+			return nil, fmt.Errorf("memory: count %s: %w", idx.content, err)
+		}
+		// rank=1 makes FTS5 compare the index against the content
+		// table, not just check its own structure.
+		_, err := s.execHook(fts, fmt.Sprintf("INSERT INTO %s(%s, rank) VALUES('integrity-check', 1)", idx.fts, idx.fts))
+		fh.InSync = err == nil
+		if err != nil {
+			fh.Detail = err.Error()
+		}
+		h.FTS = append(h.FTS, fh)
+	}
+
+	return h, nil
+}
+
+// RebuildFTS regenerates every full-text index from its content table.
+// Safe to run at any time; it is what `hoofy doctor --fix` does.
+func (s *Store) RebuildFTS() error {
+	for _, idx := range ftsIndexes {
+		if _, err := s.execHook(s.db, fmt.Sprintf("INSERT INTO %s(%s) VALUES('rebuild')", idx.fts, idx.fts)); err != nil {
+			return fmt.Errorf("memory: rebuild %s: %w", idx.fts, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/memory"
+)
+
+func TestHealth_HealthyDatabase(t *testing.T) {
+	s := newTestStore(t)
+	ensureSession(t, s, "s1", "proj")
+	if _, err := s.AddObservation(memory.AddObservationParams{
+		SessionID: "s1", Type: "decision", Title: "Use WAL", Content: "WAL mode for concurrency", Project: "proj",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddPrompt(memory.AddPromptParams{SessionID: "s1", Content: "enable wal", Project: "proj"}); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := s.Health()
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if h.JournalMode != "wal" {
+		t.Errorf("JournalMode = %q, want wal", h.JournalMode)
+	}
+	if !h.IntegrityOK() {
+		t.Errorf("Integrity = %v, want [ok]", h.Integrity)
+	}
+	if len(h.FTS) != 2 {
+		t.Fatalf("FTS entries = %d, want 2", len(h.FTS))
+	}
+	for _, f := range h.FTS {
+		if !f.InSync {
+			t.Errorf("%s out of sync: %s", f.Table, f.Detail)
+		}
+		if f.Rows != 1 {
+			t.Errorf("%s rows = %d, want 1", f.ContentTable, f.Rows)
+		}
+	}
+}
+
+func TestHealth_DetectsAndRebuildsDriftedFTS(t *testing.T) {
+	s := newTestStore(t)
+	ensureSession(t, s, "s1", "proj")
+	id, err := s.AddObservation(memory.AddObservationParams{
+		SessionID: "s1", Type: "bugfix", Title: "Fix crash", Content: "nil map on startup", Project: "proj",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the row from the index only, as a crashed writer might.
+	if _, err := s.DB().Exec(`INSERT INTO observations_fts(observations_fts, rowid, title, content, tool_name, type, project)
+		SELECT 'delete', id, title, content, tool_name, type, project FROM observations WHERE id = ?`, id); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := s.Health()
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if h.FTS[0].Table != "observations_fts" || h.FTS[0].InSync {
+		t.Fatalf("expected observations_fts out of sync, got %+v", h.FTS[0])
+	}
+	if !h.FTS[1].InSync {
+		t.Errorf("prompts_fts should still be in sync: %s", h.FTS[1].Detail)
+	}
+
+	if err := s.RebuildFTS(); err != nil {
+		t.Fatalf("RebuildFTS: %v", err)
+	}
+	h, err = s.Health()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.FTS[0].InSync {
+		t.Errorf("observations_fts still out of sync after rebuild: %s", h.FTS[0].Detail)
+	}
+	results, err := s.Search("crash", memory.SearchOptions{Project: "proj"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Search after rebuild = %d results, want 1", len(results))
+	}
+}
@@ -413,8 +413,8 @@ func TestIntegration_ExportImportPreservesData(t *testing.T) {
 		t.Fatalf("Export: %v", err)
 	}
 
-	if exported.Version != "0.1.0" {
-		t.Errorf("version = %q, want '0.1.0'", exported.Version)
+	if exported.Version != memory.ExportVersion {
+		t.Errorf("version = %q, want %q", exported.Version, memory.ExportVersion)
 	}
 	if len(exported.Sessions) != 1 {
 		t.Errorf("sessions = %d, want 1", len(exported.Sessions))
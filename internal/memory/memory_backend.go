@@ -0,0 +1,971 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// MemoryBackend is a Backend that keeps everything in process. It has
+// the same semantics as Store — topic_key upserts, the dedupe window,
+// soft deletes, relation cascade on hard delete, and sessions that must
+// exist before observations or prompts reference them — but nothing
+// survives Close.
+//
+// Full-text search approximates FTS5: every query word must appear as
+// a token sequence in one of the indexed fields, and more occurrences
+// rank better. Useful as a test double and for embedding Hoofy's memory
+// tools without SQLite.
+type MemoryBackend struct {
+	mu  sync.Mutex
+	cfg Config
+	now func() time.Time
+
+	sessions     map[string]*Session
+	observations map[int64]*memObservation
+	prompts      []Prompt
+	relations    map[int64]*Relation
+
+	lastObservationID int64
+	lastPromptID      int64
+	lastRelationID    int64
+}
+
+// memObservation is a stored observation plus its dedupe hash.
+type memObservation struct {
+	Observation
+	normHash string
+}
+
+// NewMemoryBackend creates an empty in-memory backend. Zero limits in
+// cfg fall back to DefaultConfig; DataDir is ignored.
+func NewMemoryBackend(cfg Config) *MemoryBackend {
+	def := DefaultConfig()
+	if cfg.MaxObservationLength <= 0 {
+		cfg.MaxObservationLength = def.MaxObservationLength
+	}
+	if cfg.MaxContextResults <= 0 {
+		cfg.MaxContextResults = def.MaxContextResults
+	}
+	if cfg.MaxSearchResults <= 0 {
+		cfg.MaxSearchResults = def.MaxSearchResults
+	}
+	if cfg.DedupeWindow <= 0 {
+		cfg.DedupeWindow = def.DedupeWindow
+	}
+	return &MemoryBackend{
+		cfg:          cfg,
+		now:          time.Now,
+		sessions:     make(map[string]*Session),
+		observations: make(map[int64]*memObservation),
+		relations:    make(map[int64]*Relation),
+	}
+}
+
+// Close is a no-op; it exists to satisfy Backend.
+func (m *MemoryBackend) Close() error { return nil }
+
+// stamp returns the current time in the same format SQLite's
+// datetime('now') produces, so timestamps sort and compare alike.
+func (m *MemoryBackend) stamp() string {
+	return m.now().UTC().Format("2006-01-02 15:04:05")
+}
+
+func (m *MemoryBackend) stampBefore(d time.Duration) string {
+	return m.now().Add(-d).UTC().Format("2006-01-02 15:04:05")
+}
+
+func (m *MemoryBackend) requireSession(id string) error {
+	if _, ok := m.sessions[id]; !ok {
+		return fmt.Errorf("session %q does not exist", id)
+	}
+	return nil
+}
+
+// ─── Sessions ────────────────────────────────────────────────────────────────
+
+// CreateSession registers a new coding session. Existing IDs are left untouched.
+func (m *MemoryBackend) CreateSession(id, project, directory string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		m.sessions[id] = &Session{ID: id, Project: project, Directory: directory, StartedAt: m.stamp()}
+	}
+	return nil
+}
+
+// EndSession marks a session as completed with an optional summary.
+func (m *MemoryBackend) EndSession(id string, summary string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if sess, ok := m.sessions[id]; ok {
+		ended := m.stamp()
+		sess.EndedAt = &ended
+		sess.Summary = nullableString(summary)
+	}
+	return nil
+}
+
+// GetSession retrieves a session by ID.
+func (m *MemoryBackend) GetSession(id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sess, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *sess
+	return &cp, nil
+}
+
+// RecentSessions returns recent sessions with observation counts,
+// most recently active first.
+func (m *MemoryBackend) RecentSessions(project string, limit int) ([]SessionSummary, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type ranked struct {
+		summary    SessionSummary
+		lastActive string
+	}
+	var all []ranked
+	for _, sess := range m.sessions {
+		if project != "" && sess.Project != project {
+			continue
+		}
+		r := ranked{
+			summary: SessionSummary{
+				ID: sess.ID, Project: sess.Project, StartedAt: sess.StartedAt,
+				EndedAt: sess.EndedAt, Summary: sess.Summary,
+			},
+			lastActive: sess.StartedAt,
+		}
+		for _, o := range m.observations {
+			if o.SessionID != sess.ID || o.DeletedAt != nil {
+				continue
+			}
+			r.summary.ObservationCount++
+			if o.CreatedAt > r.lastActive {
+				r.lastActive = o.CreatedAt
+			}
+		}
+		all = append(all, r)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].lastActive != all[j].lastActive {
+			return all[i].lastActive > all[j].lastActive
+		}
+		return all[i].summary.ID > all[j].summary.ID
+	})
+
+	var results []SessionSummary
+	for i := 0; i < len(all) && i < limit; i++ {
+		results = append(results, all[i].summary)
+	}
+	return results, nil
+}
+
+// ─── Observations ────────────────────────────────────────────────────────────
+
+// AddObservation creates a new observation with topic_key upsert and deduplication.
+func (m *MemoryBackend) AddObservation(p AddObservationParams) (int64, error) {
+	title := stripPrivateTags(p.Title)
+	content := stripPrivateTags(p.Content)
+	if len(content) > m.cfg.MaxObservationLength {
+		content = content[:m.cfg.MaxObservationLength] + "... [truncated]"
+	}
+	scope := normalizeScope(p.Scope)
+	normHash := hashNormalized(content)
+	topicKey := normalizeTopicKey(p.TopicKey)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.stamp()
+
+	sameBucket := func(o *memObservation) bool {
+		return o.DeletedAt == nil &&
+			derefString(o.Project) == p.Project &&
+			o.Scope == scope &&
+			derefString(o.Namespace) == p.Namespace
+	}
+
+	// Topic key upsert: if topic_key matches, update the existing observation
+	if topicKey != "" {
+		var existing *memObservation
+		for _, o := range m.observations {
+			if !sameBucket(o) || derefString(o.TopicKey) != topicKey {
+				continue
+			}
+			if existing == nil || o.UpdatedAt > existing.UpdatedAt ||
+				(o.UpdatedAt == existing.UpdatedAt && o.CreatedAt > existing.CreatedAt) {
+				existing = o
+			}
+		}
+		if existing != nil {
+			existing.Type = p.Type
+			existing.Title = title
+			existing.Content = content
+			existing.ToolName = nullableString(p.ToolName)
+			existing.TopicKey = nullableString(topicKey)
+			existing.normHash = normHash
+			existing.RevisionCount++
+			existing.LastSeenAt = &now
+			existing.UpdatedAt = now
+			return existing.ID, nil
+		}
+	}
+
+	// Deduplication: same content hash within the dedup window
+	cutoff := m.stampBefore(time.Duration(dedupeWindowMinutes(m.cfg.DedupeWindow)) * time.Minute)
+	var dup *memObservation
+	for _, o := range m.observations {
+		if !sameBucket(o) || o.normHash != normHash || o.Type != p.Type || o.Title != title || o.CreatedAt < cutoff {
+			continue
+		}
+		if dup == nil || o.CreatedAt > dup.CreatedAt {
+			dup = o
+		}
+	}
+	if dup != nil {
+		dup.DuplicateCount++
+		dup.LastSeenAt = &now
+		dup.UpdatedAt = now
+		return dup.ID, nil
+	}
+
+	if err := m.requireSession(p.SessionID); err != nil {
+		return 0, err
+	}
+	m.lastObservationID++
+	o := &memObservation{
+		Observation: Observation{
+			ID:             m.lastObservationID,
+			SessionID:      p.SessionID,
+			Type:           p.Type,
+			Title:          title,
+			Content:        content,
+			ToolName:       nullableString(p.ToolName),
+			Project:        nullableString(p.Project),
+			Scope:          scope,
+			TopicKey:       nullableString(topicKey),
+			Namespace:      nullableString(p.Namespace),
+			RevisionCount:  1,
+			DuplicateCount: 1,
+			LastSeenAt:     &now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		},
+		normHash: normHash,
+	}
+	m.observations[o.ID] = o
+	return o.ID, nil
+}
+
+// live returns non-deleted observations matching the filters, newest first.
+// Empty filters match everything; a project filter never matches NULL projects.
+func (m *MemoryBackend) live(project, scope, namespace string) []*memObservation {
+	if scope != "" {
+		scope = normalizeScope(scope)
+	}
+	var out []*memObservation
+	for _, o := range m.observations {
+		if o.DeletedAt != nil ||
+			(project != "" && derefString(o.Project) != project) ||
+			(scope != "" && o.Scope != scope) ||
+			(namespace != "" && derefString(o.Namespace) != namespace) {
+			continue
+		}
+		out = append(out, o)
+	}
+	sortNewestFirst(out)
+	return out
+}
+
+func sortNewestFirst(obs []*memObservation) {
+	sort.Slice(obs, func(i, j int) bool {
+		if obs[i].CreatedAt != obs[j].CreatedAt {
+			return obs[i].CreatedAt > obs[j].CreatedAt
+		}
+		return obs[i].ID > obs[j].ID
+	})
+}
+
+func copyObservations(obs []*memObservation, limit int) []Observation {
+	var out []Observation
+	for i := 0; i < len(obs) && i < limit; i++ {
+		out = append(out, obs[i].Observation)
+	}
+	return out
+}
+
+// RecentObservations returns recent observations filtered by project, scope, and namespace.
+func (m *MemoryBackend) RecentObservations(project, scope, namespace string, limit int) ([]Observation, error) {
+	if limit <= 0 {
+		limit = m.cfg.MaxContextResults
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return copyObservations(m.live(project, scope, namespace), limit), nil
+}
+
+// CountObservations returns the number of non-deleted observations matching the filters.
+func (m *MemoryBackend) CountObservations(project, scope, namespace string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.live(project, scope, namespace)), nil
+}
+
+// GetObservation retrieves a single observation by ID (excludes soft-deleted).
+func (m *MemoryBackend) GetObservation(id int64) (*Observation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.observations[id]
+	if !ok || o.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	cp := o.Observation
+	return &cp, nil
+}
+
+// FindByTopicKey returns the latest non-deleted observation matching the
+// given topic_key, project, and scope, or nil when there is none.
+func (m *MemoryBackend) FindByTopicKey(topicKey, project, scope string) (*Observation, error) {
+	if topicKey == "" {
+		return nil, nil
+	}
+	scope = normalizeScope(scope)
+	topicKey = normalizeTopicKey(topicKey)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var found *memObservation
+	for _, o := range m.observations {
+		if o.DeletedAt != nil || derefString(o.TopicKey) != topicKey ||
+			derefString(o.Project) != project || o.Scope != scope {
+			continue
+		}
+		if found == nil || o.UpdatedAt > found.UpdatedAt ||
+			(o.UpdatedAt == found.UpdatedAt && o.CreatedAt > found.CreatedAt) {
+			found = o
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	cp := found.Observation
+	return &cp, nil
+}
+
+// UpdateObservation partially updates an observation by ID.
+func (m *MemoryBackend) UpdateObservation(id int64, p UpdateObservationParams) (*Observation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.observations[id]
+	if !ok || o.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+
+	if p.Type != nil {
+		o.Type = *p.Type
+	}
+	if p.Title != nil {
+		o.Title = stripPrivateTags(*p.Title)
+	}
+	if p.Content != nil {
+		content := stripPrivateTags(*p.Content)
+		if len(content) > m.cfg.MaxObservationLength {
+			content = content[:m.cfg.MaxObservationLength] + "... [truncated]"
+		}
+		o.Content = content
+	}
+	if p.Project != nil {
+		o.Project = nullableString(*p.Project)
+	}
+	if p.Scope != nil {
+		o.Scope = normalizeScope(*p.Scope)
+	}
+	if p.TopicKey != nil {
+		o.TopicKey = nullableString(normalizeTopicKey(*p.TopicKey))
+	}
+	o.normHash = hashNormalized(o.Content)
+	o.RevisionCount++
+	o.UpdatedAt = m.stamp()
+
+	cp := o.Observation
+	return &cp, nil
+}
+
+// DeleteObservation soft-deletes (or hard-deletes) an observation by ID.
+// A hard delete also removes the observation's relations.
+func (m *MemoryBackend) DeleteObservation(id int64, hardDelete bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, ok := m.observations[id]
+	if !ok {
+		return nil
+	}
+	if hardDelete {
+		delete(m.observations, id)
+		for relID, r := range m.relations {
+			if r.FromID == id || r.ToID == id {
+				delete(m.relations, relID)
+			}
+		}
+		return nil
+	}
+	m.softDelete(o)
+	return nil
+}
+
+// softDelete marks o deleted and reports whether it was live.
+func (m *MemoryBackend) softDelete(o *memObservation) bool {
+	if o.DeletedAt != nil {
+		return false
+	}
+	now := m.stamp()
+	o.DeletedAt = &now
+	o.UpdatedAt = now
+	return true
+}
+
+// Timeline provides chronological context around a specific observation.
+func (m *MemoryBackend) Timeline(observationID int64, before, after int) (*TimelineResult, error) {
+	if before <= 0 {
+		before = 5
+	}
+	if after <= 0 {
+		after = 5
+	}
+
+	focus, err := m.GetObservation(observationID)
+	if err != nil {
+		return nil, fmt.Errorf("timeline: observation #%d not found: %w", observationID, err)
+	}
+	session, _ := m.GetSession(focus.SessionID)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var inSession []*memObservation
+	for _, o := range m.observations {
+		if o.SessionID == focus.SessionID && o.DeletedAt == nil {
+			inSession = append(inSession, o)
+		}
+	}
+	sort.Slice(inSession, func(i, j int) bool { return inSession[i].ID < inSession[j].ID })
+
+	result := &TimelineResult{Focus: *focus, SessionInfo: session, TotalInRange: len(inSession)}
+	var earlier []*memObservation
+	for _, o := range inSession {
+		switch {
+		case o.ID < observationID:
+			earlier = append(earlier, o)
+		case o.ID > observationID && len(result.After) < after:
+			result.After = append(result.After, timelineEntry(o.Observation))
+		}
+	}
+	for _, o := range earlier[maxInt(0, len(earlier)-before):] {
+		result.Before = append(result.Before, timelineEntry(o.Observation))
+	}
+	return result, nil
+}
+
+func timelineEntry(o Observation) TimelineEntry {
+	return TimelineEntry{
+		ID: o.ID, SessionID: o.SessionID, Type: o.Type, Title: o.Title, Content: o.Content,
+		ToolName: o.ToolName, Project: o.Project, Scope: o.Scope, TopicKey: o.TopicKey,
+		Namespace: o.Namespace, RevisionCount: o.RevisionCount, DuplicateCount: o.DuplicateCount,
+		LastSeenAt: o.LastSeenAt, CreatedAt: o.CreatedAt, UpdatedAt: o.UpdatedAt, DeletedAt: o.DeletedAt,
+	}
+}
+
+// ─── Compaction ──────────────────────────────────────────────────────────────
+
+// FindStaleObservations returns non-deleted observations older than the
+// given number of days, oldest first.
+func (m *MemoryBackend) FindStaleObservations(project, scope, namespace string, olderThanDays, limit int) ([]Observation, error) {
+	if olderThanDays <= 0 {
+		return nil, fmt.Errorf("olderThanDays must be > 0, got %d", olderThanDays)
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cutoff := m.stampBefore(time.Duration(olderThanDays) * 24 * time.Hour)
+	var stale []*memObservation
+	for _, o := range m.live(project, scope, namespace) {
+		if o.CreatedAt < cutoff {
+			stale = append(stale, o)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool { return stale[i].CreatedAt < stale[j].CreatedAt })
+	return copyObservations(stale, limit), nil
+}
+
+// CompactObservations soft-deletes the given IDs and optionally creates
+// a summary observation. Nothing changes if the summary can't be created.
+func (m *MemoryBackend) CompactObservations(p CompactParams) (*CompactResult, error) {
+	if len(p.IDs) == 0 {
+		return nil, fmt.Errorf("no observation IDs provided")
+	}
+	if len(p.IDs) > 200 {
+		return nil, fmt.Errorf("cannot compact more than 200 observations at once, got %d", len(p.IDs))
+	}
+	if p.SummaryContent != "" && p.SummaryTitle == "" {
+		return nil, fmt.Errorf("summary_content requires summary_title")
+	}
+
+	scope := normalizeScope(p.Scope)
+	sessionID := p.SessionID
+	if sessionID == "" {
+		sessionID = "manual-save"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := &CompactResult{TotalBefore: len(m.live(p.Project, scope, ""))}
+	if p.SummaryTitle != "" {
+		if err := m.requireSession(sessionID); err != nil {
+			return nil, fmt.Errorf("creating compaction summary: %w", err)
+		}
+	}
+
+	for _, id := range p.IDs {
+		if o, ok := m.observations[id]; ok && m.softDelete(o) {
+			result.DeletedCount++
+		}
+	}
+
+	if p.SummaryTitle != "" {
+		now := m.stamp()
+		m.lastObservationID++
+		summary := &memObservation{
+			Observation: Observation{
+				ID:             m.lastObservationID,
+				SessionID:      sessionID,
+				Type:           "compaction_summary",
+				Title:          p.SummaryTitle,
+				Content:        p.SummaryContent,
+				Project:        nullableString(p.Project),
+				Scope:          scope,
+				RevisionCount:  1,
+				DuplicateCount: 1,
+				LastSeenAt:     &now,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			},
+			normHash: hashNormalized(p.SummaryContent),
+		}
+		m.observations[summary.ID] = summary
+		result.SummaryID = &summary.ID
+	}
+
+	result.TotalAfter = len(m.live(p.Project, scope, ""))
+	return result, nil
+}
+
+// ─── Relations ───────────────────────────────────────────────────────────────
+
+// AddRelation creates a typed directional edge between two observations.
+// If Bidirectional is true, both directions are created or neither is.
+func (m *MemoryBackend) AddRelation(p AddRelationParams) ([]int64, error) {
+	if p.FromID == p.ToID {
+		return nil, fmt.Errorf("cannot create self-relation: from_id and to_id are both %d", p.FromID)
+	}
+	if p.Type == "" {
+		p.Type = "relates_to"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range []int64{p.FromID, p.ToID} {
+		if o, ok := m.observations[id]; !ok || o.DeletedAt != nil {
+			return nil, fmt.Errorf("observation %d not found or is deleted", id)
+		}
+	}
+	if m.hasRelation(p.FromID, p.ToID, p.Type) {
+		return nil, fmt.Errorf("relation already exists: %d → %d (%s)", p.FromID, p.ToID, p.Type)
+	}
+	if p.Bidirectional && m.hasRelation(p.ToID, p.FromID, p.Type) {
+		return nil, fmt.Errorf("reverse relation already exists: %d → %d (%s)", p.ToID, p.FromID, p.Type)
+	}
+
+	ids := []int64{m.insertRelation(p.FromID, p.ToID, p.Type, p.Note)}
+	if p.Bidirectional {
+		ids = append(ids, m.insertRelation(p.ToID, p.FromID, p.Type, p.Note))
+	}
+	return ids, nil
+}
+
+func (m *MemoryBackend) hasRelation(from, to int64, typ string) bool {
+	for _, r := range m.relations {
+		if r.FromID == from && r.ToID == to && r.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryBackend) insertRelation(from, to int64, typ, note string) int64 {
+	m.lastRelationID++
+	m.relations[m.lastRelationID] = &Relation{
+		ID: m.lastRelationID, FromID: from, ToID: to, Type: typ, Note: note, CreatedAt: m.stamp(),
+	}
+	return m.lastRelationID
+}
+
+// RemoveRelation deletes a relation by its ID.
+func (m *MemoryBackend) RemoveRelation(id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.relations[id]; !ok {
+		return fmt.Errorf("relation %d not found", id)
+	}
+	delete(m.relations, id)
+	return nil
+}
+
+// GetRelations returns all relations where the observation is either source or target.
+func (m *MemoryBackend) GetRelations(observationID int64) ([]Relation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.relationsOf(observationID), nil
+}
+
+func (m *MemoryBackend) relationsOf(observationID int64) []Relation {
+	var out []Relation
+	for _, r := range m.relations {
+		if r.FromID == observationID || r.ToID == observationID {
+			out = append(out, *r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt != out[j].CreatedAt {
+			return out[i].CreatedAt < out[j].CreatedAt
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// BuildContext traverses the relation graph from a starting observation
+// breadth-first. Default depth is 2, max is 5.
+func (m *MemoryBackend) BuildContext(observationID int64, maxDepth int) (*ContextResult, error) {
+	if maxDepth <= 0 {
+		maxDepth = 2
+	}
+	if maxDepth > 5 {
+		maxDepth = 5
+	}
+
+	root, err := m.GetObservation(observationID)
+	if err != nil {
+		return nil, fmt.Errorf("root observation %d not found: %w", observationID, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type queueItem struct {
+		id    int64
+		depth int
+	}
+	visited := map[int64]bool{observationID: true}
+	queue := []queueItem{{id: observationID, depth: 0}}
+	result := &ContextResult{Root: *root}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current.depth >= maxDepth {
+			continue
+		}
+
+		for _, rel := range m.relationsOf(current.id) {
+			otherID := rel.ToID
+			direction := "outgoing"
+			if rel.ToID == current.id {
+				otherID = rel.FromID
+				direction = "incoming"
+			}
+			if visited[otherID] {
+				continue
+			}
+			visited[otherID] = true
+
+			// Soft-deleted neighbours are still shown, as in Store.
+			other, ok := m.observations[otherID]
+			if !ok {
+				continue
+			}
+			depth := current.depth + 1
+			result.Connected = append(result.Connected, ContextNode{
+				ID:           other.ID,
+				Title:        other.Title,
+				Type:         other.Type,
+				Project:      derefString(other.Project),
+				CreatedAt:    other.CreatedAt,
+				RelationType: rel.Type,
+				Direction:    direction,
+				Note:         rel.Note,
+				Depth:        depth,
+			})
+			result.MaxDepth = maxInt(result.MaxDepth, depth)
+			queue = append(queue, queueItem{id: otherID, depth: depth})
+		}
+	}
+	result.TotalNodes = len(result.Connected)
+	return result, nil
+}
+
+// ─── User Prompts ────────────────────────────────────────────────────────────
+
+// AddPrompt saves a user prompt.
+func (m *MemoryBackend) AddPrompt(p AddPromptParams) (int64, error) {
+	content := stripPrivateTags(p.Content)
+	if len(content) > m.cfg.MaxObservationLength {
+		content = content[:m.cfg.MaxObservationLength] + "... [truncated]"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.requireSession(p.SessionID); err != nil {
+		return 0, err
+	}
+	m.lastPromptID++
+	m.prompts = append(m.prompts, Prompt{
+		ID: m.lastPromptID, SessionID: p.SessionID, Content: content,
+		Project: p.Project, Namespace: p.Namespace, CreatedAt: m.stamp(),
+	})
+	return m.lastPromptID, nil
+}
+
+// RecentPrompts returns recent user prompts filtered by project.
+func (m *MemoryBackend) RecentPrompts(project string, limit int) ([]Prompt, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var results []Prompt
+	for i := len(m.prompts) - 1; i >= 0 && len(results) < limit; i-- {
+		if project == "" || m.prompts[i].Project == project {
+			results = append(results, m.prompts[i])
+		}
+	}
+	return results, nil
+}
+
+// SearchPrompts searches user prompts by content and project.
+func (m *MemoryBackend) SearchPrompts(query string, project string, limit int) ([]Prompt, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	phrases := ftsPhrases(query)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type hit struct {
+		prompt Prompt
+		rank   float64
+	}
+	var hits []hit
+	for i := len(m.prompts) - 1; i >= 0; i-- {
+		p := m.prompts[i]
+		if project != "" && p.Project != project {
+			continue
+		}
+		if rank, ok := ftsRank(phrases, p.Content, p.Project); ok {
+			hits = append(hits, hit{p, rank})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].rank < hits[j].rank })
+
+	var results []Prompt
+	for i := 0; i < len(hits) && i < limit; i++ {
+		results = append(results, hits[i].prompt)
+	}
+	return results, nil
+}
+
+// ─── Search ──────────────────────────────────────────────────────────────────
+
+// Search performs full-text search across observations with filters.
+// If the query is empty or whitespace-only, falls back to returning recent observations.
+func (m *MemoryBackend) Search(query string, opts SearchOptions) ([]SearchResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > m.cfg.MaxSearchResults {
+		limit = m.cfg.MaxSearchResults
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	results := m.search(query, opts)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// CountSearchResults returns the number of observations Search would
+// match without a limit.
+func (m *MemoryBackend) CountSearchResults(query string, opts SearchOptions) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.search(query, opts)), nil
+}
+
+func (m *MemoryBackend) search(query string, opts SearchOptions) []SearchResult {
+	var results []SearchResult
+	candidates := m.live(opts.Project, opts.Scope, opts.Namespace)
+
+	if strings.TrimSpace(query) == "" {
+		for _, o := range candidates {
+			if opts.Type == "" || o.Type == opts.Type {
+				results = append(results, SearchResult{Observation: o.Observation})
+			}
+		}
+		return results
+	}
+
+	phrases := ftsPhrases(query)
+	for _, o := range candidates {
+		if opts.Type != "" && o.Type != opts.Type {
+			continue
+		}
+		rank, ok := ftsRank(phrases, o.Title, o.Content, derefString(o.ToolName), o.Type, derefString(o.Project))
+		if ok {
+			results = append(results, SearchResult{Observation: o.Observation, Rank: rank})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Rank < results[j].Rank })
+	return results
+}
+
+// ─── Stats / Context ─────────────────────────────────────────────────────────
+
+// Stats returns aggregate memory statistics.
+func (m *MemoryBackend) Stats() (*Stats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	live := m.live("", "", "")
+	stats := &Stats{
+		TotalSessions:     len(m.sessions),
+		TotalObservations: len(live),
+		TotalPrompts:      len(m.prompts),
+	}
+	// live is newest first, so first sight of a project is its latest activity.
+	seen := make(map[string]bool)
+	for _, o := range live {
+		if o.Project != nil && !seen[*o.Project] {
+			seen[*o.Project] = true
+			stats.Projects = append(stats.Projects, *o.Project)
+		}
+	}
+	return stats, nil
+}
+
+// FormatContextDetailed returns a markdown-formatted summary of recent memory.
+func (m *MemoryBackend) FormatContextDetailed(project, scope string, opts ContextFormatOptions) (string, error) {
+	return formatContextDetailed(m, m.cfg.MaxContextResults, project, scope, opts)
+}
+
+// PassiveCapture extracts learnings from text and saves them as observations.
+func (m *MemoryBackend) PassiveCapture(p PassiveCaptureParams) (*PassiveCaptureResult, error) {
+	return passiveCapture(m, p)
+}
+
+func (m *MemoryBackend) hasContentHash(normHash, project string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, o := range m.observations {
+		if o.DeletedAt == nil && o.normHash == normHash && derefString(o.Project) == project {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ─── Full-text matching ──────────────────────────────────────────────────────
+
+// ftsTokens splits text the way FTS5's unicode61 tokenizer does:
+// lowercase runs of letters and digits.
+func ftsTokens(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// ftsPhrases mirrors sanitizeFTS: every whitespace-separated word is a
+// quoted phrase, and all phrases must match.
+func ftsPhrases(query string) [][]string {
+	var phrases [][]string
+	for _, w := range strings.Fields(query) {
+		if tokens := ftsTokens(strings.Trim(w, `"`)); len(tokens) > 0 {
+			phrases = append(phrases, tokens)
+		}
+	}
+	return phrases
+}
+
+// ftsRank reports whether every phrase occurs in at least one field,
+// ranking by total occurrences (negated: lower is better, like bm25).
+func ftsRank(phrases [][]string, fields ...string) (float64, bool) {
+	if len(phrases) == 0 {
+		return 0, false
+	}
+	tokenized := make([][]string, len(fields))
+	for i, f := range fields {
+		tokenized[i] = ftsTokens(f)
+	}
+
+	total := 0
+	for _, phrase := range phrases {
+		n := 0
+		for _, tokens := range tokenized {
+			n += countPhrase(tokens, phrase)
+		}
+		if n == 0 {
+			return 0, false
+		}
+		total += n
+	}
+	return -float64(total), true
+}
+
+func countPhrase(tokens, phrase []string) int {
+	n := 0
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		match := true
+		for j, p := range phrase {
+			if tokens[i+j] != p {
+				match = false
+				break
+			}
+		}
+		if match {
+			n++
+		}
+	}
+	return n
+}
@@ -187,6 +187,7 @@ type Prompt struct {
 	SessionID string `json:"session_id"`
 	Content   string `json:"content"`
 	Project   string `json:"project,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
 	CreatedAt string `json:"created_at"`
 }
 
@@ -198,6 +199,20 @@ type AddPromptParams struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// ExportVersion is the current export format version.
+//
+// Format history:
+//   - 0.1.0: sessions, observations, prompts (no relations, prompt namespaces dropped)
+//   - 0.2.0: adds relations and prompt namespaces; observation IDs are
+//     remapped on import so relation edges survive a round trip
+const ExportVersion = "0.2.0"
+
+// supportedExportVersions lists the formats Import understands.
+var supportedExportVersions = map[string]bool{
+	"0.1.0": true,
+	"0.2.0": true,
+}
+
 // ExportData is the full serializable dump of the memory database.
 type ExportData struct {
 	Version      string        `json:"version"`
@@ -205,13 +220,39 @@ type ExportData struct {
 	Sessions     []Session     `json:"sessions"`
 	Observations []Observation `json:"observations"`
 	Prompts      []Prompt      `json:"prompts"`
+	Relations    []Relation    `json:"relations,omitempty"`
+}
+
+// ExportOptions holds filters for a partial export.
+// Empty fields mean "no filter"; the zero value exports everything.
+type ExportOptions struct {
+	Project   string `json:"project,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Since limits the export to records created at or after this
+	// timestamp (SQLite "YYYY-MM-DD HH:MM:SS" format, see ParseSince).
+	Since string `json:"since,omitempty"`
+}
+
+// ImportOptions controls how exported data is loaded.
+type ImportOptions struct {
+	// DryRun runs the import inside a transaction and rolls it back,
+	// so the result reports what WOULD be imported without writing.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // ImportResult holds counts of imported records.
 type ImportResult struct {
-	SessionsImported     int `json:"sessions_imported"`
-	ObservationsImported int `json:"observations_imported"`
-	PromptsImported      int `json:"prompts_imported"`
+	SessionsImported     int  `json:"sessions_imported"`
+	ObservationsImported int  `json:"observations_imported"`
+	PromptsImported      int  `json:"prompts_imported"`
+	RelationsImported    int  `json:"relations_imported"`
+	RelationsSkipped     int  `json:"relations_skipped,omitempty"`
+	DryRun               bool `json:"dry_run,omitempty"`
+	// IDMap maps each exported observation ID to the ID it received
+	// in this database. Useful for callers that hold references to
+	// exported IDs (e.g. mem_relate edges stored elsewhere).
+	IDMap map[int64]int64 `json:"id_map,omitempty"`
 }
 
 // PassiveCaptureParams holds the input for passive memory capture.
@@ -364,7 +405,7 @@ func New(cfg Config) (*Store, error) {
 		return nil, fmt.Errorf("memory: create data dir: %w", err)
 	}
 
-	dbPath := filepath.Join(cfg.DataDir, "memory.db")
+	dbPath := DBPath(cfg)
 	db, err := openDB("sqlite", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("memory: open database: %w", err)
@@ -1372,7 +1413,7 @@ func (s *Store) RecentPrompts(project string, limit int) ([]Prompt, error) {
 		limit = 20
 	}
 
-	query := `SELECT id, session_id, content, ifnull(project, '') as project, created_at FROM user_prompts`
+	query := `SELECT id, session_id, content, ifnull(project, '') as project, ifnull(namespace, '') as namespace, created_at FROM user_prompts`
 	args := []any{}
 
 	if project != "" {
@@ -1392,7 +1433,7 @@ func (s *Store) RecentPrompts(project string, limit int) ([]Prompt, error) {
 	var results []Prompt
 	for rows.Next() {
 		var p Prompt
-		if err := rows.Scan(&p.ID, &p.SessionID, &p.Content, &p.Project, &p.CreatedAt); err != nil {
+		if err := rows.Scan(&p.ID, &p.SessionID, &p.Content, &p.Project, &p.Namespace, &p.CreatedAt); err != nil {
 			return nil, err
 		}
 		results = append(results, p)
@@ -1409,7 +1450,7 @@ func (s *Store) SearchPrompts(query string, project string, limit int) ([]Prompt
 	ftsQuery := sanitizeFTS(query)
 
 	sqlStr := `
-		SELECT p.id, p.session_id, p.content, ifnull(p.project, '') as project, p.created_at
+		SELECT p.id, p.session_id, p.content, ifnull(p.project, '') as project, ifnull(p.namespace, '') as namespace, p.created_at
 		FROM prompts_fts fts
 		JOIN user_prompts p ON p.id = fts.rowid
 		WHERE prompts_fts MATCH ?
@@ -1433,7 +1474,7 @@ func (s *Store) SearchPrompts(query string, project string, limit int) ([]Prompt
 	var results []Prompt
 	for rows.Next() {
 		var p Prompt
-		if err := rows.Scan(&p.ID, &p.SessionID, &p.Content, &p.Project, &p.CreatedAt); err != nil {
+		if err := rows.Scan(&p.ID, &p.SessionID, &p.Content, &p.Project, &p.Namespace, &p.CreatedAt); err != nil {
 			return nil, err
 		}
 		results = append(results, p)
@@ -1789,187 +1830,141 @@ func (s *Store) FormatContext(project, scope string) (string, error) {
 // FormatContextDetailed returns a markdown-formatted summary of recent memory
 // with configurable verbosity (summary/standard/full) and observation limit.
 func (s *Store) FormatContextDetailed(project, scope string, opts ContextFormatOptions) (string, error) {
-	detail := ParseDetailLevel(opts.DetailLevel)
+	return formatContextDetailed(s, s.cfg.MaxContextResults, project, scope, opts)
+}
 
-	obsLimit := s.cfg.MaxContextResults
-	if opts.Limit > 0 {
-		obsLimit = opts.Limit
-	}
+// ─── Export / Import ─────────────────────────────────────────────────────────
 
-	sessions, err := s.RecentSessions(project, 5)
-	if err != nil {
-		return "", err
-	}
+// Export dumps the entire memory database as a serializable struct.
+func (s *Store) Export() (*ExportData, error) {
+	return s.ExportFiltered(ExportOptions{})
+}
 
-	observations, err := s.RecentObservations(project, scope, opts.Namespace, obsLimit)
-	if err != nil {
-		return "", err
+// ExportFiltered dumps the memory database restricted by the given filters.
+// Project and Since apply to observations, prompts and sessions; Scope
+// applies to observations only; Namespace applies to observations and
+// prompts. Sessions referenced by an exported observation or prompt are
+// always included so the dump can be imported without dangling references,
+// and only relations whose both endpoints were exported are included.
+func (s *Store) ExportFiltered(opts ExportOptions) (*ExportData, error) {
+	data := &ExportData{
+		Version:    ExportVersion,
+		ExportedAt: Now(),
 	}
 
-	prompts, err := s.RecentPrompts(project, 10)
-	if err != nil {
-		return "", err
+	// Observations
+	obsQuery := `SELECT id, session_id, type, title, content, tool_name, project,
+		        scope, topic_key, namespace, revision_count, duplicate_count, last_seen_at, created_at, updated_at, deleted_at
+		 FROM observations WHERE 1=1`
+	var obsArgs []any
+	if opts.Project != "" {
+		obsQuery += " AND project = ?"
+		obsArgs = append(obsArgs, opts.Project)
 	}
-
-	if len(sessions) == 0 && len(observations) == 0 && len(prompts) == 0 {
-		return "", nil
+	if opts.Scope != "" {
+		obsQuery += " AND scope = ?"
+		obsArgs = append(obsArgs, normalizeScope(opts.Scope))
 	}
-
-	var result string
-	switch detail {
-	case DetailSummary:
-		result = s.formatContextSummary(sessions, observations, prompts, opts.MaxTokens)
-	case DetailFull:
-		result = s.formatContextFull(sessions, observations, prompts, opts.MaxTokens)
-	default:
-		result = s.formatContextStandard(sessions, observations, prompts, opts.MaxTokens)
+	if opts.Namespace != "" {
+		obsQuery += " AND namespace = ?"
+		obsArgs = append(obsArgs, opts.Namespace)
 	}
-	return result, nil
-}
-
-// formatContextStandard is the original behavior — truncated snippets.
-// When maxTokens > 0, stops adding observations once the budget would be exceeded.
-func (s *Store) formatContextStandard(sessions []SessionSummary, observations []Observation, prompts []Prompt, maxTokens int) string {
-	var b strings.Builder
-	b.WriteString("## Memory from Previous Sessions\n\n")
-
-	if len(sessions) > 0 {
-		b.WriteString("### Recent Sessions\n")
-		for _, sess := range sessions {
-			summary := ""
-			if sess.Summary != nil {
-				summary = fmt.Sprintf(": %s", Truncate(*sess.Summary, 200))
-			}
-			fmt.Fprintf(&b, "- **%s** (%s)%s [%d observations]\n",
-				sess.Project, sess.StartedAt, summary, sess.ObservationCount)
-		}
-		b.WriteString("\n")
+	if opts.Since != "" {
+		obsQuery += " AND datetime(created_at) >= datetime(?)"
+		obsArgs = append(obsArgs, opts.Since)
 	}
+	obsQuery += " ORDER BY id"
 
-	if len(prompts) > 0 {
-		b.WriteString("### Recent User Prompts\n")
-		for _, p := range prompts {
-			fmt.Fprintf(&b, "- %s: %s\n", p.CreatedAt, Truncate(p.Content, 200))
-		}
-		b.WriteString("\n")
-	}
-
-	if len(observations) > 0 {
-		b.WriteString("### Recent Observations\n")
-		shown := 0
-		for _, obs := range observations {
-			entry := fmt.Sprintf("- [%s] **%s**: %s\n",
-				obs.Type, obs.Title, Truncate(obs.Content, 300))
-			if maxTokens > 0 && EstimateTokens(b.String()+entry) > maxTokens {
-				b.WriteString(BudgetFooter(EstimateTokens(b.String()), maxTokens, shown, len(observations)))
-				return b.String()
-			}
-			b.WriteString(entry)
-			shown++
-		}
-		b.WriteString("\n")
+	obsRows, err := s.queryItHook(s.db, obsQuery, obsArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("export observations: %w", err)
 	}
-
-	return b.String()
-}
-
-// formatContextSummary returns minimal metadata — session names, observation
-// titles, and prompt timestamps. No content snippets. Minimal tokens.
-// When maxTokens > 0, stops adding observations once the budget would be exceeded.
-func (s *Store) formatContextSummary(sessions []SessionSummary, observations []Observation, prompts []Prompt, maxTokens int) string {
-	var b strings.Builder
-	b.WriteString("## Memory Context (summary)\n\n")
-
-	if len(sessions) > 0 {
-		b.WriteString("### Sessions\n")
-		for _, sess := range sessions {
-			fmt.Fprintf(&b, "- %s (%s) [%d obs]\n",
-				sess.Project, sess.StartedAt, sess.ObservationCount)
+	defer func() { _ = obsRows.Close() }()
+	for obsRows.Next() {
+		var o Observation
+		if err := obsRows.Scan(
+			&o.ID, &o.SessionID, &o.Type, &o.Title, &o.Content,
+			&o.ToolName, &o.Project, &o.Scope, &o.TopicKey, &o.Namespace, &o.RevisionCount, &o.DuplicateCount, &o.LastSeenAt,
+			&o.CreatedAt, &o.UpdatedAt, &o.DeletedAt,
+		); err != nil {
+			return nil, err
 		}
-		b.WriteString("\n")
+		data.Observations = append(data.Observations, o)
 	}
-
-	if len(prompts) > 0 {
-		b.WriteString("### Prompts\n")
-		for _, p := range prompts {
-			fmt.Fprintf(&b, "- %s: %s\n", p.CreatedAt, Truncate(p.Content, 80))
-		}
-		b.WriteString("\n")
+	if err := obsRows.Err(); err != nil {
+		return nil, err
 	}
 
-	if len(observations) > 0 {
-		b.WriteString("### Observations\n")
-		shown := 0
-		for _, obs := range observations {
-			entry := fmt.Sprintf("- #%d [%s] %s\n", obs.ID, obs.Type, obs.Title)
-			if maxTokens > 0 && EstimateTokens(b.String()+entry) > maxTokens {
-				b.WriteString(BudgetFooter(EstimateTokens(b.String()), maxTokens, shown, len(observations)))
-				return b.String()
-			}
-			b.WriteString(entry)
-			shown++
-		}
-		b.WriteString("\n")
+	// Prompts — scope does not apply (prompts are always project-bound).
+	promptQuery := "SELECT id, session_id, content, ifnull(project, '') as project, ifnull(namespace, '') as namespace, created_at FROM user_prompts WHERE 1=1"
+	var promptArgs []any
+	if opts.Project != "" {
+		promptQuery += " AND project = ?"
+		promptArgs = append(promptArgs, opts.Project)
 	}
+	if opts.Namespace != "" {
+		promptQuery += " AND namespace = ?"
+		promptArgs = append(promptArgs, opts.Namespace)
+	}
+	if opts.Since != "" {
+		promptQuery += " AND datetime(created_at) >= datetime(?)"
+		promptArgs = append(promptArgs, opts.Since)
+	}
+	promptQuery += " ORDER BY id"
 
-	return b.String()
-}
-
-// formatContextFull returns complete untruncated content for deep analysis.
-// When maxTokens > 0, stops adding observations once the budget would be exceeded.
-func (s *Store) formatContextFull(sessions []SessionSummary, observations []Observation, prompts []Prompt, maxTokens int) string {
-	var b strings.Builder
-	b.WriteString("## Memory from Previous Sessions (full)\n\n")
-
-	if len(sessions) > 0 {
-		b.WriteString("### Recent Sessions\n")
-		for _, sess := range sessions {
-			summary := ""
-			if sess.Summary != nil {
-				summary = fmt.Sprintf(": %s", *sess.Summary) // untruncated
-			}
-			fmt.Fprintf(&b, "- **%s** (%s)%s [%d observations]\n",
-				sess.Project, sess.StartedAt, summary, sess.ObservationCount)
+	promptRows, err := s.queryItHook(s.db, promptQuery, promptArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("export prompts: %w", err)
+	}
+	defer func() { _ = promptRows.Close() }()
+	for promptRows.Next() {
+		var p Prompt
+		if err := promptRows.Scan(&p.ID, &p.SessionID, &p.Content, &p.Project, &p.Namespace, &p.CreatedAt); err != nil {
+			return nil, err
 		}
-		b.WriteString("\n")
+		data.Prompts = append(data.Prompts, p)
+	}
+	if err := promptRows.Err(); err != nil {
+		return nil, err
 	}
 
-	if len(prompts) > 0 {
-		b.WriteString("### Recent User Prompts\n")
-		for _, p := range prompts {
-			fmt.Fprintf(&b, "- %s: %s\n", p.CreatedAt, p.Content) // untruncated
+	// Relations — only edges between exported observations.
+	exported := make(map[int64]bool, len(data.Observations))
+	for _, o := range data.Observations {
+		exported[o.ID] = true
+	}
+	relRows, err := s.queryItHook(s.db,
+		`SELECT id, from_id, to_id, type, COALESCE(note, ''), created_at FROM relations ORDER BY id`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("export relations: %w", err)
+	}
+	defer func() { _ = relRows.Close() }()
+	for relRows.Next() {
+		var r Relation
+		if err := relRows.Scan(&r.ID, &r.FromID, &r.ToID, &r.Type, &r.Note, &r.CreatedAt); err != nil {
+			return nil, err
 		}
-		b.WriteString("\n")
-	}
-
-	if len(observations) > 0 {
-		b.WriteString("### Recent Observations\n")
-		shown := 0
-		for _, obs := range observations {
-			entry := fmt.Sprintf("#### [%s] %s (ID: %d)\n%s\n\n",
-				obs.Type, obs.Title, obs.ID, obs.Content)
-			if maxTokens > 0 && EstimateTokens(b.String()+entry) > maxTokens {
-				b.WriteString(BudgetFooter(EstimateTokens(b.String()), maxTokens, shown, len(observations)))
-				return b.String()
-			}
-			b.WriteString(entry)
-			shown++
+		if exported[r.FromID] && exported[r.ToID] {
+			data.Relations = append(data.Relations, r)
 		}
 	}
+	if err := relRows.Err(); err != nil {
+		return nil, err
+	}
 
-	return b.String()
-}
-
-// ─── Export / Import ─────────────────────────────────────────────────────────
-
-// Export dumps the entire memory database as a serializable struct.
-func (s *Store) Export() (*ExportData, error) {
-	data := &ExportData{
-		Version:    "0.1.0",
-		ExportedAt: Now(),
+	// Sessions — filtered in Go so referenced sessions are never dropped.
+	referenced := make(map[string]bool)
+	for _, o := range data.Observations {
+		referenced[o.SessionID] = true
 	}
+	for _, p := range data.Prompts {
+		referenced[p.SessionID] = true
+	}
+	// Scope and namespace are observation-level concepts: when either is
+	// set, only sessions that own exported records are meaningful.
+	sessionFilterOnly := opts.Scope != "" || opts.Namespace != ""
 
-	// Sessions
 	rows, err := s.queryItHook(s.db,
 		"SELECT id, project, directory, started_at, ended_at, summary FROM sessions ORDER BY started_at",
 	)
@@ -1982,68 +1977,129 @@ func (s *Store) Export() (*ExportData, error) {
 		if err := rows.Scan(&sess.ID, &sess.Project, &sess.Directory, &sess.StartedAt, &sess.EndedAt, &sess.Summary); err != nil {
 			return nil, err
 		}
+		if !referenced[sess.ID] {
+			if sessionFilterOnly {
+				continue
+			}
+			if opts.Project != "" && sess.Project != opts.Project {
+				continue
+			}
+			if opts.Since != "" && sess.StartedAt < opts.Since {
+				continue
+			}
+		}
 		data.Sessions = append(data.Sessions, sess)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	// Observations
-	obsRows, err := s.queryItHook(s.db,
-		`SELECT id, session_id, type, title, content, tool_name, project,
-		        scope, topic_key, namespace, revision_count, duplicate_count, last_seen_at, created_at, updated_at, deleted_at
-		 FROM observations ORDER BY id`,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("export observations: %w", err)
+	return data, nil
+}
+
+// ParseSince converts a user-supplied date ("2006-01-02", "2006-01-02 15:04:05"
+// or RFC 3339) into the SQLite timestamp format used by ExportOptions.Since.
+func ParseSince(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
 	}
-	defer func() { _ = obsRows.Close() }()
-	for obsRows.Next() {
-		var o Observation
-		if err := obsRows.Scan(
-			&o.ID, &o.SessionID, &o.Type, &o.Title, &o.Content,
-			&o.ToolName, &o.Project, &o.Scope, &o.TopicKey, &o.Namespace, &o.RevisionCount, &o.DuplicateCount, &o.LastSeenAt,
-			&o.CreatedAt, &o.UpdatedAt, &o.DeletedAt,
-		); err != nil {
-			return nil, err
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.UTC().Format("2006-01-02 15:04:05"), nil
 		}
-		data.Observations = append(data.Observations, o)
-	}
-	if err := obsRows.Err(); err != nil {
-		return nil, err
 	}
+	return "", fmt.Errorf("invalid date %q: expected YYYY-MM-DD, \"YYYY-MM-DD HH:MM:SS\" or RFC 3339", v)
+}
 
-	// Prompts
-	promptRows, err := s.queryItHook(s.db,
-		"SELECT id, session_id, content, ifnull(project, '') as project, created_at FROM user_prompts ORDER BY id",
-	)
-	if err != nil {
-		return nil, fmt.Errorf("export prompts: %w", err)
+// Filter returns a copy of the export restricted by the given options,
+// mirroring the semantics of ExportFiltered. Used to import a subset
+// of a dump.
+func (d *ExportData) Filter(opts ExportOptions) *ExportData {
+	out := &ExportData{Version: d.Version, ExportedAt: d.ExportedAt}
+
+	referenced := make(map[string]bool)
+	for _, o := range d.Observations {
+		if opts.Project != "" && derefString(o.Project) != opts.Project {
+			continue
+		}
+		if opts.Scope != "" && normalizeScope(o.Scope) != normalizeScope(opts.Scope) {
+			continue
+		}
+		if opts.Namespace != "" && derefString(o.Namespace) != opts.Namespace {
+			continue
+		}
+		if opts.Since != "" && o.CreatedAt < opts.Since {
+			continue
+		}
+		out.Observations = append(out.Observations, o)
+		referenced[o.SessionID] = true
 	}
-	defer func() { _ = promptRows.Close() }()
-	for promptRows.Next() {
-		var p Prompt
-		if err := promptRows.Scan(&p.ID, &p.SessionID, &p.Content, &p.Project, &p.CreatedAt); err != nil {
-			return nil, err
+	for _, p := range d.Prompts {
+		if opts.Namespace != "" && p.Namespace != opts.Namespace {
+			continue
 		}
-		data.Prompts = append(data.Prompts, p)
+		if opts.Project != "" && p.Project != opts.Project {
+			continue
+		}
+		if opts.Since != "" && p.CreatedAt < opts.Since {
+			continue
+		}
+		out.Prompts = append(out.Prompts, p)
+		referenced[p.SessionID] = true
 	}
-	if err := promptRows.Err(); err != nil {
-		return nil, err
+	sessionFilterOnly := opts.Scope != "" || opts.Namespace != ""
+	for _, sess := range d.Sessions {
+		if !referenced[sess.ID] {
+			if sessionFilterOnly {
+				continue
+			}
+			if opts.Project != "" && sess.Project != opts.Project {
+				continue
+			}
+			if opts.Since != "" && sess.StartedAt < opts.Since {
+				continue
+			}
+		}
+		out.Sessions = append(out.Sessions, sess)
 	}
-
-	return data, nil
+	kept := make(map[int64]bool, len(out.Observations))
+	for _, o := range out.Observations {
+		kept[o.ID] = true
+	}
+	for _, r := range d.Relations {
+		if kept[r.FromID] && kept[r.ToID] {
+			out.Relations = append(out.Relations, r)
+		}
+	}
+	return out
 }
 
 // Import loads exported data into the memory database.
 func (s *Store) Import(data *ExportData) (*ImportResult, error) {
+	return s.ImportWithOptions(data, ImportOptions{})
+}
+
+// ImportWithOptions loads exported data into the memory database.
+// With DryRun set, every insert runs inside the transaction (so
+// constraint violations and ignored duplicates are detected) and the
+// transaction is rolled back instead of committed.
+//
+// Observations receive new IDs in this database; relations are remapped
+// through the old→new ID map so knowledge-graph edges survive the round
+// trip. Relations whose endpoints are not part of the dump are skipped.
+func (s *Store) ImportWithOptions(data *ExportData, opts ImportOptions) (*ImportResult, error) {
+	if data.Version != "" && !supportedExportVersions[data.Version] {
+		return nil, fmt.Errorf("import: unsupported export version %q (this build supports up to %s)", data.Version, ExportVersion)
+	}
+
 	tx, err := s.beginTxHook()
 	if err != nil {
 		return nil, fmt.Errorf("import: begin tx: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	result := &ImportResult{}
+	result := &ImportResult{DryRun: opts.DryRun, IDMap: make(map[int64]int64, len(data.Observations))}
 
 	for _, sess := range data.Sessions {
 		res, err := s.execHook(tx,
@@ -2059,13 +2115,14 @@ func (s *Store) Import(data *ExportData) (*ImportResult, error) {
 	}
 
 	for _, obs := range data.Observations {
-		_, err := s.execHook(tx,
-			`INSERT INTO observations (session_id, type, title, content, tool_name, project, scope, topic_key, normalized_hash, revision_count, duplicate_count, last_seen_at, created_at, updated_at, deleted_at)
-			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		res, err := s.execHook(tx,
+			`INSERT INTO observations (session_id, type, title, content, tool_name, project, scope, topic_key, namespace, normalized_hash, revision_count, duplicate_count, last_seen_at, created_at, updated_at, deleted_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			obs.SessionID, obs.Type, obs.Title, obs.Content,
 			obs.ToolName, obs.Project,
 			normalizeScope(obs.Scope),
 			nullableString(normalizeTopicKey(derefString(obs.TopicKey))),
+			nullableString(derefString(obs.Namespace)),
 			hashNormalized(obs.Content),
 			maxInt(obs.RevisionCount, 1),
 			maxInt(obs.DuplicateCount, 1),
@@ -2074,14 +2131,19 @@ func (s *Store) Import(data *ExportData) (*ImportResult, error) {
 		if err != nil {
 			return nil, fmt.Errorf("import observation %d: %w", obs.ID, err)
 		}
+		newID, err := res.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("import observation %d: last insert id: %w", obs.ID, err)
+		}
+		result.IDMap[obs.ID] = newID
 		result.ObservationsImported++
 	}
 
 	for _, p := range data.Prompts {
 		_, err := s.execHook(tx,
-			`INSERT INTO user_prompts (session_id, content, project, created_at)
-			 VALUES (?, ?, ?, ?)`,
-			p.SessionID, p.Content, p.Project, p.CreatedAt,
+			`INSERT INTO user_prompts (session_id, content, project, namespace, created_at)
+			 VALUES (?, ?, ?, ?, ?)`,
+			p.SessionID, p.Content, p.Project, nullableString(p.Namespace), p.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("import prompt %d: %w", p.ID, err)
@@ -2089,6 +2151,41 @@ func (s *Store) Import(data *ExportData) (*ImportResult, error) {
 		result.PromptsImported++
 	}
 
+	for _, r := range data.Relations {
+		fromID, okFrom := result.IDMap[r.FromID]
+		toID, okTo := result.IDMap[r.ToID]
+		if !okFrom || !okTo {
+			result.RelationsSkipped++
+			continue
+		}
+		relType := r.Type
+		if relType == "" {
+			relType = "relates_to"
+		}
+		createdAt := r.CreatedAt
+		if createdAt == "" {
+			createdAt = Now()
+		}
+		res, err := s.execHook(tx,
+			`INSERT OR IGNORE INTO relations (from_id, to_id, type, note, created_at)
+			 VALUES (?, ?, ?, ?, ?)`,
+			fromID, toID, relType, nullableString(r.Note), createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("import relation %d: %w", r.ID, err)
+		}
+		n, _ := res.RowsAffected()
+		if n == 0 {
+			result.RelationsSkipped++
+			continue
+		}
+		result.RelationsImported++
+	}
+
+	if opts.DryRun {
+		return result, nil // deferred Rollback discards everything
+	}
+
 	if err := s.commitHook(tx); err != nil {
 		return nil, fmt.Errorf("import: commit: %w", err)
 	}
@@ -2167,53 +2264,23 @@ func cleanMarkdown(text string) string {
 
 // PassiveCapture extracts learnings from text and saves them as observations.
 func (s *Store) PassiveCapture(p PassiveCaptureParams) (*PassiveCaptureResult, error) {
-	result := &PassiveCaptureResult{}
-
-	learnings := ExtractLearnings(p.Content)
-	result.Extracted = len(learnings)
-
-	if len(learnings) == 0 {
-		return result, nil
-	}
-
-	for _, learning := range learnings {
-		normHash := hashNormalized(learning)
-		var existingID int64
-		err := s.db.QueryRow(
-			`SELECT id FROM observations
-			 WHERE normalized_hash = ?
-			   AND ifnull(project, '') = ifnull(?, '')
-			   AND deleted_at IS NULL
-			 LIMIT 1`,
-			normHash, nullableString(p.Project),
-		).Scan(&existingID)
-
-		if err == nil {
-			result.Duplicates++
-			continue
-		}
-
-		title := learning
-		if len(title) > 60 {
-			title = title[:60] + "..."
-		}
+	return passiveCapture(s, p)
+}
 
-		_, err = s.AddObservation(AddObservationParams{
-			SessionID: p.SessionID,
-			Type:      "passive",
-			Title:     title,
-			Content:   learning,
-			Project:   p.Project,
-			Scope:     "project",
-			ToolName:  p.Source,
-		})
-		if err != nil {
-			return result, fmt.Errorf("passive capture save: %w", err)
-		}
-		result.Saved++
+func (s *Store) hasContentHash(normHash, project string) (bool, error) {
+	var existingID int64
+	err := s.db.QueryRow(
+		`SELECT id FROM observations
+		 WHERE normalized_hash = ?
+		   AND ifnull(project, '') = ifnull(?, '')
+		   AND deleted_at IS NULL
+		 LIMIT 1`,
+		normHash, nullableString(project),
+	).Scan(&existingID)
+	if err == sql.ErrNoRows {
+		return false, nil
 	}
-
-	return result, nil
+	return err == nil, err
 }
 
 // ─── Helpers ─────────────────────────────────────────────────────────────────
@@ -2394,6 +2461,12 @@ func hashNormalized(content string) string {
 }
 
 func dedupeWindowExpression(window time.Duration) string {
+	return "-" + strconv.Itoa(dedupeWindowMinutes(window)) + " minutes"
+}
+
+// dedupeWindowMinutes rounds the dedupe window down to whole minutes
+// (at least one), defaulting to 15.
+func dedupeWindowMinutes(window time.Duration) int {
 	if window <= 0 {
 		window = 15 * time.Minute
 	}
@@ -2401,7 +2474,7 @@ func dedupeWindowExpression(window time.Duration) string {
 	if minutes < 1 {
 		minutes = 1
 	}
-	return "-" + strconv.Itoa(minutes) + " minutes"
+	return minutes
 }
 
 func maxInt(a, b int) int {
@@ -1390,8 +1390,8 @@ func TestExport_Empty(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if data.Version != "0.1.0" {
-		t.Errorf("Version = %q, want %q", data.Version, "0.1.0")
+	if data.Version != memory.ExportVersion {
+		t.Errorf("Version = %q, want %q", data.Version, memory.ExportVersion)
 	}
 	if len(data.Sessions) != 0 {
 		t.Errorf("Sessions: len = %d, want 0", len(data.Sessions))
@@ -1481,6 +1481,295 @@ func TestImport_DuplicateSessionsIgnored(t *testing.T) {
 	}
 }
 
+func TestExportFiltered_ByProjectAndScope(t *testing.T) {
+	s := newTestStore(t)
+	ensureSession(t, s, "sess-a", "alpha")
+	ensureSession(t, s, "sess-b", "beta")
+
+	for _, p := range []memory.AddObservationParams{
+		{SessionID: "sess-a", Type: "decision", Title: "Alpha project", Content: "alpha project obs", Project: "alpha", Scope: "project"},
+		{SessionID: "sess-a", Type: "decision", Title: "Alpha personal", Content: "alpha personal obs", Project: "alpha", Scope: "personal"},
+		{SessionID: "sess-b", Type: "decision", Title: "Beta project", Content: "beta project obs", Project: "beta", Scope: "project"},
+	} {
+		if _, err := s.AddObservation(p); err != nil {
+			t.Fatalf("AddObservation: %v", err)
+		}
+	}
+	if _, err := s.AddPrompt(memory.AddPromptParams{SessionID: "sess-b", Content: "beta prompt", Project: "beta"}); err != nil {
+		t.Fatalf("AddPrompt: %v", err)
+	}
+
+	data, err := s.ExportFiltered(memory.ExportOptions{Project: "alpha"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Observations) != 2 {
+		t.Errorf("project filter: observations = %d, want 2", len(data.Observations))
+	}
+	if len(data.Prompts) != 0 {
+		t.Errorf("project filter: prompts = %d, want 0", len(data.Prompts))
+	}
+	if len(data.Sessions) != 1 || data.Sessions[0].ID != "sess-a" {
+		t.Errorf("project filter: sessions = %+v, want only sess-a", data.Sessions)
+	}
+
+	data, err = s.ExportFiltered(memory.ExportOptions{Project: "alpha", Scope: "personal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Observations) != 1 || data.Observations[0].Title != "Alpha personal" {
+		t.Errorf("scope filter: observations = %+v, want only 'Alpha personal'", data.Observations)
+	}
+}
+
+func TestExportFiltered_SinceExcludesOlder(t *testing.T) {
+	s := newTestStore(t)
+	ensureSession(t, s, "sess", "proj")
+
+	if _, err := s.AddObservation(memory.AddObservationParams{
+		SessionID: "sess", Type: "decision", Title: "Old", Content: "old content", Project: "proj",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DB().Exec(`UPDATE observations SET created_at = '2020-01-01 00:00:00'`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddObservation(memory.AddObservationParams{
+		SessionID: "sess", Type: "decision", Title: "New", Content: "new content", Project: "proj",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	since, err := memory.ParseSince("2024-06-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.ExportFiltered(memory.ExportOptions{Since: since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Observations) != 1 || data.Observations[0].Title != "New" {
+		t.Errorf("since filter: observations = %+v, want only 'New'", data.Observations)
+	}
+	// The session owning the new observation must still be exported.
+	if len(data.Sessions) != 1 {
+		t.Errorf("since filter: sessions = %d, want 1 (referenced)", len(data.Sessions))
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"2024-06-01", "2024-06-01 00:00:00", false},
+		{"2024-06-01 12:30:00", "2024-06-01 12:30:00", false},
+		{"2024-06-01T12:30:00+02:00", "2024-06-01 10:30:00", false},
+		{"last tuesday", "", true},
+	}
+	for _, tt := range tests {
+		got, err := memory.ParseSince(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSince(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSince(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExportData_Filter(t *testing.T) {
+	alpha, beta := "alpha", "beta"
+	data := &memory.ExportData{
+		Version: "0.1.0",
+		Sessions: []memory.Session{
+			{ID: "sess-a", Project: "alpha"},
+			{ID: "sess-b", Project: "beta"},
+		},
+		Observations: []memory.Observation{
+			{ID: 1, SessionID: "sess-a", Title: "a", Project: &alpha, Scope: "project"},
+			{ID: 2, SessionID: "sess-b", Title: "b", Project: &beta, Scope: "project"},
+		},
+		Prompts: []memory.Prompt{
+			{ID: 1, SessionID: "sess-b", Content: "p", Project: "beta"},
+		},
+	}
+
+	got := data.Filter(memory.ExportOptions{Project: "beta"})
+	if len(got.Observations) != 1 || got.Observations[0].ID != 2 {
+		t.Errorf("Observations = %+v, want only #2", got.Observations)
+	}
+	if len(got.Prompts) != 1 {
+		t.Errorf("Prompts = %d, want 1", len(got.Prompts))
+	}
+	if len(got.Sessions) != 1 || got.Sessions[0].ID != "sess-b" {
+		t.Errorf("Sessions = %+v, want only sess-b", got.Sessions)
+	}
+}
+
+func TestImportWithOptions_DryRunWritesNothing(t *testing.T) {
+	s1 := newTestStore(t)
+	ensureSession(t, s1, "sess", "proj")
+	if _, err := s1.AddObservation(memory.AddObservationParams{
+		SessionID: "sess", Type: "decision", Title: "Dry run", Content: "not persisted", Project: "proj",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	exported, err := s1.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s2 := newTestStore(t)
+	result, err := s2.ImportWithOptions(exported, memory.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportWithOptions: %v", err)
+	}
+	if !result.DryRun {
+		t.Error("DryRun = false, want true")
+	}
+	if result.SessionsImported != 1 || result.ObservationsImported != 1 {
+		t.Errorf("result = %+v, want 1 session and 1 observation", result)
+	}
+
+	stats, _ := s2.Stats()
+	if stats.TotalSessions != 0 || stats.TotalObservations != 0 {
+		t.Errorf("dry run wrote data: %+v", stats)
+	}
+}
+
+func TestExportImport_PreservesRelationsAndNamespaces(t *testing.T) {
+	s1 := newTestStore(t)
+	ensureSession(t, s1, "sess", "proj")
+
+	// Pad the source store so exported IDs differ from the IDs the
+	// fresh store will assign — exercises the remapping.
+	padID, err := s1.AddObservation(memory.AddObservationParams{
+		SessionID: "sess", Type: "manual", Title: "Padding", Content: "padding", Project: "proj",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s1.DeleteObservation(padID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	idA, err := s1.AddObservation(memory.AddObservationParams{
+		SessionID: "sess", Type: "decision", Title: "Use SQLite", Content: "chose sqlite",
+		Project: "proj", Namespace: "subagent/backend",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	idB, err := s1.AddObservation(memory.AddObservationParams{
+		SessionID: "sess", Type: "bugfix", Title: "WAL lock fix", Content: "busy timeout", Project: "proj",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s1.AddRelation(memory.AddRelationParams{FromID: idB, ToID: idA, Type: "caused_by", Note: "lock contention"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s1.AddPrompt(memory.AddPromptParams{
+		SessionID: "sess", Content: "namespaced prompt", Project: "proj", Namespace: "subagent/backend",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := s1.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exported.Relations) != 1 {
+		t.Fatalf("exported relations = %d, want 1", len(exported.Relations))
+	}
+	if len(exported.Prompts) != 1 || exported.Prompts[0].Namespace != "subagent/backend" {
+		t.Fatalf("exported prompts = %+v, want namespace preserved", exported.Prompts)
+	}
+
+	s2 := newTestStore(t)
+	result, err := s2.Import(exported)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.RelationsImported != 1 {
+		t.Errorf("RelationsImported = %d, want 1", result.RelationsImported)
+	}
+
+	newA, newB := result.IDMap[idA], result.IDMap[idB]
+	if newA == 0 || newB == 0 {
+		t.Fatalf("IDMap = %v, missing entries for %d/%d", result.IDMap, idA, idB)
+	}
+	if newA == idA {
+		t.Errorf("expected observation %d to be remapped, got same ID", idA)
+	}
+
+	obsA, err := s2.GetObservation(newA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obsA.Namespace == nil || *obsA.Namespace != "subagent/backend" {
+		t.Errorf("imported namespace = %v, want subagent/backend", obsA.Namespace)
+	}
+
+	ctx, err := s2.BuildContext(newB, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.TotalNodes != 1 || ctx.Connected[0].ID != newA || ctx.Connected[0].RelationType != "caused_by" {
+		t.Errorf("BuildContext after import = %+v, want edge to #%d (caused_by)", ctx.Connected, newA)
+	}
+}
+
+func TestImport_V1FormatStillSupported(t *testing.T) {
+	s := newTestStore(t)
+	data := &memory.ExportData{
+		Version:  "0.1.0",
+		Sessions: []memory.Session{{ID: "old", Project: "proj", Directory: "/tmp", StartedAt: memory.Now()}},
+		Observations: []memory.Observation{
+			{ID: 7, SessionID: "old", Type: "decision", Title: "Legacy", Content: "legacy dump", Scope: "project",
+				CreatedAt: memory.Now(), UpdatedAt: memory.Now()},
+		},
+	}
+	result, err := s.Import(data)
+	if err != nil {
+		t.Fatalf("Import v1: %v", err)
+	}
+	if result.ObservationsImported != 1 || result.RelationsImported != 0 {
+		t.Errorf("result = %+v, want 1 observation and no relations", result)
+	}
+}
+
+func TestImport_UnsupportedVersion(t *testing.T) {
+	s := newTestStore(t)
+	_, err := s.Import(&memory.ExportData{Version: "9.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported export version") {
+		t.Errorf("err = %v, want unsupported export version", err)
+	}
+}
+
+func TestExportFiltered_DropsRelationsToFilteredObservations(t *testing.T) {
+	s := newTestStore(t)
+	ensureSession(t, s, "sess", "alpha")
+
+	idA, _ := s.AddObservation(memory.AddObservationParams{SessionID: "sess", Type: "decision", Title: "A", Content: "a", Project: "alpha"})
+	idB, _ := s.AddObservation(memory.AddObservationParams{SessionID: "sess", Type: "decision", Title: "B", Content: "b", Project: "beta"})
+	if _, err := s.AddRelation(memory.AddRelationParams{FromID: idA, ToID: idB}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := s.ExportFiltered(memory.ExportOptions{Project: "alpha"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Relations) != 0 {
+		t.Errorf("relations = %+v, want none (endpoint filtered out)", data.Relations)
+	}
+}
+
 // ─── Passive Capture ─────────────────────────────────────────────────────────
 
 func TestExtractLearnings_English(t *testing.T) {
@@ -15,11 +15,11 @@ import (
 //   - Without compact_ids: identifies stale observation candidates (identify mode)
 //   - With compact_ids: batch soft-deletes observations + optionally creates summary (execute mode)
 type CompactTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewCompactTool creates a CompactTool with the given memory store.
-func NewCompactTool(store *memory.Store) *CompactTool {
+func NewCompactTool(store memory.Backend) *CompactTool {
 	return &CompactTool{store: store}
 }
 
@@ -9,11 +9,11 @@ import (
 
 // ContextTool handles the mem_context MCP tool.
 type ContextTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewContextTool creates a ContextTool.
-func NewContextTool(store *memory.Store) *ContextTool {
+func NewContextTool(store memory.Backend) *ContextTool {
 	return &ContextTool{store: store}
 }
 
@@ -1,7 +1,7 @@
 // Package memtools provides MCP tool handlers for the persistent memory system.
 //
 // Each tool handler follows the same pattern as internal/tools:
-// - A struct with dependencies (memory.Store) injected via constructor
+// - A struct with dependencies (memory.Backend) injected via constructor
 // - Definition() returns the mcp.Tool schema
 // - Handle() processes the request and returns a result
 //
@@ -12,11 +12,11 @@ import (
 
 // DeleteTool handles the mem_delete MCP tool.
 type DeleteTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewDeleteTool creates a DeleteTool with the given memory store.
-func NewDeleteTool(store *memory.Store) *DeleteTool {
+func NewDeleteTool(store memory.Backend) *DeleteTool {
 	return &DeleteTool{store: store}
 }
 
@@ -61,11 +61,11 @@ func (t *DeleteTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 
 // UpdateTool handles the mem_update MCP tool.
 type UpdateTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewUpdateTool creates an UpdateTool with the given memory store.
-func NewUpdateTool(store *memory.Store) *UpdateTool {
+func NewUpdateTool(store memory.Backend) *UpdateTool {
 	return &UpdateTool{store: store}
 }
 
@@ -242,7 +242,7 @@ func mustBeToolError(t *testing.T, r *mcp.CallToolResult, err error, wantSubstr
 }
 
 // seedSession creates a session in the store for testing.
-func seedSession(t *testing.T, store *memory.Store, id, project string) {
+func seedSession(t *testing.T, store memory.Backend, id, project string) {
 	t.Helper()
 	if err := store.CreateSession(id, project, "/tmp/test"); err != nil {
 		t.Fatalf("seed session: %v", err)
@@ -250,7 +250,7 @@ func seedSession(t *testing.T, store *memory.Store, id, project string) {
 }
 
 // seedManualSession ensures the "manual-save" session exists (needed for FK constraints).
-func seedManualSession(t *testing.T, store *memory.Store) {
+func seedManualSession(t *testing.T, store memory.Backend) {
 	t.Helper()
 	// CreateSession is idempotent (INSERT OR IGNORE), safe to call multiple times.
 	if err := store.CreateSession("manual-save", "", "/tmp/test"); err != nil {
@@ -260,7 +260,7 @@ func seedManualSession(t *testing.T, store *memory.Store) {
 
 // seedObservation creates an observation and returns its ID.
 // Requires a session with ID "test-session" to exist (call seedSession first).
-func seedObservation(t *testing.T, store *memory.Store, title, content, project string) int64 {
+func seedObservation(t *testing.T, store memory.Backend, title, content, project string) int64 {
 	t.Helper()
 	id, err := store.AddObservation(memory.AddObservationParams{
 		SessionID: "test-session",
@@ -597,6 +597,30 @@ func TestSearchTool_FindsResults(t *testing.T) {
 	}
 }
 
+// TestSearchTool_InMemoryBackend checks the tools only depend on the
+// memory.Backend contract, not on the SQLite store.
+func TestSearchTool_InMemoryBackend(t *testing.T) {
+	store := memory.NewMemoryBackend(memory.DefaultConfig())
+	seedManualSession(t, store)
+
+	r, err := NewSaveTool(store).Handle(ctx, makeReq(map[string]interface{}{
+		"title":   "JWT middleware",
+		"content": "Added JWT auth middleware for Express",
+		"project": "my-app",
+	}))
+	mustNotError(t, r, err)
+
+	r, err = NewSearchTool(store).Handle(ctx, makeReq(map[string]interface{}{
+		"query":   "jwt auth",
+		"project": "my-app",
+	}))
+	mustNotError(t, r, err)
+
+	if text := resultText(r); !strings.Contains(text, "JWT middleware") {
+		t.Errorf("expected JWT result, got: %s", text)
+	}
+}
+
 func TestSearchTool_NoResults(t *testing.T) {
 	store := newTestStore(t)
 	tool := NewSearchTool(store)
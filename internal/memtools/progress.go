@@ -16,11 +16,11 @@ import (
 //
 // Only ONE active progress exists per project (enforced via topic_key upsert).
 type ProgressTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewProgressTool creates a ProgressTool with the given memory store.
-func NewProgressTool(store *memory.Store) *ProgressTool {
+func NewProgressTool(store memory.Backend) *ProgressTool {
 	return &ProgressTool{store: store}
 }
 
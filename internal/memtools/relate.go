@@ -13,11 +13,11 @@ import (
 
 // RelateTool handles the mem_relate MCP tool.
 type RelateTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewRelateTool creates a RelateTool with the given memory store.
-func NewRelateTool(store *memory.Store) *RelateTool {
+func NewRelateTool(store memory.Backend) *RelateTool {
 	return &RelateTool{store: store}
 }
 
@@ -12,11 +12,11 @@ import (
 
 // SaveTool handles the mem_save MCP tool.
 type SaveTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewSaveTool creates a SaveTool with the given memory store.
-func NewSaveTool(store *memory.Store) *SaveTool {
+func NewSaveTool(store memory.Backend) *SaveTool {
 	return &SaveTool{store: store}
 }
 
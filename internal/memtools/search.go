@@ -11,11 +11,11 @@ import (
 
 // SearchTool handles the mem_search MCP tool.
 type SearchTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewSearchTool creates a SearchTool.
-func NewSearchTool(store *memory.Store) *SearchTool {
+func NewSearchTool(store memory.Backend) *SearchTool {
 	return &SearchTool{store: store}
 }
 
@@ -11,11 +11,11 @@ import (
 
 // SessionTool handles the unified mem_session MCP tool.
 type SessionTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewSessionTool creates a SessionTool.
-func NewSessionTool(store *memory.Store) *SessionTool {
+func NewSessionTool(store memory.Backend) *SessionTool {
 	return &SessionTool{store: store}
 }
 
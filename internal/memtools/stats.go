@@ -11,11 +11,11 @@ import (
 
 // StatsTool handles the mem_stats MCP tool.
 type StatsTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewStatsTool creates a StatsTool with the given memory store.
-func NewStatsTool(store *memory.Store) *StatsTool {
+func NewStatsTool(store memory.Backend) *StatsTool {
 	return &StatsTool{store: store}
 }
 
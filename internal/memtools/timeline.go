@@ -11,11 +11,11 @@ import (
 
 // TimelineTool handles the mem_timeline MCP tool.
 type TimelineTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewTimelineTool creates a TimelineTool.
-func NewTimelineTool(store *memory.Store) *TimelineTool {
+func NewTimelineTool(store memory.Backend) *TimelineTool {
 	return &TimelineTool{store: store}
 }
 
@@ -185,11 +185,11 @@ func (t *TimelineTool) formatTimelineFull(b *strings.Builder, result *memory.Tim
 
 // GetObservationTool handles the mem_get MCP tool.
 type GetObservationTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewGetObservationTool creates a GetObservationTool.
-func NewGetObservationTool(store *memory.Store) *GetObservationTool {
+func NewGetObservationTool(store memory.Backend) *GetObservationTool {
 	return &GetObservationTool{store: store}
 }
 
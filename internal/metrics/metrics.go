@@ -0,0 +1,390 @@
+// Package metrics aggregates the stage timestamps of changes into flow
+// analytics.
+//
+// Every change records when it was created, and every stage when it
+// started and completed. Build turns those into:
+//
+//   - lead time per completed change (created, or earliest stage start if
+//     earlier → last stage completed)
+//   - time spent in each stage
+//   - throughput and lead time by change type and size
+//   - how often context-check led to a scope change — the change was
+//     resized, rewound to a stage before context-check, or abandoned
+//     after it
+//   - the largest outliers: the changes and stages that took the most
+//     times their median
+//
+// Reports render as markdown or JSON.
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+)
+
+// DefaultOutliers is the number of outliers reported when Options.Outliers is 0.
+const DefaultOutliers = 5
+
+// Options selects the changes to measure.
+type Options struct {
+	// From and To bound the range (YYYY-MM-DD, inclusive, whole UTC
+	// days); empty means unbounded. Changes count when they completed
+	// in range, stages when they completed in range.
+	From string
+	To   string
+	// Outliers is the number of outliers to report (default DefaultOutliers).
+	Outliers int
+}
+
+// Stats summarizes a set of durations, in hours.
+type Stats struct {
+	Count       int     `json:"count"`
+	MedianHours float64 `json:"median_hours"`
+	MeanHours   float64 `json:"mean_hours"`
+	MaxHours    float64 `json:"max_hours"`
+}
+
+// ChangeMetrics is the lead time of one completed change.
+type ChangeMetrics struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Size          string    `json:"size"`
+	StartedAt     time.Time `json:"started_at"`
+	CompletedAt   time.Time `json:"completed_at"`
+	LeadTimeHours float64   `json:"lead_time_hours"`
+}
+
+// StageStats is the time spent in one stage, across changes.
+type StageStats struct {
+	Stage string `json:"stage"`
+	Stats
+}
+
+// GroupStats is the throughput and lead time of one type or size.
+type GroupStats struct {
+	Key      string `json:"key"`
+	LeadTime Stats  `json:"lead_time"`
+}
+
+// ScopeChange is a change whose scope moved after context-check.
+type ScopeChange struct {
+	ChangeID string `json:"change_id"`
+	Reason   string `json:"reason"`
+}
+
+// ContextCheckStats counts the changes whose context-check completed in
+// range and those among them that changed scope afterwards.
+type ContextCheckStats struct {
+	Checked      int           `json:"checked"`
+	ScopeChanged []ScopeChange `json:"scope_changed"`
+}
+
+// Percent is the share of checked changes that changed scope, rounded down.
+func (c ContextCheckStats) Percent() int {
+	if c.Checked == 0 {
+		return 0
+	}
+	return len(c.ScopeChanged) * 100 / c.Checked
+}
+
+// Outlier is a change lead time (Stage empty) or a stage duration that
+// took Factor times the median of its kind.
+type Outlier struct {
+	ChangeID    string  `json:"change_id"`
+	Stage       string  `json:"stage,omitempty"`
+	Hours       float64 `json:"hours"`
+	MedianHours float64 `json:"median_hours"`
+	Factor      float64 `json:"factor"`
+}
+
+// Report is the result of Build.
+type Report struct {
+	From         string            `json:"from,omitempty"`
+	To           string            `json:"to,omitempty"`
+	Changes      []ChangeMetrics   `json:"changes"`
+	LeadTime     Stats             `json:"lead_time"`
+	Stages       []StageStats      `json:"stages"`
+	ByType       []GroupStats      `json:"by_type"`
+	BySize       []GroupStats      `json:"by_size"`
+	ContextCheck ContextCheckStats `json:"context_check"`
+	Outliers     []Outlier         `json:"outliers"`
+}
+
+// stageSpan is one completed stage entry.
+type stageSpan struct {
+	changeID string
+	stage    string
+	hours    float64
+}
+
+// Build measures the changes in the store within opts' range.
+func Build(projectRoot string, store changes.Store, opts Options) (*Report, error) {
+	var after, through time.Time
+	if opts.From != "" {
+		d, err := time.Parse("2006-01-02", opts.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %q is not a YYYY-MM-DD date", opts.From)
+		}
+		after = d
+	}
+	if opts.To != "" {
+		d, err := time.Parse("2006-01-02", opts.To)
+		if err != nil {
+			return nil, fmt.Errorf("to: %q is not a YYYY-MM-DD date", opts.To)
+		}
+		through = d.Add(24*time.Hour - time.Nanosecond)
+	}
+	if !after.IsZero() && !through.IsZero() && through.Before(after) {
+		return nil, fmt.Errorf("range is empty: %s is after %s", opts.From, opts.To)
+	}
+	inRange := func(t time.Time) bool {
+		return (after.IsZero() || !t.Before(after)) && (through.IsZero() || !t.After(through))
+	}
+	if opts.Outliers <= 0 {
+		opts.Outliers = DefaultOutliers
+	}
+
+	records, err := store.List(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("listing changes: %w", err)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+
+	r := &Report{From: opts.From, To: opts.To, Changes: []ChangeMetrics{}}
+	r.ContextCheck.ScopeChanged = []ScopeChange{}
+	var spans []stageSpan
+	// Stages are ordered by their mean relative position in the flows
+	// (0 = first, 1 = last), since flows differ in length.
+	posSum, posCount := map[string]float64{}, map[string]int{}
+	for i := range records {
+		c := &records[i]
+		for pos, s := range c.Stages {
+			if len(c.Stages) > 1 {
+				posSum[string(s.Name)] += float64(pos) / float64(len(c.Stages)-1)
+			}
+			posCount[string(s.Name)]++
+			start, okStart := parseTime(s.StartedAt)
+			end, okEnd := parseTime(s.CompletedAt)
+			if okStart && okEnd && !end.Before(start) && inRange(end) {
+				spans = append(spans, stageSpan{c.ID, string(s.Name), hours(end.Sub(start))})
+			}
+		}
+
+		if m, ok := changeMetrics(c); ok && inRange(m.CompletedAt) {
+			r.Changes = append(r.Changes, m)
+		}
+		if reason, checked := scopeChange(c, inRange); checked {
+			r.ContextCheck.Checked++
+			if reason != "" {
+				r.ContextCheck.ScopeChanged = append(r.ContextCheck.ScopeChanged, ScopeChange{c.ID, reason})
+			}
+		}
+	}
+	sort.SliceStable(r.Changes, func(i, j int) bool { return r.Changes[i].CompletedAt.Before(r.Changes[j].CompletedAt) })
+
+	lead := make([]float64, len(r.Changes))
+	byType, bySize := map[string][]float64{}, map[string][]float64{}
+	for i, m := range r.Changes {
+		lead[i] = m.LeadTimeHours
+		byType[m.Type] = append(byType[m.Type], m.LeadTimeHours)
+		bySize[m.Size] = append(bySize[m.Size], m.LeadTimeHours)
+	}
+	r.LeadTime = summarize(lead)
+	r.ByType = groups(byType, nil)
+	r.BySize = groups(bySize, []string{string(changes.SizeSmall), string(changes.SizeMedium), string(changes.SizeLarge)})
+
+	byStage := map[string][]float64{}
+	for _, s := range spans {
+		byStage[s.stage] = append(byStage[s.stage], s.hours)
+	}
+	stages := make([]string, 0, len(byStage))
+	for name := range byStage {
+		stages = append(stages, name)
+	}
+	rank := func(name string) float64 { return posSum[name] / float64(posCount[name]) }
+	sort.Slice(stages, func(i, j int) bool {
+		if ri, rj := rank(stages[i]), rank(stages[j]); ri != rj {
+			return ri < rj
+		}
+		return stages[i] < stages[j]
+	})
+	r.Stages = []StageStats{}
+	for _, name := range stages {
+		r.Stages = append(r.Stages, StageStats{Stage: name, Stats: summarize(byStage[name])})
+	}
+
+	r.Outliers = outliers(r, spans, opts.Outliers)
+	return r, nil
+}
+
+// changeMetrics returns the lead time of a completed change, measured
+// from its creation (or the earliest recorded stage start). A rewind
+// restarts a stage's StartedAt, so the first stage alone can't be used.
+func changeMetrics(c *changes.ChangeRecord) (ChangeMetrics, bool) {
+	if !c.Landed() || len(c.Stages) == 0 {
+		return ChangeMetrics{}, false
+	}
+	start, ok := parseTime(c.CreatedAt)
+	for _, s := range c.Stages {
+		if t, tok := parseTime(s.StartedAt); tok && (!ok || t.Before(start)) {
+			start, ok = t, true
+		}
+	}
+	if !ok {
+		return ChangeMetrics{}, false
+	}
+	end, ok := parseTime(c.Stages[len(c.Stages)-1].CompletedAt)
+	if !ok || end.Before(start) {
+		return ChangeMetrics{}, false
+	}
+	return ChangeMetrics{
+		ID:            c.ID,
+		Type:          string(c.Type),
+		Size:          string(c.Size),
+		StartedAt:     start,
+		CompletedAt:   end,
+		LeadTimeHours: hours(end.Sub(start)),
+	}, true
+}
+
+// scopeChange reports whether the change's context-check completed in
+// range (checked) and, if so, why its scope moved afterwards ("" when it
+// didn't).
+func scopeChange(c *changes.ChangeRecord, inRange func(time.Time) bool) (reason string, checked bool) {
+	idx := slices.IndexFunc(c.Stages, func(s changes.StageEntry) bool { return s.Name == changes.StageContextCheck })
+	if idx < 0 {
+		return "", false
+	}
+	checkedAt, ok := parseTime(c.Stages[idx].CompletedAt)
+	if !ok || !inRange(checkedAt) {
+		return "", false
+	}
+
+	var reasons []string
+	for _, rs := range c.Resizes {
+		at, ok := parseTime(rs.ResizedAt)
+		if rs.AtStage == changes.StageContextCheck || (ok && !at.Before(checkedAt)) {
+			reasons = append(reasons, fmt.Sprintf("resized %s → %s", rs.From, rs.To))
+		}
+	}
+	for _, s := range c.Stages[:idx] {
+		if s.Revision > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s rewritten", s.Name))
+		}
+	}
+	if c.Status == changes.StatusAbandoned {
+		reasons = append(reasons, "abandoned")
+	}
+	return strings.Join(reasons, ", "), true
+}
+
+// groups summarizes durations by key, in order (unlisted keys sorted
+// after it).
+func groups(byKey map[string][]float64, order []string) []GroupStats {
+	keys := make([]string, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := slices.Index(order, keys[i]), slices.Index(order, keys[j])
+		if ri < 0 {
+			ri = len(order)
+		}
+		if rj < 0 {
+			rj = len(order)
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	out := []GroupStats{}
+	for _, k := range keys {
+		out = append(out, GroupStats{Key: k, LeadTime: summarize(byKey[k])})
+	}
+	return out
+}
+
+// outliers ranks change lead times and stage durations by how many
+// times their median they took, largest first. Only entries above the
+// median qualify.
+func outliers(r *Report, spans []stageSpan, limit int) []Outlier {
+	var out []Outlier
+	add := func(changeID, stage string, h, median float64) {
+		if median > 0 && h > median {
+			out = append(out, Outlier{ChangeID: changeID, Stage: stage, Hours: h, MedianHours: median, Factor: round(h / median)})
+		}
+	}
+	for _, m := range r.Changes {
+		add(m.ID, "", m.LeadTimeHours, r.LeadTime.MedianHours)
+	}
+	medians := map[string]float64{}
+	for _, s := range r.Stages {
+		medians[s.Stage] = s.MedianHours
+	}
+	for _, s := range spans {
+		add(s.changeID, s.stage, s.hours, medians[s.stage])
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Factor != out[j].Factor {
+			return out[i].Factor > out[j].Factor
+		}
+		return out[i].Hours > out[j].Hours
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	if out == nil {
+		out = []Outlier{}
+	}
+	return out
+}
+
+// summarize computes the stats of a set of durations in hours.
+func summarize(values []float64) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return Stats{
+		Count:       n,
+		MedianHours: round(median),
+		MeanHours:   round(sum / float64(n)),
+		MaxHours:    round(sorted[n-1]),
+	}
+}
+
+// parseTime parses an RFC 3339 timestamp from a change record.
+func parseTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// hours converts a duration to hours, rounded to two decimals.
+func hours(d time.Duration) float64 {
+	return round(d.Hours())
+}
+
+// round rounds to two decimals.
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
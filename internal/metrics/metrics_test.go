@@ -0,0 +1,218 @@
+package metrics
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+)
+
+var base = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// at returns base plus h hours as an RFC 3339 timestamp.
+func at(h float64) string {
+	return base.Add(time.Duration(h * float64(time.Hour))).Format(time.RFC3339)
+}
+
+// span is a stage that ran from start to end hours after base; end < 0
+// leaves it unfinished.
+type span struct {
+	stage      changes.ChangeStage
+	start, end float64
+}
+
+// seedChange stores a change with the given stages.
+func seedChange(t *testing.T, root, id string, ct changes.ChangeType, size changes.ChangeSize, status changes.ChangeStatus, spans ...span) *changes.ChangeRecord {
+	t.Helper()
+	c := &changes.ChangeRecord{ID: id, Type: ct, Size: size, Status: status, CreatedAt: at(spans[0].start)}
+	for _, s := range spans {
+		entry := changes.StageEntry{Name: s.stage, Status: "completed", StartedAt: at(s.start)}
+		if s.end >= 0 {
+			entry.CompletedAt = at(s.end)
+		} else {
+			entry.Status = "in_progress"
+		}
+		c.Stages = append(c.Stages, entry)
+	}
+	c.CurrentStage = c.Stages[len(c.Stages)-1].Name
+	if err := changes.NewFileStore().Create(root, c); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	return c
+}
+
+func seedProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	// fast: 4h lead time.
+	seedChange(t, root, "fast", changes.TypeFix, changes.SizeSmall, changes.StatusCompleted,
+		span{changes.StageDescribe, 0, 1}, span{changes.StageContextCheck, 1, 2}, span{changes.StageVerify, 2, 4})
+	// slow: 50h lead time, resized after context-check.
+	slow := seedChange(t, root, "slow", changes.TypeFeature, changes.SizeMedium, changes.StatusArchived,
+		span{changes.StageDescribe, 0, 2}, span{changes.StageContextCheck, 2, 3}, span{changes.StageSpec, 3, 45}, span{changes.StageVerify, 45, 50})
+	slow.Resizes = []changes.SizeChange{{From: changes.SizeSmall, To: changes.SizeMedium, AtStage: changes.StageSpec, ResizedAt: at(4)}}
+	if err := changes.NewFileStore().Save(root, slow); err != nil {
+		t.Fatal(err)
+	}
+	// mid: 6h lead time.
+	seedChange(t, root, "mid", changes.TypeFix, changes.SizeSmall, changes.StatusCompleted,
+		span{changes.StageDescribe, 0, 1}, span{changes.StageContextCheck, 1, 3}, span{changes.StageVerify, 3, 6})
+	// in flight, its describe rewritten after context-check.
+	wip := seedChange(t, root, "wip", changes.TypeFeature, changes.SizeSmall, changes.StatusActive,
+		span{changes.StageDescribe, 0, 1}, span{changes.StageContextCheck, 1, 2}, span{changes.StageTasks, 2, -1})
+	wip.Stages[0].Revision = 1
+	if err := changes.NewFileStore().Save(root, wip); err != nil {
+		t.Fatal(err)
+	}
+	// completed before the range used below.
+	seedChange(t, root, "old", changes.TypeFix, changes.SizeLarge, changes.StatusCompleted,
+		span{changes.StageDescribe, -200, -199}, span{changes.StageVerify, -199, -190})
+	return root
+}
+
+func TestBuild(t *testing.T) {
+	root := seedProject(t)
+
+	r, err := Build(root, changes.NewFileStore(), Options{From: "2026-03-01", To: "2026-03-31", Outliers: 3})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(r.Changes) != 3 || r.Changes[0].ID != "fast" || r.Changes[2].ID != "slow" || r.Changes[2].LeadTimeHours != 50 {
+		t.Errorf("changes = %+v", r.Changes)
+	}
+	if r.LeadTime != (Stats{Count: 3, MedianHours: 6, MeanHours: 20, MaxHours: 50}) {
+		t.Errorf("lead time = %+v", r.LeadTime)
+	}
+
+	var stages []string
+	for _, s := range r.Stages {
+		stages = append(stages, s.Stage)
+	}
+	if strings.Join(stages, ",") != "describe,context-check,spec,verify" {
+		t.Errorf("stages = %v", stages)
+	}
+	if cc := r.Stages[1]; cc.Count != 4 || cc.MedianHours != 1 || cc.MaxHours != 2 {
+		t.Errorf("context-check stats = %+v", cc)
+	}
+
+	if len(r.ByType) != 2 || r.ByType[0].Key != "feature" || r.ByType[1].LeadTime.Count != 2 {
+		t.Errorf("by type = %+v", r.ByType)
+	}
+	if len(r.BySize) != 2 || r.BySize[0].Key != "small" || r.BySize[1].Key != "medium" {
+		t.Errorf("by size = %+v", r.BySize)
+	}
+
+	if r.ContextCheck.Checked != 4 || r.ContextCheck.Percent() != 50 {
+		t.Errorf("context check = %+v", r.ContextCheck)
+	}
+	reasons := map[string]string{}
+	for _, s := range r.ContextCheck.ScopeChanged {
+		reasons[s.ChangeID] = s.Reason
+	}
+	if reasons["slow"] != "resized small → medium" || reasons["wip"] != "describe rewritten" {
+		t.Errorf("scope changes = %+v", r.ContextCheck.ScopeChanged)
+	}
+
+	if len(r.Outliers) != 3 {
+		t.Fatalf("outliers = %+v", r.Outliers)
+	}
+	if o := r.Outliers[0]; o.ChangeID != "slow" || o.Stage != "" || o.Factor != 8.33 {
+		t.Errorf("top outlier = %+v", o)
+	}
+}
+
+func TestBuild_RewoundToFirstStage(t *testing.T) {
+	root := t.TempDir()
+	c := seedChange(t, root, "redo", changes.TypeFix, changes.SizeSmall, changes.StatusActive,
+		span{changes.StageDescribe, 0, 1}, span{changes.StageContextCheck, 1, 2}, span{changes.StageVerify, 2, -1})
+
+	// Rewound to describe at hour 30, then driven to completion.
+	if err := changes.Rewind(c, changes.StageDescribe); err != nil {
+		t.Fatalf("Rewind failed: %v", err)
+	}
+	c.Stages[0].StartedAt = at(30) // Rewind stamps the current time
+	c.Stages[0].Status, c.Stages[0].CompletedAt = "completed", at(31)
+	c.Stages[1].Status, c.Stages[1].StartedAt, c.Stages[1].CompletedAt = "completed", at(31), at(32)
+	c.Stages[2].Status, c.Stages[2].StartedAt, c.Stages[2].CompletedAt = "completed", at(32), at(40)
+	c.Status = changes.StatusCompleted
+	if err := changes.NewFileStore().Save(root, c); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Build(root, changes.NewFileStore(), Options{})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(r.Changes) != 1 || r.Changes[0].LeadTimeHours != 40 || !r.Changes[0].StartedAt.Equal(base) {
+		t.Errorf("lead time should run from creation, not the rewind: %+v", r.Changes)
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	root := t.TempDir()
+	for _, opts := range []Options{{From: "March"}, {To: "2026-13-01"}, {From: "2026-03-05", To: "2026-03-01"}} {
+		if _, err := Build(root, changes.NewFileStore(), opts); err == nil {
+			t.Errorf("Build(%+v) should fail", opts)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	root := seedProject(t)
+	r, err := Build(root, changes.NewFileStore(), Options{From: "2026-03-01"})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	md, err := r.Render(FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"**Range:** 2026-03-01 onwards",
+		"3 change(s) completed — median 6.0h · mean 20.0h · max 2.1d",
+		"| `slow` | feature | medium | 2026-03-04 | 2.1d |",
+		"| context-check | 4 | 1.0h | 1.2h | 2.0h |",
+		"| Type | Completed | Median lead time | Max lead time |",
+		"| fix | 2 | 5.0h | 6.0h |",
+		"2 of 4 change(s) changed scope after context-check (50%).",
+		"- ⚠️ `wip` — describe rewritten",
+		"| `slow` | (lead time) | 2.1d | 6.0h | 8.3× |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	out, err := r.Render(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(decoded.Changes) != 3 || decoded.Stages[0].Stage != "describe" || !strings.Contains(out, `"median_hours"`) {
+		t.Errorf("decoded = %+v", decoded)
+	}
+
+	if _, err := r.Render("yaml"); err == nil {
+		t.Error("unknown format should fail")
+	}
+
+	empty, _ := Build(t.TempDir(), changes.NewFileStore(), Options{})
+	if md := empty.Markdown(); !strings.Contains(md, "**Range:** all time") || !strings.Contains(md, "⬜ No changes completed") {
+		t.Errorf("empty report:\n%s", md)
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	for h, want := range map[float64]string{0.25: "15m", 1: "1.0h", 47.5: "47.5h", 72: "3.0d"} {
+		if got := FormatHours(h); got != want {
+			t.Errorf("FormatHours(%v) = %q, want %q", h, got, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Formats a report renders as.
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// Render renders the report in the given format.
+func (r *Report) Render(format string) (string, error) {
+	switch format {
+	case "", FormatMarkdown:
+		return r.Markdown(), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("encoding metrics: %w", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unknown format %q: must be markdown or json", format)
+	}
+}
+
+// Markdown renders the report as a markdown document.
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# Change Metrics\n\n")
+	fmt.Fprintf(&sb, "**Range:** %s\n\n", r.rangeLabel())
+
+	sb.WriteString("## Lead Time\n\n")
+	if len(r.Changes) == 0 {
+		sb.WriteString("⬜ No changes completed in this range.\n\n")
+	} else {
+		fmt.Fprintf(&sb, "%d change(s) completed — %s\n\n", len(r.Changes), statsLine(r.LeadTime))
+		sb.WriteString("| Change | Type | Size | Completed | Lead time |\n")
+		sb.WriteString("|--------|------|------|-----------|-----------|\n")
+		for _, m := range r.Changes {
+			fmt.Fprintf(&sb, "| `%s` | %s | %s | %s | %s |\n",
+				m.ID, m.Type, m.Size, m.CompletedAt.UTC().Format("2006-01-02"), FormatHours(m.LeadTimeHours))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Time in Stage\n\n")
+	if len(r.Stages) == 0 {
+		sb.WriteString("⬜ No stages completed in this range.\n\n")
+	} else {
+		sb.WriteString("| Stage | Completed | Median | Mean | Max |\n")
+		sb.WriteString("|-------|-----------|--------|------|-----|\n")
+		for _, s := range r.Stages {
+			fmt.Fprintf(&sb, "| %s | %d | %s | %s | %s |\n",
+				s.Stage, s.Count, FormatHours(s.MedianHours), FormatHours(s.MeanHours), FormatHours(s.MaxHours))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(r.Changes) > 0 {
+		sb.WriteString("## Throughput\n\n")
+		sb.WriteString(groupTable("Type", r.ByType))
+		sb.WriteString("\n")
+		sb.WriteString(groupTable("Size", r.BySize))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Context-Check Outcomes\n\n")
+	cc := r.ContextCheck
+	if cc.Checked == 0 {
+		sb.WriteString("⬜ No context-check completed in this range.\n\n")
+	} else {
+		fmt.Fprintf(&sb, "%d of %d change(s) changed scope after context-check (%d%%).\n",
+			len(cc.ScopeChanged), cc.Checked, cc.Percent())
+		if len(cc.ScopeChanged) > 0 {
+			sb.WriteString("\n")
+			for _, s := range cc.ScopeChanged {
+				fmt.Fprintf(&sb, "- ⚠️ `%s` — %s\n", s.ChangeID, s.Reason)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Outliers\n\n")
+	if len(r.Outliers) == 0 {
+		sb.WriteString("⬜ Nothing took longer than its median.\n")
+	} else {
+		sb.WriteString("| Change | Stage | Took | Median | × median |\n")
+		sb.WriteString("|--------|-------|------|--------|----------|\n")
+		for _, o := range r.Outliers {
+			stage := o.Stage
+			if stage == "" {
+				stage = "(lead time)"
+			}
+			fmt.Fprintf(&sb, "| `%s` | %s | %s | %s | %.1f× |\n",
+				o.ChangeID, stage, FormatHours(o.Hours), FormatHours(o.MedianHours), o.Factor)
+		}
+	}
+	return sb.String()
+}
+
+// rangeLabel renders the report's date range.
+func (r *Report) rangeLabel() string {
+	switch {
+	case r.From == "" && r.To == "":
+		return "all time"
+	case r.From == "":
+		return "through " + r.To
+	case r.To == "":
+		return r.From + " onwards"
+	default:
+		return r.From + " → " + r.To
+	}
+}
+
+// statsLine renders stats as "median 5.0h · mean 6.2h · max 2.1d".
+func statsLine(s Stats) string {
+	return fmt.Sprintf("median %s · mean %s · max %s",
+		FormatHours(s.MedianHours), FormatHours(s.MeanHours), FormatHours(s.MaxHours))
+}
+
+// groupTable renders throughput and lead time by type or size.
+func groupTable(label string, groups []GroupStats) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "| %s | Completed | Median lead time | Max lead time |\n", label)
+	sb.WriteString("|------|-----------|------------------|---------------|\n")
+	for _, g := range groups {
+		fmt.Fprintf(&sb, "| %s | %d | %s | %s |\n",
+			g.Key, g.LeadTime.Count, FormatHours(g.LeadTime.MedianHours), FormatHours(g.LeadTime.MaxHours))
+	}
+	return sb.String()
+}
+
+// FormatHours renders a duration in hours compactly: minutes under an
+// hour, hours under two days, days beyond.
+func FormatHours(h float64) string {
+	switch {
+	case h < 1:
+		return fmt.Sprintf("%.0fm", h*60)
+	case h < 48:
+		return fmt.Sprintf("%.1fh", h)
+	default:
+		return fmt.Sprintf("%.1fd", h/24)
+	}
+}
@@ -81,7 +81,8 @@ When context-check is the current stage:
    - **Non-breaking**: adds new behavior without affecting existing
    - **Patch**: internal change, no behavior modification
 
-6. Generate the context-check.md content and call sdd_change_advance
+6. Call sdd_change_advance with artifacts_checked and conflicts (plus
+   related_changes and impact when relevant) to render context-check.md
 
 **If critical issues are found**:
 - Present them to the user with specific questions
@@ -136,6 +137,27 @@ For medium/large changes (design stage exists), cross-check:
 - Check if the task breakdown introduces new coupling not documented in the design
 - Verify no God Class patterns emerge from combining multiple tasks into one component
 
+## Spec Stage — Delta Sections
+
+A change's spec.md stays in its change directory, so write what it does to
+the project-level docs/requirements.md and docs/business-rules.md as delta
+sections. Hoofy previews the resulting diff when the spec is saved and merges
+it when the change completes:
+
+- **## ADDED Requirements** — new requirements with change-local IDs
+  (FR-001, NFR-001). They get the next free project IDs on merge. Optional
+  "### Should Have"-style subheadings pick the priority section (default
+  Must Have).
+- **## MODIFIED Requirements** — existing project IDs with their new text.
+- **## REMOVED Requirements** — existing project IDs, optionally with the
+  reason. They are struck through, not deleted.
+- **## ADDED Business Rules** — "When ... Then ..." rules appended to the
+  Constraints section.
+
+Every merged line records the change that added, modified or removed it.
+MODIFIED and REMOVED IDs must exist in requirements.md — check the preview
+warnings before moving on.
+
 ## Wave Assignments in Tasks Stage
 
 When writing content for the **tasks** stage (both project pipeline and change pipeline),
@@ -147,6 +169,12 @@ include execution wave assignments to enable parallel execution:
 - Format as a clear section in the tasks content (e.g., "## Execution Waves")
 - For the project pipeline, use the wave_assignments parameter in sdd_create_tasks
 
+Hoofy parses every ` + "`### TASK-NNN`" + ` block and its ` + "`**Dependencies**:`" + ` line
+(` + "`None`" + ` or a list of TASK IDs) into a task graph. Dependency cycles and references
+to undefined tasks are rejected. Hoofy computes the waves itself, flags waves you
+wrote that contradict the dependencies, and saves the graph as ` + "`tasks.json`" + `
+next to tasks.md. When a warning says a wave is wrong, the computed waves win.
+
 ## Wave Execution — Multi-Agent Orchestration
 
 When the user asks you to IMPLEMENT tasks that have wave assignments, use this strategy
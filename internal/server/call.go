@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CallTool dispatches a tool call in-process through the same JSON-RPC
+// path an MCP client would use, so middleware and panic recovery apply.
+// A tool-level failure is returned as a result with IsError set; the
+// error return is reserved for protocol problems (unknown tool, bad args).
+func CallTool(ctx context.Context, s *server.MCPServer, name string, args map[string]any) (*mcp.CallToolResult, error) {
+	msg, err := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      1,
+		"method":  string(mcp.MethodToolsCall),
+		"params": map[string]any{
+			"name":      name,
+			"arguments": args,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding call: %w", err)
+	}
+
+	switch resp := s.HandleMessage(ctx, msg).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := resp.Result.(*mcp.CallToolResult)
+		if !ok {
+			return nil, fmt.Errorf("unexpected result type %T", resp.Result)
+		}
+		return result, nil
+	case mcp.JSONRPCError:
+		return nil, fmt.Errorf("%s", resp.Error.Message)
+	default:
+		return nil, fmt.Errorf("unexpected response type %T", resp)
+	}
+}
+
+// ToolDefinitions returns every registered tool, sorted by name.
+func ToolDefinitions(s *server.MCPServer) []mcp.Tool {
+	registered := s.ListTools()
+	defs := make([]mcp.Tool, 0, len(registered))
+	for _, t := range registered {
+		defs = append(defs, t.Tool)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// ParseToolArg converts a "key=value" pair into a typed argument using
+// the tool's input schema: numbers, booleans, arrays and objects are
+// parsed as JSON, everything else stays a string. Without a schema
+// entry the value is kept as a string.
+func ParseToolArg(tool mcp.Tool, pair string) (string, any, error) {
+	key, raw, found := strings.Cut(pair, "=")
+	if !found || key == "" {
+		return "", nil, fmt.Errorf("invalid argument %q: expected key=value", pair)
+	}
+
+	prop, _ := tool.InputSchema.Properties[key].(map[string]any)
+	typ, _ := prop["type"].(string)
+
+	switch typ {
+	case "number":
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("argument %s: %q is not a number", key, raw)
+		}
+		return key, v, nil
+	case "integer":
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("argument %s: %q is not an integer", key, raw)
+		}
+		return key, v, nil
+	case "boolean":
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", nil, fmt.Errorf("argument %s: %q is not a boolean", key, raw)
+		}
+		return key, v, nil
+	case "array", "object":
+		var v any
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			// Allow comma-separated shorthand for arrays: --arg tags=a,b
+			if typ == "array" {
+				return key, splitComma(raw), nil
+			}
+			return "", nil, fmt.Errorf("argument %s: invalid JSON: %w", key, err)
+		}
+		return key, v, nil
+	default:
+		return key, raw, nil
+	}
+}
+
+func splitComma(s string) []any {
+	var out []any
+	for _, part := range strings.Split(s, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// ResultText joins the text content of a tool result. Non-text content
+// (images, resources) is summarized by type.
+func ResultText(r *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range r.Content {
+		switch v := c.(type) {
+		case mcp.TextContent:
+			parts = append(parts, v.Text)
+		case *mcp.TextContent:
+			parts = append(parts, v.Text)
+		default:
+			parts = append(parts, fmt.Sprintf("[%T content omitted — use --json]", c))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func newTestServer(t *testing.T) *server.MCPServer {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	cfg := userconfig.Default()
+	cfg.Memory.DataDir = t.TempDir()
+	s, cleanup, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(cleanup)
+	return s
+}
+
+func TestCallTool_DispatchesToHandler(t *testing.T) {
+	srv := newTestServer(t)
+
+	result, err := CallTool(context.Background(), srv, "mem_stats", nil)
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("mem_stats returned error: %s", ResultText(result))
+	}
+	if !strings.Contains(ResultText(result), "Observations") {
+		t.Errorf("unexpected mem_stats output:\n%s", ResultText(result))
+	}
+}
+
+func TestCallTool_ToolErrorIsResult(t *testing.T) {
+	srv := newTestServer(t)
+
+	// No active change in an empty directory: the tool reports an error
+	// result rather than a protocol error.
+	result, err := CallTool(context.Background(), srv, "sdd_change_status", nil)
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !result.IsError {
+		t.Errorf("expected IsError, got %s", ResultText(result))
+	}
+}
+
+func TestCallTool_UnknownTool(t *testing.T) {
+	srv := newTestServer(t)
+	if _, err := CallTool(context.Background(), srv, "no_such_tool", nil); err == nil {
+		t.Fatal("expected error for unknown tool")
+	}
+}
+
+func TestToolDefinitions_Sorted(t *testing.T) {
+	srv := newTestServer(t)
+	defs := ToolDefinitions(srv)
+	if len(defs) == 0 {
+		t.Fatal("no tools registered")
+	}
+	if !sort.SliceIsSorted(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name }) {
+		t.Error("definitions are not sorted by name")
+	}
+}
+
+func TestParseToolArg_UsesSchemaTypes(t *testing.T) {
+	tool := mcp.NewTool("demo",
+		mcp.WithString("title"),
+		mcp.WithNumber("limit"),
+		mcp.WithBoolean("hard_delete"),
+		mcp.WithArray("tags"),
+	)
+
+	tests := []struct {
+		pair    string
+		key     string
+		want    any
+		wantErr bool
+	}{
+		{"title=42", "title", "42", false},
+		{"limit=5", "limit", float64(5), false},
+		{"hard_delete=true", "hard_delete", true, false},
+		{"undeclared=x=y", "undeclared", "x=y", false},
+		{"limit=lots", "", nil, true},
+		{"novalue", "", nil, true},
+	}
+	for _, tt := range tests {
+		key, got, err := ParseToolArg(tool, tt.pair)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseToolArg(%q) error = %v, wantErr %v", tt.pair, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (key != tt.key || got != tt.want) {
+			t.Errorf("ParseToolArg(%q) = %q, %#v; want %q, %#v", tt.pair, key, got, tt.key, tt.want)
+		}
+	}
+
+	_, tags, err := ParseToolArg(tool, "tags=a, b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list, ok := tags.([]any); !ok || len(list) != 2 || list[1] != "b" {
+		t.Errorf("tags = %#v, want [a b]", tags)
+	}
+}
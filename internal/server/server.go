@@ -6,6 +6,7 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/HendryAvila/Hoofy/internal/resources"
 	"github.com/HendryAvila/Hoofy/internal/templates"
 	"github.com/HendryAvila/Hoofy/internal/tools"
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -30,27 +32,53 @@ var Version = "dev"
 // The returned cleanup function closes the memory store's database
 // connection and must be called on shutdown (typically via defer).
 // It is always non-nil and safe to call even if memory init failed.
-func New() (*server.MCPServer, func(), error) {
+//
+// User settings (~/.hoofy/config.json) drive the memory store, the
+// default docs location for new projects, and which tools are exposed.
+// Extra server options (e.g. tool middleware installed by Serve) are
+// appended after Hoofy's defaults.
+func New(cfg userconfig.Config, extra ...server.ServerOption) (*server.MCPServer, func(), error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, noop, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// --- Create shared dependencies ---
 
+	if err := config.SetDefaultDocsDir(cfg.Docs.Dir); err != nil {
+		return nil, noop, err
+	}
 	store := config.NewFileStore()
 
-	renderer, err := templates.NewRenderer()
+	// Project-defined change types and flows (docs/hoofy-flows.json) must be
+	// in place before sdd_change builds its type enum. A broken file is not
+	// fatal: the built-in flows are used and the change tools report it.
+	if err := tools.LoadProjectFlows(); err != nil {
+		log.Printf("WARNING: change flows: %v — using built-in flows", err)
+	}
+
+	// Template overrides (docs/.hoofy/templates) are checked against their
+	// data structs here. Like the flows file, a broken override is not
+	// fatal: the embedded templates are used and doctor reports it.
+	renderer, err := tools.LoadProjectTemplates()
 	if err != nil {
-		return nil, noop, fmt.Errorf("creating template renderer: %w", err)
+		log.Printf("WARNING: template overrides: %v — using built-in templates", err)
+		if renderer, err = templates.NewRenderer(); err != nil {
+			return nil, noop, fmt.Errorf("creating template renderer: %w", err)
+		}
 	}
 
 	// --- Create the MCP server ---
 
-	s := server.NewMCPServer(
-		"hoofy",
-		Version,
+	opts := []server.ServerOption{
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(false, true),
 		server.WithPromptCapabilities(true),
 		server.WithRecovery(),
 		server.WithInstructions(serverInstructions()),
-	)
+	}
+	opts = append(opts, extra...)
+
+	s := server.NewMCPServer("hoofy", Version, opts...)
 
 	// --- Register SDD tools ---
 
@@ -110,15 +138,27 @@ func New() (*server.MCPServer, func(), error) {
 	changeTool := tools.NewChangeTool(changeStore)
 	s.AddTool(changeTool.Definition(), changeTool.Handle)
 
-	changeAdvanceTool := tools.NewChangeAdvanceTool(changeStore)
+	changeAdvanceTool := tools.NewChangeAdvanceTool(changeStore, renderer)
 	s.AddTool(changeAdvanceTool.Definition(), changeAdvanceTool.Handle)
 
 	changeStatusTool := tools.NewChangeStatusTool(changeStore)
 	s.AddTool(changeStatusTool.Definition(), changeStatusTool.Handle)
 
+	changeManageTool := tools.NewChangeManageTool(changeStore)
+	s.AddTool(changeManageTool.Definition(), changeManageTool.Handle)
+
 	adrTool := tools.NewADRTool(changeStore)
 	s.AddTool(adrTool.Definition(), adrTool.Handle)
 
+	changelogTool := tools.NewChangelogTool(changeStore)
+	s.AddTool(changelogTool.Definition(), changelogTool.Handle)
+
+	taskTool := tools.NewTaskTool(changeStore)
+	s.AddTool(taskTool.Definition(), taskTool.Handle)
+
+	metricsTool := tools.NewMetricsTool(changeStore)
+	s.AddTool(metricsTool.Definition(), metricsTool.Handle)
+
 	// --- Register memory tools ---
 	//
 	// Memory is an independent subsystem: if it fails to initialize,
@@ -127,7 +167,16 @@ func New() (*server.MCPServer, func(), error) {
 	// spec-driven development.
 
 	cleanup := noop
-	memStore, memErr := memory.New(memory.DefaultConfig())
+	// memStore stays a nil interface when memory is unavailable — a
+	// typed nil *memory.Store would defeat the tools' nil checks.
+	var memStore memory.Backend
+	memErr := errors.New("disabled in user configuration (memory.disabled)")
+	if !cfg.Memory.Disabled {
+		var sqliteStore *memory.Store
+		if sqliteStore, memErr = memory.New(cfg.MemoryStoreConfig()); memErr == nil {
+			memStore = sqliteStore
+		}
+	}
 
 	// Context-check tool registered unconditionally — handles nil memStore
 	// internally by skipping memory search (ADR-001: scanner, not analyzer).
@@ -145,10 +194,13 @@ func New() (*server.MCPServer, func(), error) {
 
 	// Review tool registered unconditionally — standalone spec-aware
 	// code review, generates checklists from project specs.
-	reviewTool := tools.NewReviewTool(memStore)
+	reviewTool := tools.NewReviewTool(memStore, changeStore)
 	s.AddTool(reviewTool.Definition(), reviewTool.Handle)
 	if memErr != nil {
 		log.Printf("WARNING: memory subsystem disabled: %v", memErr)
+		if !cfg.Memory.Disabled {
+			log.Printf("WARNING: mem_* tools are unavailable — run `hoofy doctor` for a diagnosis")
+		}
 	} else {
 		cleanup = func() {
 			if err := memStore.Close(); err != nil {
@@ -176,12 +228,13 @@ func New() (*server.MCPServer, func(), error) {
 		// Wire change pipeline bridge — saves stage completions and ADRs
 		// to memory for cross-session awareness.
 		changeAdvanceTool.SetBridge(bridge)
+		changeManageTool.SetBridge(bridge)
 		adrTool.SetBridge(bridge)
 
 		// --- Register explore tool (SDD + Memory hybrid) ---
 		//
 		// sdd_explore is a standalone tool that captures pre-pipeline context.
-		// It depends only on memory.Store, not on config or change stores.
+		// It depends only on memory.Backend, not on config or change stores.
 		// Registered here because it requires memory to be available.
 		exploreTool := tools.NewExploreTool(memStore)
 		s.AddTool(exploreTool.Definition(), exploreTool.Handle)
@@ -212,15 +265,32 @@ func New() (*server.MCPServer, func(), error) {
 	resourceHandler := resources.NewHandler(store)
 	s.AddResource(resourceHandler.StatusResource(), resourceHandler.HandleStatus)
 
+	// --- Apply tool-surface settings ---
+
+	disableTools(s, cfg.Tools.Disabled)
+
 	return s, cleanup, nil
 }
 
+// disableTools removes the named tools after registration. Unknown
+// names are reported but not fatal — a memory tool may legitimately be
+// absent when the memory subsystem is disabled.
+func disableTools(s *server.MCPServer, names []string) {
+	for _, name := range names {
+		if s.GetTool(name) == nil {
+			log.Printf("WARNING: tools.disabled: unknown tool %q", name)
+			continue
+		}
+		s.DeleteTools(name)
+	}
+}
+
 // noop is a no-op cleanup function used as the default when memory
 // is disabled or hasn't been initialized.
 func noop() {}
 
 // registerMemoryTools registers memory MCP tools with the server.
-func registerMemoryTools(s *server.MCPServer, ms *memory.Store) {
+func registerMemoryTools(s *server.MCPServer, ms memory.Backend) {
 	// --- Session lifecycle ---
 	sessionTool := memtools.NewSessionTool(ms)
 	s.AddTool(sessionTool.Definition(), sessionTool.Handle)
@@ -420,7 +490,8 @@ adaptive change pipeline instead of the full 9-stage SDD pipeline.
 Each change has a TYPE and SIZE that determine the pipeline stages.
 ALL flows include a mandatory context-check stage.
 
-**Types**: feature, fix, refactor, enhancement
+**Types**: feature, fix, refactor, enhancement — plus any types the project
+declares in docs/hoofy-flows.json (the sdd_change type enum lists them all)
 **Sizes**: small (4 stages), medium (5 stages), large (6-7 stages)
 
 ### Stage Flows by Type and Size
@@ -445,25 +516,68 @@ ALL flows include a mandatory context-check stage.
 - medium: charter → context-check → spec → tasks → verify
 - large: charter → context-check → spec → clarify → design → tasks → verify
 
+Projects can override these flows per type and size in docs/hoofy-flows.json,
+and add custom stages (e.g. threat-model). Custom flows always include
+context-check and end with verify — follow the stages the change was created with.
+
 ### Change Pipeline Workflow
 
 1. **Create a change**: Call sdd_change with type, size, and description
-   - Only ONE active change at a time
+   - Only ONE active change per git branch (the change is bound to the
+     branch checked out when it is created)
    - The tool creates a directory at docs/changes/<slug>/
+   - When a feature is split into changes that must land in order, pass
+     depends_on (and/or blocks) with the other change IDs; sdd_change warns
+     when prerequisites haven't landed and sdd_change_status shows the queue.
+     Add or fix links later with sdd_change_manage (action: link / unlink)
 
 2. **Work through stages**: For each stage, generate content and call
    sdd_change_advance with the content
+   - Built-in stages take structured fields instead of raw content (e.g.
+     summary + motivation for describe, artifacts_checked + conflicts for
+     context-check); the tool renders them through the stage's template.
+     A missing-field error lists the fields the current stage expects
    - The tool writes the content as <stage>.md in the change directory
+     (custom stages may use another filename from docs/hoofy-flows.json)
    - It advances the state machine to the next stage
+   - Content is validated first: context-check must name the artifacts it
+     checked, spec needs requirement IDs (FR-001), tasks need TASK-NNN items
+     with acceptance criteria, verify must mention every task. Fix violations
+     and retry; use force: true only when the user accepts the gap (it is
+     recorded in change.json)
+   - Write spec changes to the project requirements as ## ADDED / MODIFIED /
+     REMOVED Requirements sections: the diff is previewed when the spec is
+     saved and merged into docs/requirements.md when the change completes
    - When the final stage (verify) is completed, the change is marked done
 
-3. **Check progress**: Call sdd_change_status to see the current state
+3. **Check progress**: Call sdd_change_status to see the current state.
+   While implementing, call sdd_task (start / done / block) for each
+   TASK-NNN; sdd_task list shows progress and the next wave to work on
 
 4. **Capture decisions**: Call sdd_adr at any time to record an ADR
 
+5. **Manage the lifecycle**: Call sdd_change_manage to archive a completed
+   change, abandon an unwanted one (with a reason), pause/switch between
+   changes, reopen one from docs/history/, or list changes by status/type
+
+6. **Go back**: If a later stage shows an earlier one was wrong (e.g. design
+   reveals a spec gap), call sdd_change_manage with action="rewind" and the
+   stage to reopen. Later stages become stale and must be redone before
+   verify; the previous artifacts are kept under revisions/. If the change
+   turns out bigger (or smaller) than sized, call sdd_change_manage with
+   action="resize" and the new size: missing stages are inserted after the
+   current one and completed work is kept
+
+7. **Release notes**: Call sdd_changelog to render completed changes as a
+   Keep a Changelog section (write: true merges them into CHANGELOG.md
+   under Unreleased)
+
+8. **Look back**: Call sdd_metrics for lead time, time in each stage,
+   throughput by type/size, and outliers over a date range
+
 ### Important Rules
-- Only ONE active change at a time
-- Complete or archive a change before starting a new one
+- Only ONE active change per git branch
+- Complete, pause or abandon the active change before starting a new one
 - Generate REAL content for each stage — no placeholders
 - All flows end with verify — use it to validate the change
 - ADRs can be captured at any time during a change
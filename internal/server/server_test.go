@@ -0,0 +1,53 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
+)
+
+func TestNew_AppliesUserConfig(t *testing.T) {
+	cfg := userconfig.Default()
+	cfg.Memory.DataDir = t.TempDir()
+	cfg.Tools.Disabled = []string{"sdd_audit", "no_such_tool"}
+
+	s, cleanup, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer cleanup()
+
+	if s.GetTool("sdd_audit") != nil {
+		t.Error("sdd_audit should be disabled")
+	}
+	if s.GetTool("sdd_init_project") == nil {
+		t.Error("sdd_init_project should still be registered")
+	}
+	if s.GetTool("mem_save") == nil {
+		t.Error("mem_save should be registered when memory is enabled")
+	}
+}
+
+func TestNew_MemoryDisabled(t *testing.T) {
+	cfg := userconfig.Default()
+	cfg.Memory.Disabled = true
+
+	s, cleanup, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer cleanup()
+
+	if s.GetTool("mem_save") != nil {
+		t.Error("mem_save should not be registered when memory is disabled")
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := userconfig.Default()
+	cfg.Memory.MaxSearchResults = 0
+
+	if _, _, err := New(cfg); err == nil {
+		t.Fatal("expected error for invalid configuration")
+	}
+}
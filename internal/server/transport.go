@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/userconfig"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Transport identifies how the MCP server talks to its clients.
+type Transport string
+
+const (
+	// TransportStdio serves a single client over stdin/stdout (default).
+	// Each editor spawns its own hoofy process.
+	TransportStdio Transport = "stdio"
+	// TransportHTTP serves the MCP streamable HTTP transport, so several
+	// agents can share one long-lived server.
+	TransportHTTP Transport = "http"
+	// TransportSSE serves the legacy HTTP+SSE transport for clients
+	// that don't support streamable HTTP yet.
+	TransportSSE Transport = "sse"
+)
+
+// Default values for ServeOptions.
+const (
+	DefaultAddr            = "127.0.0.1:7777"
+	DefaultHTTPPath        = "/mcp"
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+// ServeOptions configures how Serve exposes the MCP server.
+type ServeOptions struct {
+	// Config holds the user settings passed to New.
+	Config    userconfig.Config
+	Transport Transport
+	// Addr is the listen address for the http and sse transports.
+	Addr string
+	// Path is the endpoint path for the streamable HTTP transport.
+	Path string
+	// Token, when non-empty, requires every HTTP request to carry
+	// "Authorization: Bearer <Token>". Ignored for stdio.
+	Token string
+	// ShutdownTimeout bounds how long Serve waits for in-flight tool
+	// calls to finish after ctx is cancelled.
+	ShutdownTimeout time.Duration
+}
+
+// ParseTransport validates a transport name.
+func ParseTransport(v string) (Transport, error) {
+	switch t := Transport(strings.ToLower(strings.TrimSpace(v))); t {
+	case "", TransportStdio:
+		return TransportStdio, nil
+	case TransportHTTP, TransportSSE:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unknown transport %q: expected stdio, http, or sse", v)
+	}
+}
+
+// Serve builds the MCP server and serves it on the configured transport
+// until ctx is cancelled. On cancellation it stops accepting new requests,
+// waits (up to ShutdownTimeout) for in-flight tool calls to drain, and
+// only then closes the memory store.
+func Serve(ctx context.Context, opts ServeOptions) error {
+	if opts.Transport == "" {
+		opts.Transport = TransportStdio
+	}
+	if opts.Addr == "" {
+		opts.Addr = DefaultAddr
+	}
+	if opts.Path == "" {
+		opts.Path = DefaultHTTPPath
+	}
+	if opts.ShutdownTimeout <= 0 {
+		opts.ShutdownTimeout = DefaultShutdownTimeout
+	}
+
+	calls := &callTracker{}
+	s, cleanup, err := New(opts.Config, server.WithToolHandlerMiddleware(calls.middleware))
+	if err != nil {
+		return fmt.Errorf("creating server: %w", err)
+	}
+	defer cleanup()
+
+	var serveErr error
+	switch opts.Transport {
+	case TransportStdio:
+		serveErr = server.NewStdioServer(s).Listen(ctx, os.Stdin, os.Stdout)
+		if errors.Is(serveErr, context.Canceled) {
+			serveErr = nil
+		}
+	case TransportHTTP, TransportSSE:
+		serveErr = serveHTTP(ctx, s, opts)
+	default:
+		return fmt.Errorf("unknown transport %q", opts.Transport)
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+	defer cancel()
+	if err := calls.Wait(drainCtx); err != nil {
+		log.Printf("WARNING: %d tool call(s) still running at shutdown: %v", calls.Active(), err)
+	}
+
+	return serveErr
+}
+
+// serveHTTP runs the streamable HTTP or SSE transport behind an optional
+// bearer-token check and shuts it down gracefully when ctx is cancelled.
+func serveHTTP(ctx context.Context, s *server.MCPServer, opts ServeOptions) error {
+	if opts.Token == "" && !isLoopback(opts.Addr) {
+		log.Printf("WARNING: serving on %s without a bearer token — anyone who can reach this address can use Hoofy", opts.Addr)
+	}
+
+	httpSrv := &http.Server{
+		Addr:              opts.Addr,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// shutdown stops the transport; SSE needs its own Shutdown so
+	// long-lived event streams are closed instead of blocking.
+	var shutdown func(context.Context) error
+	switch opts.Transport {
+	case TransportSSE:
+		sse := server.NewSSEServer(s, server.WithHTTPServer(httpSrv))
+		httpSrv.Handler = requireBearer(opts.Token, sse)
+		shutdown = sse.Shutdown
+	default:
+		streamable := server.NewStreamableHTTPServer(s, server.WithEndpointPath(opts.Path))
+		mux := http.NewServeMux()
+		mux.Handle(opts.Path, requireBearer(opts.Token, streamable))
+		httpSrv.Handler = mux
+		shutdown = httpSrv.Shutdown
+	}
+
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", opts.Addr, err)
+	}
+	log.Printf("hoofy %s listening on %s (transport: %s)", Version, ln.Addr(), opts.Transport)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpSrv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	// Stop accepting connections and let in-flight requests finish.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+	defer cancel()
+	if err := shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("shutting down: %w", err)
+	}
+	return nil
+}
+
+// requireBearer rejects requests that don't carry the expected bearer
+// token. An empty token disables the check.
+func requireBearer(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="hoofy"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopback reports whether addr binds only to a loopback interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// callTracker counts in-flight tool calls so shutdown can wait for them.
+// The SSE transport answers HTTP requests before the tool runs, so
+// http.Server.Shutdown alone cannot guarantee calls have finished. Once
+// Wait has been called, new calls are rejected so the count can only
+// go down.
+type callTracker struct {
+	mu      sync.Mutex
+	active  int
+	closing bool
+	// idle is closed once closing is set and no call is active.
+	idle chan struct{}
+}
+
+func (c *callTracker) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		c.mu.Lock()
+		if c.closing {
+			c.mu.Unlock()
+			return mcp.NewToolResultError("Hoofy is shutting down; retry once the server is back."), nil
+		}
+		c.active++
+		c.mu.Unlock()
+		defer c.finish()
+		return next(ctx, req)
+	}
+}
+
+func (c *callTracker) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	if c.closing && c.active == 0 {
+		close(c.idle)
+	}
+}
+
+// Active returns the number of tool calls currently running.
+func (c *callTracker) Active() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active
+}
+
+// Wait stops accepting tool calls and blocks until the in-flight ones
+// finish or ctx expires.
+func (c *callTracker) Wait(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.closing {
+		c.closing = true
+		c.idle = make(chan struct{})
+		if c.active == 0 {
+			close(c.idle)
+		}
+	}
+	done := c.idle
+	c.mu.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseTransport(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Transport
+		wantErr bool
+	}{
+		{"", TransportStdio, false},
+		{"stdio", TransportStdio, false},
+		{"HTTP", TransportHTTP, false},
+		{"sse", TransportSSE, false},
+		{"websocket", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTransport(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTransport(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTransport(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRequireBearer(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{"no token configured", "", "", http.StatusNoContent},
+		{"missing header", "s3cret", "", http.StatusUnauthorized},
+		{"wrong token", "s3cret", "Bearer nope", http.StatusUnauthorized},
+		{"wrong scheme", "s3cret", "Basic s3cret", http.StatusUnauthorized},
+		{"valid token", "s3cret", "Bearer s3cret", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			requireBearer(tt.token, ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:7777": true,
+		"localhost:7777": true,
+		"[::1]:7777":     true,
+		"0.0.0.0:7777":   false,
+		":7777":          false,
+		"10.0.0.5:7777":  false,
+	}
+	for addr, want := range tests {
+		if got := isLoopback(addr); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestCallTracker_WaitDrainsInFlightCalls(t *testing.T) {
+	c := &callTracker{}
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	handler := c.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+	go func() { _, _ = handler(context.Background(), mcp.CallToolRequest{}) }()
+	<-started
+
+	if got := c.Active(); got != 1 {
+		t.Fatalf("Active() = %d, want 1", got)
+	}
+
+	// Wait must time out while the call is blocked...
+	short, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Wait(short); err == nil {
+		t.Fatal("Wait returned nil while a call was in flight")
+	}
+
+	// ...and return once it completes.
+	close(release)
+	long, cancel2 := context.WithTimeout(context.Background(), time.Second)
+	defer cancel2()
+	if err := c.Wait(long); err != nil {
+		t.Fatalf("Wait after release: %v", err)
+	}
+	if got := c.Active(); got != 0 {
+		t.Errorf("Active() after drain = %d, want 0", got)
+	}
+
+	// Calls arriving after shutdown started are rejected, not run.
+	ran := false
+	late := c.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ran = true
+		return mcp.NewToolResultText("done"), nil
+	})
+	res, err := late(context.Background(), mcp.CallToolRequest{})
+	if err != nil || res == nil || !res.IsError || ran {
+		t.Errorf("call after Wait: ran = %v, result = %+v, err = %v", ran, res, err)
+	}
+}
@@ -0,0 +1,235 @@
+package taskgraph
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Status is a task's implementation status.
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusInProgress Status = "in_progress"
+	StatusDone       Status = "done"
+	StatusBlocked    Status = "blocked"
+)
+
+// state returns the task's status, defaulting to pending.
+func (t *Task) state() Status {
+	if t.Status == "" {
+		return StatusPending
+	}
+	return t.Status
+}
+
+// Task returns the task with the given ID, or nil.
+func (g *Graph) Task(id string) *Task {
+	for i := range g.Tasks {
+		if g.Tasks[i].ID == id {
+			return &g.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// SetStatus records a task's status. A task can only start once all its
+// dependencies are done, and blocking it requires a reason.
+func (g *Graph) SetStatus(id string, status Status, reason string, now time.Time) error {
+	task := g.Task(id)
+	if task == nil {
+		return fmt.Errorf("task %s is not defined", id)
+	}
+
+	switch status {
+	case StatusPending, StatusDone:
+	case StatusInProgress:
+		var waiting []string
+		for _, dep := range task.Dependencies {
+			if d := g.Task(dep); d != nil && d.state() != StatusDone {
+				waiting = append(waiting, fmt.Sprintf("%s (%s)", dep, d.state()))
+			}
+		}
+		if len(waiting) > 0 {
+			return fmt.Errorf("%s depends on unfinished tasks: %s", id, strings.Join(waiting, ", "))
+		}
+	case StatusBlocked:
+		if strings.TrimSpace(reason) == "" {
+			return fmt.Errorf("blocking %s requires a reason", id)
+		}
+	default:
+		return fmt.Errorf("unknown task status %q", status)
+	}
+
+	task.Status = status
+	task.BlockedReason = ""
+	if status == StatusBlocked {
+		task.BlockedReason = strings.TrimSpace(reason)
+	}
+	task.UpdatedAt = now.UTC().Format(time.RFC3339)
+	return nil
+}
+
+// Carry copies the statuses recorded in prev onto tasks with the same
+// ID, so re-saving tasks.md keeps the progress made so far.
+func (g *Graph) Carry(prev *Graph) {
+	if prev == nil {
+		return
+	}
+	for i := range g.Tasks {
+		if old := prev.Task(g.Tasks[i].ID); old != nil {
+			g.Tasks[i].Status = old.Status
+			g.Tasks[i].BlockedReason = old.BlockedReason
+			g.Tasks[i].UpdatedAt = old.UpdatedAt
+		}
+	}
+}
+
+// Progress summarizes the implementation status of a graph.
+type Progress struct {
+	Total      int
+	Done       int
+	InProgress int
+	Blocked    int
+
+	// NextWave is the earliest wave with a pending task whose
+	// dependencies are all done, and Ready lists those tasks. When none
+	// can start, NextWave is the earliest unfinished wave and Ready is
+	// empty; when all tasks are done, it is 0.
+	NextWave int
+	Ready    []string
+}
+
+// Progress counts the tasks by status and finds the next wave to work on.
+func (g *Graph) Progress() Progress {
+	p := Progress{Total: len(g.Tasks)}
+	unfinished, readyWave := 0, 0
+	for i := range g.Tasks {
+		t := &g.Tasks[i]
+		switch t.state() {
+		case StatusDone:
+			p.Done++
+			continue
+		case StatusInProgress:
+			p.InProgress++
+		case StatusBlocked:
+			p.Blocked++
+		}
+		if unfinished == 0 || t.Wave < unfinished {
+			unfinished = t.Wave
+		}
+		if g.ready(t) && (readyWave == 0 || t.Wave < readyWave) {
+			readyWave = t.Wave
+		}
+	}
+
+	p.NextWave = unfinished
+	if readyWave > 0 {
+		p.NextWave = readyWave
+		for i := range g.Tasks {
+			if t := &g.Tasks[i]; t.Wave == readyWave && g.ready(t) {
+				p.Ready = append(p.Ready, t.ID)
+			}
+		}
+	}
+	return p
+}
+
+// ready reports whether a task is pending with all dependencies done.
+func (g *Graph) ready(t *Task) bool {
+	if t.state() != StatusPending {
+		return false
+	}
+	for _, dep := range t.Dependencies {
+		if d := g.Task(dep); d != nil && d.state() != StatusDone {
+			return false
+		}
+	}
+	return true
+}
+
+// Percent is the share of tasks done, rounded down.
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// Summary renders the progress as one line, e.g.
+// "3/8 tasks done (37%) · 1 in progress · 1 blocked".
+func (p Progress) Summary() string {
+	s := fmt.Sprintf("%d/%d tasks done (%d%%)", p.Done, p.Total, p.Percent())
+	if p.InProgress > 0 {
+		s += fmt.Sprintf(" · %d in progress", p.InProgress)
+	}
+	if p.Blocked > 0 {
+		s += fmt.Sprintf(" · %d blocked", p.Blocked)
+	}
+	return s
+}
+
+// NextStep renders the next wave and the tasks ready in it.
+func (p Progress) NextStep() string {
+	switch {
+	case p.Total == 0:
+		return "no tasks defined"
+	case p.NextWave == 0:
+		return "all tasks done"
+	case len(p.Ready) == 0:
+		return fmt.Sprintf("Wave %d — no pending task ready; finish or unblock the ones in progress", p.NextWave)
+	default:
+		return fmt.Sprintf("Wave %d — ready: %s", p.NextWave, strings.Join(p.Ready, ", "))
+	}
+}
+
+// Open reads the tasks artifact at tasksPath, builds its graph, and
+// carries over the statuses saved in tasks.json beside it. The markdown
+// is the source of truth for tasks and dependencies; tasks.json for
+// status. Returns nil, nil when the artifact doesn't exist.
+func Open(tasksPath string) (*Graph, error) {
+	data, err := os.ReadFile(tasksPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading tasks: %w", err)
+	}
+	g, err := Build(Parse(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	prev, err := Load(tasksPath)
+	if err != nil {
+		return nil, err
+	}
+	g.Carry(prev)
+	return g, nil
+}
+
+// checkboxPattern matches a markdown checkbox list item.
+var checkboxPattern = regexp.MustCompile(`^(\s*[-*]\s+)\[[ xX]\]`)
+
+// MarkCheckboxes ticks (done) or clears every checkbox in the block of
+// task id — its own list-item checkbox and its acceptance criteria —
+// and returns the updated content. Content without the task is
+// returned unchanged.
+func MarkCheckboxes(content, id string, done bool) string {
+	box := "[ ]"
+	if done {
+		box = "[x]"
+	}
+	lines := strings.Split(content, "\n")
+	for _, t := range Parse(content) {
+		if t.ID != id {
+			continue
+		}
+		for i := t.start; i < t.end; i++ {
+			lines[i] = checkboxPattern.ReplaceAllString(lines[i], "${1}"+box)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,169 @@
+package taskgraph
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestSetStatus(t *testing.T) {
+	g, err := Build(Parse(sampleTasks))
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if err := g.SetStatus("TASK-002", StatusInProgress, "", now); err == nil || !strings.Contains(err.Error(), "TASK-001 (pending)") {
+		t.Errorf("starting before dependencies are done: %v", err)
+	}
+	if err := g.SetStatus("TASK-009", StatusDone, "", now); err == nil {
+		t.Error("unknown task should fail")
+	}
+	if err := g.SetStatus("TASK-001", StatusBlocked, " ", now); err == nil {
+		t.Error("blocking without a reason should fail")
+	}
+	if err := g.SetStatus("TASK-001", "paused", "", now); err == nil {
+		t.Error("unknown status should fail")
+	}
+
+	if err := g.SetStatus("TASK-001", StatusBlocked, "waiting on credentials", now); err != nil {
+		t.Fatalf("block failed: %v", err)
+	}
+	if task := g.Task("TASK-001"); task.BlockedReason != "waiting on credentials" || task.UpdatedAt != "2026-03-01T12:00:00Z" {
+		t.Errorf("blocked task = %+v", task)
+	}
+	if err := g.SetStatus("TASK-001", StatusDone, "", now); err != nil {
+		t.Fatalf("done failed: %v", err)
+	}
+	if task := g.Task("TASK-001"); task.Status != StatusDone || task.BlockedReason != "" {
+		t.Errorf("done task = %+v", task)
+	}
+	if err := g.SetStatus("TASK-002", StatusInProgress, "", now); err != nil {
+		t.Errorf("starting once dependencies are done: %v", err)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	g, err := Build([]Task{
+		{ID: "TASK-001"},
+		{ID: "TASK-002"},
+		{ID: "TASK-003"},
+		{ID: "TASK-004", Dependencies: []string{"TASK-001"}},
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	p := g.Progress()
+	if p.Total != 4 || p.Done != 0 || p.NextWave != 1 || !slices.Equal(p.Ready, []string{"TASK-001", "TASK-002", "TASK-003"}) {
+		t.Errorf("initial progress = %+v", p)
+	}
+
+	for _, id := range []string{"TASK-001", "TASK-002"} {
+		if err := g.SetStatus(id, StatusDone, "", now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.SetStatus("TASK-003", StatusBlocked, "needs review", now); err != nil {
+		t.Fatal(err)
+	}
+	// A blocked task in wave 1 doesn't hold back wave 2 tasks that can start.
+	p = g.Progress()
+	if p.Done != 2 || p.Blocked != 1 || p.Percent() != 50 || p.NextWave != 2 || !slices.Equal(p.Ready, []string{"TASK-004"}) {
+		t.Errorf("progress = %+v", p)
+	}
+	if got := p.Summary(); got != "2/4 tasks done (50%) · 1 blocked" {
+		t.Errorf("Summary = %q", got)
+	}
+	if got := p.NextStep(); got != "Wave 2 — ready: TASK-004" {
+		t.Errorf("NextStep = %q", got)
+	}
+
+	if err := g.SetStatus("TASK-004", StatusInProgress, "", now); err != nil {
+		t.Fatal(err)
+	}
+	p = g.Progress()
+	if p.NextWave != 1 || !strings.Contains(p.NextStep(), "Wave 1 — no pending task ready") {
+		t.Errorf("progress = %+v, NextStep = %q", p, p.NextStep())
+	}
+
+	for _, id := range []string{"TASK-003", "TASK-004"} {
+		if err := g.SetStatus(id, StatusDone, "", now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p = g.Progress(); p.Percent() != 100 || p.NextStep() != "all tasks done" {
+		t.Errorf("finished progress = %+v", p)
+	}
+}
+
+func TestOpen_CarriesStatus(t *testing.T) {
+	dir := t.TempDir()
+	tasksPath := filepath.Join(dir, "tasks.md")
+
+	if g, err := Open(tasksPath); g != nil || err != nil {
+		t.Errorf("Open without tasks.md = %+v, %v", g, err)
+	}
+
+	if err := os.WriteFile(tasksPath, []byte(sampleTasks), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := Open(tasksPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := g.SetStatus("TASK-001", StatusDone, "", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Save(tasksPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// tasks.md gains a task: structure comes from the markdown, status from tasks.json.
+	edited := sampleTasks + "\n### TASK-005: Docs\n**Dependencies**: TASK-001\n"
+	if err := os.WriteFile(tasksPath, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err = Open(tasksPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if len(g.Tasks) != 5 || g.Task("TASK-001").Status != StatusDone || g.Task("TASK-005").Status != "" {
+		t.Errorf("reopened graph = %+v", g.Tasks)
+	}
+}
+
+func TestMarkCheckboxes(t *testing.T) {
+	content := `### TASK-001: Model
+**Acceptance Criteria**:
+- [ ] saved
+- [ ] validated
+
+- [ ] **TASK-002**: Endpoint
+  - [ ] returns 201
+
+**Wave 1**:
+- [ ] TASK-001
+`
+	done := MarkCheckboxes(content, "TASK-001", true)
+	want := strings.Replace(strings.Replace(content, "- [ ] saved", "- [x] saved", 1), "- [ ] validated", "- [x] validated", 1)
+	if done != want {
+		t.Errorf("MarkCheckboxes(TASK-001) =\n%s\nwant:\n%s", done, want)
+	}
+
+	done = MarkCheckboxes(done, "TASK-002", true)
+	if !strings.Contains(done, "- [x] **TASK-002**: Endpoint\n  - [x] returns 201") {
+		t.Errorf("list-item task not ticked:\n%s", done)
+	}
+
+	if reopened := MarkCheckboxes(done, "TASK-001", false); !strings.Contains(reopened, "- [ ] saved\n- [ ] validated") {
+		t.Errorf("clearing failed:\n%s", reopened)
+	}
+	if MarkCheckboxes(content, "TASK-009", true) != content {
+		t.Error("unknown task should leave content unchanged")
+	}
+}
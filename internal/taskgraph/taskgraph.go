@@ -0,0 +1,355 @@
+// Package taskgraph turns a tasks.md artifact into a dependency graph.
+//
+// Task breakdowns are written by an AI as markdown: "### TASK-NNN: Title"
+// blocks with a "**Dependencies**:" line each, plus optional wave
+// assignments. Nothing in the markdown guarantees the dependencies form a
+// DAG or that the waves respect them. This package parses the blocks,
+// rejects dangling references and cycles, computes waves by topological
+// sort, and checks AI-written waves against them. The result is saved as
+// tasks.json next to tasks.md, for both the project pipeline and changes.
+package taskgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// File is the name of the graph saved next to tasks.md.
+const File = "tasks.json"
+
+// Task is one TASK-NNN block.
+type Task struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title,omitempty"`
+	Dependencies []string `json:"dependencies"`
+	Wave         int      `json:"wave"`
+
+	// Status is the implementation status set with sdd_task; empty
+	// means pending.
+	Status        Status `json:"status,omitempty"`
+	BlockedReason string `json:"blocked_reason,omitempty"`
+	UpdatedAt     string `json:"updated_at,omitempty"`
+
+	// Body is the block's markdown, heading included.
+	Body string `json:"-"`
+	// start and end are the block's line range in the parsed content.
+	start, end int
+}
+
+// Graph is a validated task graph with computed waves.
+type Graph struct {
+	Tasks []Task     `json:"tasks"`
+	Waves [][]string `json:"waves"`
+}
+
+var (
+	// definitionPattern matches a line that defines a task: a heading or
+	// list item whose first token is the task ID, e.g. "### TASK-001: ..."
+	// or "- **TASK-002**: ...".
+	definitionPattern = regexp.MustCompile(`^\s*(#{1,6}\s+|[-*]\s+(?:\[[ xX]\]\s+)?)\**(TASK-\d{3,})\b\**:?\**\s*(.*)$`)
+	// dependenciesPattern matches "**Dependencies**: TASK-001, TASK-002".
+	dependenciesPattern = regexp.MustCompile(`(?i)^\s*(?:[-*]\s+)?\**(?:dependencies|depends on)\**:?\**\s*(.*)$`)
+	// taskIDPattern matches any task reference.
+	taskIDPattern = regexp.MustCompile(`\bTASK-\d{3,}\b`)
+	// headingPattern matches a markdown heading.
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s`)
+)
+
+// Parse splits a tasks artifact into task blocks, in document order. A
+// heading task runs until the next heading at its level or above; a
+// list-item task until the next heading. List items under a "Wave N"
+// marker are wave assignments, not definitions. Tasks defined twice are
+// returned twice — Build reports them.
+func Parse(content string) []Task {
+	var tasks []Task
+	var body strings.Builder
+	level := 0 // heading level of the open task; 7 for list-item tasks; 0 = none
+	inWave := false
+	flush := func(end int) {
+		if level > 0 {
+			tasks[len(tasks)-1].Body = body.String()
+			tasks[len(tasks)-1].end = end
+		}
+		body.Reset()
+		level = 0
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if wavePattern.MatchString(line) {
+			flush(i)
+			inWave = true
+			continue
+		}
+		if headingPattern.MatchString(line) {
+			inWave = false
+		}
+		if inWave {
+			continue
+		}
+
+		if m := definitionPattern.FindStringSubmatch(line); m != nil {
+			flush(i)
+			level = 7
+			if h := strings.TrimSpace(m[1]); strings.HasPrefix(h, "#") {
+				level = len(h)
+			}
+			tasks = append(tasks, Task{ID: m[2], Title: strings.TrimSpace(strings.Trim(m[3], "*")), Dependencies: []string{}, start: i})
+		} else if h := headingPattern.FindStringSubmatch(line); h != nil && level > 0 && len(h[1]) <= level {
+			flush(i)
+		}
+		if level == 0 {
+			continue
+		}
+
+		body.WriteString(line)
+		body.WriteByte('\n')
+		if m := dependenciesPattern.FindStringSubmatch(line); m != nil {
+			task := &tasks[len(tasks)-1]
+			for _, id := range taskIDPattern.FindAllString(m[1], -1) {
+				if !slices.Contains(task.Dependencies, id) {
+					task.Dependencies = append(task.Dependencies, id)
+				}
+			}
+		}
+	}
+	flush(len(lines))
+	return tasks
+}
+
+// Build validates the tasks' dependencies and computes their waves: a
+// task with no dependencies is in wave 1, any other task one wave after
+// its latest dependency. Duplicate IDs, references to undefined tasks,
+// and cycles are errors.
+func Build(tasks []Task) (*Graph, error) {
+	index := make(map[string]int, len(tasks))
+	var problems []string
+	for i, t := range tasks {
+		if _, dup := index[t.ID]; dup {
+			problems = append(problems, fmt.Sprintf("%s is defined more than once", t.ID))
+			continue
+		}
+		index[t.ID] = i
+	}
+	for _, t := range tasks {
+		for _, dep := range t.Dependencies {
+			if dep == t.ID {
+				problems = append(problems, fmt.Sprintf("%s depends on itself", t.ID))
+			} else if _, ok := index[dep]; !ok {
+				problems = append(problems, fmt.Sprintf("%s depends on %s, which is not defined", t.ID, dep))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid task graph: %s", strings.Join(problems, "; "))
+	}
+
+	g := &Graph{Tasks: make([]Task, len(tasks))}
+	copy(g.Tasks, tasks)
+
+	// Kahn's algorithm, in document order within each wave.
+	remaining := make(map[string]int, len(tasks))
+	dependents := make(map[string][]string, len(tasks))
+	for _, t := range g.Tasks {
+		remaining[t.ID] = len(t.Dependencies)
+		for _, dep := range t.Dependencies {
+			dependents[dep] = append(dependents[dep], t.ID)
+		}
+	}
+	var wave []string
+	for _, t := range g.Tasks {
+		if remaining[t.ID] == 0 {
+			wave = append(wave, t.ID)
+		}
+	}
+	placed := 0
+	for len(wave) > 0 {
+		g.Waves = append(g.Waves, wave)
+		var next []string
+		for _, id := range wave {
+			g.Tasks[index[id]].Wave = len(g.Waves)
+			placed++
+			for _, d := range dependents[id] {
+				if remaining[d]--; remaining[d] == 0 {
+					next = append(next, d)
+				}
+			}
+		}
+		sort.Slice(next, func(i, j int) bool { return index[next[i]] < index[next[j]] })
+		wave = next
+	}
+
+	if placed < len(g.Tasks) {
+		return nil, fmt.Errorf("invalid task graph: dependency cycle %s", g.findCycle(index))
+	}
+	if g.Waves == nil {
+		g.Waves = [][]string{}
+	}
+	return g, nil
+}
+
+// findCycle returns one cycle among the tasks no wave could hold, as
+// "TASK-001 → TASK-002 → TASK-001".
+func (g *Graph) findCycle(index map[string]int) string {
+	state := map[string]int{} // 0 unvisited, 1 on stack, 2 done
+	var stack []string
+	var cycle []string
+	var visit func(id string) bool
+	visit = func(id string) bool {
+		state[id] = 1
+		stack = append(stack, id)
+		for _, dep := range g.Tasks[index[id]].Dependencies {
+			if state[dep] == 1 {
+				start := slices.Index(stack, dep)
+				cycle = append(append([]string(nil), stack[start:]...), dep)
+				return true
+			}
+			if state[dep] == 0 && visit(dep) {
+				return true
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = 2
+		return false
+	}
+	for _, t := range g.Tasks {
+		if t.Wave == 0 && state[t.ID] == 0 && visit(t.ID) {
+			break
+		}
+	}
+	// Edges point at dependencies; show them in execution order.
+	slices.Reverse(cycle)
+	return strings.Join(cycle, " → ")
+}
+
+// wavePattern matches a wave marker: "**Wave 2**", "### Wave 2", "Wave 2:".
+var wavePattern = regexp.MustCompile(`(?i)^\s*(?:#{1,6}\s+)?\**wave\s+(\d+)\b`)
+
+// waveItemPattern matches a task listed in a wave: "- TASK-003: ..." or "- **TASK-003**".
+var waveItemPattern = regexp.MustCompile(`^\s*[-*]\s+\**(TASK-\d{3,})\b`)
+
+// ParseWaves reads wave assignments — "Wave N" markers followed by list
+// items that start with a task ID — and returns task ID → wave.
+func ParseWaves(content string) map[string]int {
+	assigned := map[string]int{}
+	wave := 0
+	for _, line := range strings.Split(content, "\n") {
+		if m := wavePattern.FindStringSubmatch(line); m != nil {
+			// A number that doesn't parse (overflow) skips the marker; its
+			// items aren't assigned to the previous wave either.
+			wave = 0
+			if n, err := strconv.Atoi(m[1]); err == nil {
+				wave = n
+			}
+			continue
+		}
+		if headingPattern.MatchString(line) {
+			wave = 0
+			continue
+		}
+		if m := waveItemPattern.FindStringSubmatch(line); m != nil && wave > 0 {
+			assigned[m[1]] = wave
+		}
+	}
+	return assigned
+}
+
+// CheckWaves compares AI-written wave assignments with the graph and
+// describes every contradiction: a task scheduled no later than one of
+// its dependencies, a wave naming an undefined task, or a task left out.
+// Waves later than necessary are allowed.
+func (g *Graph) CheckWaves(assigned map[string]int) []string {
+	if len(assigned) == 0 {
+		return nil
+	}
+	var issues []string
+	known := make(map[string]bool, len(g.Tasks))
+	for _, t := range g.Tasks {
+		known[t.ID] = true
+		w, ok := assigned[t.ID]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("%s is not assigned to any wave (computed: wave %d)", t.ID, t.Wave))
+			continue
+		}
+		for _, dep := range t.Dependencies {
+			if dw, ok := assigned[dep]; ok && dw >= w {
+				issues = append(issues, fmt.Sprintf("%s is in wave %d but depends on %s in wave %d (computed: wave %d)", t.ID, w, dep, dw, t.Wave))
+			}
+		}
+	}
+	var unknown []string
+	for id := range assigned {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		issues = append(issues, fmt.Sprintf("wave %d lists %s, which is not defined", assigned[id], id))
+	}
+	return issues
+}
+
+// Markdown renders the computed waves as wave assignments.
+func (g *Graph) Markdown() string {
+	titles := make(map[string]string, len(g.Tasks))
+	for _, t := range g.Tasks {
+		titles[t.ID] = t.Title
+	}
+	var sb strings.Builder
+	for i, wave := range g.Waves {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "**Wave %d**:\n", i+1)
+		for _, id := range wave {
+			if titles[id] != "" {
+				fmt.Fprintf(&sb, "- %s: %s\n", id, titles[id])
+			} else {
+				fmt.Fprintf(&sb, "- %s\n", id)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Path returns the tasks.json path for the tasks artifact at tasksPath.
+func Path(tasksPath string) string {
+	return filepath.Join(filepath.Dir(tasksPath), File)
+}
+
+// Save writes the graph as tasks.json next to the tasks artifact.
+func (g *Graph) Save(tasksPath string) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling task graph: %w", err)
+	}
+	if err := os.WriteFile(Path(tasksPath), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", File, err)
+	}
+	return nil
+}
+
+// Load reads the tasks.json saved next to the tasks artifact. Returns
+// nil, nil when there is none.
+func Load(tasksPath string) (*Graph, error) {
+	data, err := os.ReadFile(Path(tasksPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", File, err)
+	}
+	var g Graph
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", File, err)
+	}
+	return &g, nil
+}
@@ -0,0 +1,206 @@
+package taskgraph
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const sampleTasks = `# Tasks
+
+## Tasks
+
+### TASK-001: Set up scaffolding
+**Dependencies**: None
+**Acceptance Criteria**:
+- [ ] builds
+
+### TASK-002: Database schema
+**Dependencies**: TASK-001
+
+#### Notes
+Mentions TASK-004 in passing.
+
+- **TASK-003**: Auth module
+  Dependencies: TASK-001, TASK-002
+
+### TASK-004: Integration tests
+**Depends on**: TASK-003 and TASK-002
+
+## Dependency Graph
+
+TASK-001 → TASK-002 → TASK-003 → TASK-004
+`
+
+func TestParse(t *testing.T) {
+	tasks := Parse(sampleTasks)
+
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	if !slices.Equal(ids, []string{"TASK-001", "TASK-002", "TASK-003", "TASK-004"}) {
+		t.Fatalf("IDs = %v", ids)
+	}
+	if tasks[0].Title != "Set up scaffolding" || tasks[2].Title != "Auth module" {
+		t.Errorf("titles = %q, %q", tasks[0].Title, tasks[2].Title)
+	}
+	wantDeps := [][]string{{}, {"TASK-001"}, {"TASK-001", "TASK-002"}, {"TASK-003", "TASK-002"}}
+	for i, want := range wantDeps {
+		if !slices.Equal(tasks[i].Dependencies, want) {
+			t.Errorf("%s dependencies = %v, want %v", tasks[i].ID, tasks[i].Dependencies, want)
+		}
+	}
+
+	// A lower heading stays in the task; the summary section doesn't.
+	if !strings.Contains(tasks[1].Body, "#### Notes") {
+		t.Errorf("TASK-002 body lost its sub-heading:\n%s", tasks[1].Body)
+	}
+	if strings.Contains(tasks[3].Body, "Dependency Graph") {
+		t.Errorf("TASK-004 body runs into the next section:\n%s", tasks[3].Body)
+	}
+
+	// Wave entries reference tasks; they don't redefine them.
+	if got := Parse("### TASK-001: A\n\n**Wave 1**:\n- TASK-001: A\n\n### Wave 2\n- **TASK-002**\n"); len(got) != 1 || strings.Contains(got[0].Body, "Wave") {
+		t.Errorf("wave entries parsed as tasks: %+v", got)
+	}
+	if got := Parse("See TASK-001 in the old plan."); len(got) != 0 {
+		t.Errorf("a mid-line mention is not a task: %+v", got)
+	}
+}
+
+func TestBuild_Waves(t *testing.T) {
+	g, err := Build(Parse(sampleTasks))
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	want := [][]string{{"TASK-001"}, {"TASK-002"}, {"TASK-003"}, {"TASK-004"}}
+	if !slices.EqualFunc(g.Waves, want, slices.Equal) {
+		t.Errorf("Waves = %v, want %v", g.Waves, want)
+	}
+
+	g, err = Build([]Task{
+		{ID: "TASK-001"},
+		{ID: "TASK-002", Dependencies: []string{"TASK-003"}},
+		{ID: "TASK-003"},
+		{ID: "TASK-004", Dependencies: []string{"TASK-001"}},
+	})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	want = [][]string{{"TASK-001", "TASK-003"}, {"TASK-002", "TASK-004"}}
+	if !slices.EqualFunc(g.Waves, want, slices.Equal) {
+		t.Errorf("Waves = %v, want %v", g.Waves, want)
+	}
+	if g.Tasks[1].Wave != 2 || g.Tasks[2].Wave != 1 {
+		t.Errorf("Tasks = %+v", g.Tasks)
+	}
+
+	if g, err := Build(nil); err != nil || len(g.Waves) != 0 {
+		t.Errorf("Build(nil) = %+v, %v", g, err)
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  string
+	}{
+		{"duplicate", []Task{{ID: "TASK-001"}, {ID: "TASK-001"}}, "TASK-001 is defined more than once"},
+		{"dangling", []Task{{ID: "TASK-001", Dependencies: []string{"TASK-009"}}}, "TASK-001 depends on TASK-009, which is not defined"},
+		{"self", []Task{{ID: "TASK-001", Dependencies: []string{"TASK-001"}}}, "TASK-001 depends on itself"},
+		{"cycle", []Task{
+			{ID: "TASK-001"},
+			{ID: "TASK-002", Dependencies: []string{"TASK-001", "TASK-004"}},
+			{ID: "TASK-003", Dependencies: []string{"TASK-002"}},
+			{ID: "TASK-004", Dependencies: []string{"TASK-003"}},
+		}, "dependency cycle TASK-002 → TASK-003 → TASK-004 → TASK-002"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Build(tt.tasks)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Build error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWaves(t *testing.T) {
+	waves := ParseWaves(`**Wave 1** (parallel — no dependencies):
+- TASK-001: Project scaffolding
+- **TASK-002**: Database schema
+
+### Wave 2
+- TASK-003
+
+## Notes
+- TASK-004 is not in a wave
+
+**Wave 99999999999999999999**
+- TASK-005
+`)
+	want := map[string]int{"TASK-001": 1, "TASK-002": 1, "TASK-003": 2}
+	if len(waves) != len(want) {
+		t.Fatalf("waves = %v, want %v", waves, want)
+	}
+	for id, w := range want {
+		if waves[id] != w {
+			t.Errorf("%s in wave %d, want %d", id, waves[id], w)
+		}
+	}
+}
+
+func TestCheckWaves(t *testing.T) {
+	g, err := Build(Parse(sampleTasks))
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if issues := g.CheckWaves(map[string]int{"TASK-001": 1, "TASK-002": 2, "TASK-003": 3, "TASK-004": 5}); issues != nil {
+		t.Errorf("consistent waves flagged: %v", issues)
+	}
+	if issues := g.CheckWaves(nil); issues != nil {
+		t.Errorf("no waves flagged: %v", issues)
+	}
+
+	issues := g.CheckWaves(map[string]int{"TASK-001": 1, "TASK-002": 1, "TASK-003": 2, "TASK-009": 3})
+	want := []string{
+		"TASK-002 is in wave 1 but depends on TASK-001 in wave 1 (computed: wave 2)",
+		"TASK-004 is not assigned to any wave (computed: wave 4)",
+		"wave 3 lists TASK-009, which is not defined",
+	}
+	if !slices.Equal(issues, want) {
+		t.Errorf("issues = %q, want %q", issues, want)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	tasksPath := filepath.Join(dir, "tasks.md")
+
+	if g, err := Load(tasksPath); g != nil || err != nil {
+		t.Errorf("Load without tasks.json = %+v, %v", g, err)
+	}
+
+	g, err := Build(Parse(sampleTasks))
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if err := g.Save(tasksPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if Path(tasksPath) != filepath.Join(dir, File) {
+		t.Errorf("Path = %s", Path(tasksPath))
+	}
+
+	loaded, err := Load(tasksPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Tasks) != 4 || loaded.Tasks[3].Wave != 4 || len(loaded.Waves) != 4 || loaded.Tasks[0].Body != "" {
+		t.Errorf("loaded = %+v", loaded)
+	}
+}
@@ -0,0 +1,163 @@
+package templates
+
+import "slices"
+
+// Template names for the change-pipeline stages, one per ChangeStage.
+const (
+	ChangeDescribe     = "change-describe.md.tmpl"
+	ChangeScope        = "change-scope.md.tmpl"
+	ChangeCharter      = "change-charter.md.tmpl"
+	ChangeContextCheck = "change-context-check.md.tmpl"
+	ChangeSpec         = "change-spec.md.tmpl"
+	ChangeClarify      = "change-clarify.md.tmpl"
+	ChangeDesign       = "change-design.md.tmpl"
+	ChangeTasks        = "change-tasks.md.tmpl"
+	ChangeVerify       = "change-verify.md.tmpl"
+)
+
+// ChangeStageData holds the data for rendering a change-stage artifact.
+// Fields maps each structured input (see ChangeStageFields) to its
+// content; templates read them as {{ .Fields.summary }}.
+type ChangeStageData struct {
+	Title    string // heading: the caller's title, or the change description
+	ChangeID string
+	Type     string
+	Size     string
+	Stage    string
+	Fields   map[string]string
+}
+
+// ChangeField is a structured input of a change-stage template. Its
+// Name doubles as the sdd_change_advance parameter.
+type ChangeField struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// changeFieldDescriptions documents every change-stage field. Fields
+// shared by several stages mean the same thing in each.
+var changeFieldDescriptions = map[string]string{
+	"summary": "What the change does, in a few sentences.",
+	"motivation": "Why it's needed: the bug, request, or problem behind it. " +
+		"For fixes, include how to reproduce and what happens instead.",
+	"affected_areas":      "Modules, files, or user-facing areas the change touches (markdown list).",
+	"acceptance_criteria": "How we'll know it's done: observable outcomes, ideally Given/When/Then (markdown list).",
+	"in_scope":            "What is being restructured (markdown list).",
+	"out_of_scope":        "What deliberately stays untouched (markdown list).",
+	"preserved_behavior":  "Behavior that must remain identical, and how that will be checked.",
+	"problem":             "The problem or opportunity, and who has it.",
+	"proposed_solution":   "The solution at a high level — what, not how.",
+	"success_criteria":    "Measurable outcomes that show the change succeeded.",
+	"artifacts_checked": "The project artifacts reviewed, by file name, with what was relevant in each. " +
+		"Example: '- docs/requirements.md — FR-003, FR-007 cover login'. " +
+		"Write 'No existing artifacts' when the project has none.",
+	"conflicts":       "Conflicts with existing requirements, business rules, or in-flight changes — or 'None found'.",
+	"related_changes": "Past or in-flight changes that touch the same area.",
+	"impact":          "How existing requirements, rules, or conventions are affected.",
+	"added_requirements": "New requirements with change-local IDs, e.g. '- **FR-001**: Users can export CSV'. " +
+		"Rendered under '## ADDED Requirements' and merged into docs/requirements.md on completion.",
+	"modified_requirements": "Project requirements whose text changes, by project ID, e.g. '- **FR-004**: ...'. " +
+		"Rendered under '## MODIFIED Requirements'.",
+	"removed_requirements": "Project requirements removed, by project ID, with the reason. " +
+		"Rendered under '## REMOVED Requirements'.",
+	"added_business_rules": "New business rules (When ... Then ...). Rendered under '## ADDED Business Rules'.",
+	"questions":            "The ambiguities raised and how each was resolved (Q/A list).",
+	"decisions":            "Decisions taken as a result, with rationale.",
+	"open_questions":       "Questions still open, and who will answer them.",
+	"approach":             "The technical approach and why it was chosen.",
+	"components":           "Components added or changed, with responsibilities and the requirements (FR-XXX) each covers.",
+	"data_model":           "Schema or data structure changes.",
+	"api_changes":          "API, CLI, or interface changes, including compatibility notes.",
+	"risks":                "Risks and how they are mitigated.",
+	"alternatives":         "Alternatives considered and why they were rejected.",
+	"tasks": "The task breakdown: '### TASK-001: Title' blocks, each with a '**Dependencies**:' line " +
+		"(TASK IDs or None) and '**Acceptance Criteria**:' checkboxes.",
+	"waves":                 "Optional execution waves: '**Wave 1**:' markers followed by '- TASK-001' items.",
+	"results":               "Verification of every task (TASK-NNN): what was checked and the outcome.",
+	"requirements_coverage": "How each requirement of the change is satisfied.",
+	"tests":                 "Tests added or run, and their results.",
+	"follow_ups":            "Follow-up work deliberately left out of this change.",
+}
+
+// changeStageSpec is the template and the fields of one change stage.
+type changeStageSpec struct {
+	template string
+	required []string
+	optional []string
+}
+
+// changeStages maps each change stage to its template. Stages declared
+// only in docs/hoofy-flows.json have no template and take raw content.
+var changeStages = map[string]changeStageSpec{
+	"describe": {ChangeDescribe,
+		[]string{"summary", "motivation"},
+		[]string{"affected_areas", "acceptance_criteria"}},
+	"scope": {ChangeScope,
+		[]string{"summary", "in_scope", "out_of_scope", "preserved_behavior"},
+		[]string{"affected_areas"}},
+	"charter": {ChangeCharter,
+		[]string{"problem", "proposed_solution", "success_criteria"},
+		[]string{"out_of_scope", "affected_areas"}},
+	"context-check": {ChangeContextCheck,
+		[]string{"artifacts_checked", "conflicts"},
+		[]string{"related_changes", "impact"}},
+	"spec": {ChangeSpec,
+		nil,
+		[]string{"added_requirements", "modified_requirements", "removed_requirements", "added_business_rules", "acceptance_criteria"}},
+	"clarify": {ChangeClarify,
+		[]string{"questions"},
+		[]string{"decisions", "open_questions"}},
+	"design": {ChangeDesign,
+		[]string{"approach", "components"},
+		[]string{"data_model", "api_changes", "risks", "alternatives"}},
+	"tasks": {ChangeTasks,
+		[]string{"tasks"},
+		[]string{"waves"}},
+	"verify": {ChangeVerify,
+		[]string{"results"},
+		[]string{"requirements_coverage", "tests", "follow_ups"}},
+}
+
+// ChangeStageTemplate returns the template name and fields of a change
+// stage, required fields first. ok is false for stages without a template.
+func ChangeStageTemplate(stage string) (name string, fields []ChangeField, ok bool) {
+	spec, ok := changeStages[stage]
+	if !ok {
+		return "", nil, false
+	}
+	for _, f := range spec.required {
+		fields = append(fields, ChangeField{Name: f, Description: changeFieldDescriptions[f], Required: true})
+	}
+	for _, f := range spec.optional {
+		fields = append(fields, ChangeField{Name: f, Description: changeFieldDescriptions[f]})
+	}
+	return spec.template, fields, true
+}
+
+// ChangeFieldNames returns every change-stage field name, sorted.
+func ChangeFieldNames() []string {
+	names := make([]string, 0, len(changeFieldDescriptions))
+	for name := range changeFieldDescriptions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ChangeFieldStages returns the stages that take a field, sorted.
+func ChangeFieldStages(field string) []string {
+	var stages []string
+	for stage, spec := range changeStages {
+		if slices.Contains(spec.required, field) || slices.Contains(spec.optional, field) {
+			stages = append(stages, stage)
+		}
+	}
+	slices.Sort(stages)
+	return stages
+}
+
+// ChangeFieldDescription returns the description of a change-stage field.
+func ChangeFieldDescription(field string) string {
+	return changeFieldDescriptions[field]
+}
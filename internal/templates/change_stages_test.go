@@ -0,0 +1,89 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+)
+
+func TestChangeStageTemplate_CoversBuiltinStages(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	for _, stage := range []changes.ChangeStage{
+		changes.StageDescribe, changes.StageScope, changes.StageCharter, changes.StageContextCheck,
+		changes.StageSpec, changes.StageClarify, changes.StageDesign, changes.StageTasks, changes.StageVerify,
+	} {
+		name, fields, ok := ChangeStageTemplate(string(stage))
+		if !ok {
+			t.Errorf("stage %s has no template", stage)
+			continue
+		}
+
+		// Every field rendered: its content shows up.
+		data := ChangeStageData{Title: "Add CSV export", ChangeID: "add-csv-export", Type: "feature", Size: "small", Stage: string(stage), Fields: map[string]string{}}
+		for _, f := range fields {
+			if f.Description == "" {
+				t.Errorf("%s: field %s has no description", stage, f.Name)
+			}
+			data.Fields[f.Name] = "content of " + f.Name
+		}
+		result, err := r.Render(name, data)
+		if err != nil {
+			t.Fatalf("Render(%s) failed: %v", name, err)
+		}
+		for _, want := range []string{"# Add CSV export — ", "`add-csv-export` (feature/small) | Stage: " + string(stage)} {
+			if !strings.Contains(result, want) {
+				t.Errorf("%s output missing %q:\n%s", stage, want, result)
+			}
+		}
+		for _, f := range fields {
+			if !strings.Contains(result, "content of "+f.Name) {
+				t.Errorf("%s output missing field %s:\n%s", stage, f.Name, result)
+			}
+		}
+	}
+
+	if _, _, ok := ChangeStageTemplate("security-review"); ok {
+		t.Error("stages without a template should report !ok")
+	}
+}
+
+func TestRender_ChangeDescribe_OptionalSections(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	result, err := r.Render(ChangeDescribe, ChangeStageData{
+		Title:  "Fix FTS5 crash",
+		Fields: map[string]string{"summary": "Guard empty queries.", "motivation": "Search crashes on ''."},
+	})
+	if err != nil {
+		t.Fatalf("Render(ChangeDescribe) failed: %v", err)
+	}
+	if !strings.Contains(result, "## Summary\n\nGuard empty queries.") || !strings.Contains(result, "## Motivation") {
+		t.Errorf("required sections missing:\n%s", result)
+	}
+	if strings.Contains(result, "## Affected Areas") || strings.Contains(result, "<no value>") {
+		t.Errorf("empty optional sections should not render:\n%s", result)
+	}
+}
+
+func TestChangeFields(t *testing.T) {
+	names := ChangeFieldNames()
+	for _, name := range names {
+		if len(ChangeFieldStages(name)) == 0 {
+			t.Errorf("field %s is not used by any stage", name)
+		}
+	}
+	if got := strings.Join(ChangeFieldStages("out_of_scope"), ","); got != "charter,scope" {
+		t.Errorf("ChangeFieldStages(out_of_scope) = %s", got)
+	}
+	if _, fields, _ := ChangeStageTemplate("describe"); !fields[0].Required || fields[len(fields)-1].Required {
+		t.Errorf("required fields should come first: %+v", fields)
+	}
+}
@@ -0,0 +1,208 @@
+package templates
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/config"
+)
+
+// OverridesDir is where a project keeps its template overrides,
+// relative to the docs directory. A file there named like an embedded
+// template (e.g. requirements.md.tmpl) replaces it.
+const OverridesDir = ".hoofy/templates"
+
+// OverridesPath returns the absolute path to the project's template
+// overrides directory.
+func OverridesPath(projectRoot string) string {
+	return filepath.Join(config.DocsPath(projectRoot), filepath.FromSlash(OverridesDir))
+}
+
+// Names returns the names of the embedded templates, sorted.
+func Names() []string {
+	names, _ := fs.Glob(templateFS, "*.tmpl") // the pattern is valid
+	slices.Sort(names)
+	return names
+}
+
+// Lookup resolves a template name. Besides the full file name it accepts
+// the name without its extension: "requirements" or "requirements.md".
+func Lookup(name string) (string, bool) {
+	for _, candidate := range []string{name, name + ".tmpl", name + ".md.tmpl"} {
+		if slices.Contains(Names(), candidate) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// Source returns the embedded source of a template.
+func Source(name string) ([]byte, error) {
+	full, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q — known templates: %s", name, strings.Join(Names(), ", "))
+	}
+	return templateFS.ReadFile(full)
+}
+
+// LoadRenderer returns a renderer with the project's overrides layered
+// over the embedded templates. A project without overrides gets the
+// embedded templates; a broken override is an error naming every file
+// that doesn't parse or render.
+func LoadRenderer(projectRoot string) (*EmbedRenderer, error) {
+	return NewLayeredRenderer(OverridesPath(projectRoot))
+}
+
+// NewLayeredRenderer creates a renderer with the templates in dir
+// layered over the embedded ones. A missing dir is not an error.
+func NewLayeredRenderer(dir string) (*EmbedRenderer, error) {
+	r, problems, err := layer(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		msgs := make([]string, len(problems))
+		for i, p := range problems {
+			msgs[i] = p.Error()
+		}
+		return nil, fmt.Errorf("invalid template overrides in %s: %s", dir, strings.Join(msgs, "; "))
+	}
+	return r, nil
+}
+
+// ValidateOverrides checks the templates in dir without building a
+// renderer for use. It returns the overridden names and one error per
+// override that doesn't parse or render.
+func ValidateOverrides(dir string) ([]string, []error, error) {
+	r, problems, err := layer(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.Overrides(), problems, nil
+}
+
+// Eject copies an embedded template into the project's overrides
+// directory for editing and returns the written path. An existing
+// override is only replaced when force is set.
+func Eject(projectRoot, name string, force bool) (string, error) {
+	full, ok := Lookup(name)
+	if !ok {
+		return "", fmt.Errorf("unknown template %q — known templates: %s", name, strings.Join(Names(), ", "))
+	}
+	src, err := templateFS.ReadFile(full)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", full, err)
+	}
+
+	dir := OverridesPath(projectRoot)
+	path := filepath.Join(dir, full)
+	if _, err := os.Stat(path); err == nil && !force {
+		return "", fmt.Errorf("%s already exists — edit it, or pass --force to replace it with the default", path)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// layer parses the embedded templates, then each *.tmpl in dir over
+// them, and executes every override against sample data. Broken
+// overrides are returned as problems; err is for I/O failures only.
+func layer(dir string) (*EmbedRenderer, []error, error) {
+	r, err := NewRenderer()
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil, nil
+		}
+		return nil, nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	var problems []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".tmpl") {
+			continue
+		}
+		if !slices.Contains(Names(), name) {
+			problems = append(problems, fmt.Errorf("%s is not a Hoofy template (run 'hoofy templates list' for the names)", name))
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		if _, err := r.templates.New(name).Parse(string(src)); err != nil {
+			problems = append(problems, fmt.Errorf("%s does not parse: %w", name, err))
+			continue
+		}
+		r.overrides = append(r.overrides, name)
+	}
+
+	// Execute only once everything is parsed: an override may call
+	// templates defined by another.
+	for _, name := range r.overrides {
+		data := sampleData(name)
+		if err := r.templates.ExecuteTemplate(io.Discard, name, data); err != nil {
+			problems = append(problems, fmt.Errorf("%s does not render with %T: %w", name, data, err))
+		}
+	}
+	return r, problems, nil
+}
+
+// sampleData returns the data a template is rendered with, every field
+// set so that optional sections are executed too.
+func sampleData(name string) any {
+	switch name {
+	case Principles:
+		return sample[PrinciplesData]()
+	case Charter:
+		return sample[CharterData]()
+	case Requirements:
+		return sample[RequirementsData]()
+	case BusinessRules:
+		return sample[BusinessRulesData]()
+	case Clarifications:
+		return sample[ClarificationsData]()
+	case Design:
+		return sample[DesignData]()
+	case Tasks:
+		return sample[TasksData]()
+	case AgentInstructions:
+		return sample[AgentInstructionsData]()
+	default:
+		data := sample[ChangeStageData]()
+		data.Fields = map[string]string{}
+		for _, f := range ChangeFieldNames() {
+			data.Fields[f] = "sample " + f
+		}
+		return data
+	}
+}
+
+// sample returns a T with every string and int field set.
+func sample[T any]() T {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	for i := range rv.NumField() {
+		switch f := rv.Field(i); f.Kind() {
+		case reflect.String:
+			f.SetString("sample " + rv.Type().Field(i).Name)
+		case reflect.Int:
+			f.SetInt(1)
+		}
+	}
+	return v
+}
@@ -0,0 +1,149 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeOverride(t *testing.T, dir, name, src string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSampleData_RendersEveryTemplate(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	// Overrides are validated with sampleData, so it must fit every
+	// embedded template — a new template needs its data struct there.
+	for _, name := range Names() {
+		if _, err := r.Render(name, sampleData(name)); err != nil {
+			t.Errorf("Render(%s) with sample data failed: %v", name, err)
+		}
+	}
+}
+
+func TestLoadRenderer(t *testing.T) {
+	root := t.TempDir()
+
+	// No overrides: the embedded templates.
+	r, err := LoadRenderer(root)
+	if err != nil {
+		t.Fatalf("LoadRenderer failed: %v", err)
+	}
+	if len(r.Overrides()) != 0 {
+		t.Errorf("overrides = %v, want none", r.Overrides())
+	}
+
+	dir := OverridesPath(root)
+	if !strings.HasSuffix(filepath.ToSlash(dir), "docs/.hoofy/templates") {
+		t.Errorf("OverridesPath = %s", dir)
+	}
+	writeOverride(t, dir, Requirements, "# {{ .Name }} — House Requirements\n\n{{ .MustHave }}\n")
+	writeOverride(t, dir, "README.md", "notes for the team")
+
+	r, err = LoadRenderer(root)
+	if err != nil {
+		t.Fatalf("LoadRenderer failed: %v", err)
+	}
+	if got := r.Overrides(); len(got) != 1 || got[0] != Requirements {
+		t.Errorf("overrides = %v", got)
+	}
+	out, err := r.Render(Requirements, RequirementsData{Name: "Shop", MustHave: "- FR-001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "# Shop — House Requirements\n\n- FR-001\n" {
+		t.Errorf("override not used:\n%s", out)
+	}
+	// Other templates are untouched.
+	if out, _ := r.Render(Principles, PrinciplesData{Name: "Shop", Principles: "x"}); !strings.Contains(out, "# Shop — Principles") {
+		t.Errorf("embedded template lost:\n%s", out)
+	}
+}
+
+func TestLoadRenderer_InvalidOverrides(t *testing.T) {
+	root := t.TempDir()
+	dir := OverridesPath(root)
+	writeOverride(t, dir, Charter, "# {{ .Name }\n")
+	writeOverride(t, dir, Design, "# {{ .Title }}\n")
+	writeOverride(t, dir, "roadmap.md.tmpl", "# Roadmap\n")
+	writeOverride(t, dir, Tasks, "# {{ .Name }} — Tasks\n")
+
+	_, err := LoadRenderer(root)
+	if err == nil {
+		t.Fatal("LoadRenderer should fail")
+	}
+	for _, want := range []string{
+		"charter.md.tmpl does not parse",
+		"design.md.tmpl does not render with templates.DesignData",
+		"roadmap.md.tmpl is not a Hoofy template",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q: %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), Tasks) {
+		t.Errorf("valid override reported: %v", err)
+	}
+
+	names, problems, err := ValidateOverrides(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 3 || strings.Join(names, ",") != "design.md.tmpl,tasks.md.tmpl" {
+		t.Errorf("names = %v, problems = %v", names, problems)
+	}
+}
+
+func TestEject(t *testing.T) {
+	root := t.TempDir()
+
+	path, err := Eject(root, "requirements", false)
+	if err != nil {
+		t.Fatalf("Eject failed: %v", err)
+	}
+	if path != filepath.Join(OverridesPath(root), Requirements) {
+		t.Errorf("path = %s", path)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := Source(Requirements)
+	if string(got) != string(want) {
+		t.Error("ejected template differs from the embedded one")
+	}
+
+	// The ejected copy is a valid override as is.
+	r, err := LoadRenderer(root)
+	if err != nil || len(r.Overrides()) != 1 {
+		t.Fatalf("LoadRenderer after eject: %v, %v", r, err)
+	}
+
+	// Edits are not clobbered without force.
+	if err := os.WriteFile(path, []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Eject(root, Requirements, false); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Eject over an existing override = %v", err)
+	}
+	if _, err := Eject(root, "requirements.md", true); err != nil {
+		t.Fatalf("Eject --force failed: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != string(want) {
+		t.Error("--force should restore the default")
+	}
+
+	if _, err := Eject(root, "roadmap", false); err == nil {
+		t.Error("unknown template should fail")
+	}
+}
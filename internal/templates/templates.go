@@ -2,7 +2,8 @@
 //
 // Uses Go's embed package to bundle templates at compile time — no external
 // file dependencies at runtime (Dependency Inversion: depend on abstractions,
-// the binary carries everything it needs).
+// the binary carries everything it needs). Projects may override any of
+// them from docs/.hoofy/templates (see LoadRenderer).
 package templates
 
 import (
@@ -33,9 +34,11 @@ type Renderer interface {
 	Render(templateName string, data any) (string, error)
 }
 
-// EmbedRenderer renders templates from the embedded filesystem.
+// EmbedRenderer renders templates from the embedded filesystem, with
+// any project overrides layered on top.
 type EmbedRenderer struct {
 	templates *template.Template
+	overrides []string
 }
 
 // NewRenderer creates a renderer with all embedded templates parsed.
@@ -57,6 +60,12 @@ func (r *EmbedRenderer) Render(templateName string, data any) (string, error) {
 	return buf.String(), nil
 }
 
+// Overrides returns the names of the templates replaced by project
+// overrides, sorted.
+func (r *EmbedRenderer) Overrides() []string {
+	return r.overrides
+}
+
 // --- Template data structures ---
 
 // PrinciplesData holds the data for rendering project principles.
@@ -24,13 +24,13 @@ type StageObserver interface {
 // using topic_key upserts, so each stage has one evolving observation
 // per project. This enables cross-session awareness of SDD pipeline state.
 type MemoryBridge struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewMemoryBridge creates a bridge that auto-saves SDD stage completions
 // to the memory store. Returns nil if store is nil — callers should
 // check before using (or just assign to a StageObserver variable).
-func NewMemoryBridge(store *memory.Store) *MemoryBridge {
+func NewMemoryBridge(store memory.Backend) *MemoryBridge {
 	if store == nil {
 		return nil
 	}
@@ -136,6 +136,85 @@ func notifyChangeObserver(obs ChangeObserver, changeID string, stage changes.Cha
 	obs.OnChangeStageComplete(changeID, stage, content)
 }
 
+// ChangeLifecycleObserver is notified when a change moves between
+// lifecycle statuses (archive, abandon, reopen, pause, switch) or is
+// resized. It's an optional dependency — tools work fine with a nil observer.
+type ChangeLifecycleObserver interface {
+	// OnChangeTransition is called after the new status has been
+	// persisted. note carries extra context such as an abandon reason.
+	OnChangeTransition(changeID string, from, to changes.ChangeStatus, note string)
+	// OnChangeResized is called after the resized change has been
+	// persisted. note describes the stages added and removed.
+	OnChangeResized(changeID string, from, to changes.ChangeSize, note string)
+}
+
+// OnChangeTransition records a change lifecycle transition in memory.
+// Each transition is its own observation (no topic_key), so the history
+// of a change can be reconstructed from memory.
+//
+// Best-effort: memory save failures are logged but don't propagate.
+func (b *MemoryBridge) OnChangeTransition(changeID string, from, to changes.ChangeStatus, note string) {
+	title := fmt.Sprintf("Change %s: %s", to, changeID)
+	content := fmt.Sprintf("**Transition**: change `%s` %s → %s", changeID, from, to)
+	if note != "" {
+		content += "\n\n" + note
+	}
+
+	_ = b.store.CreateSession("manual-save", "", "")
+
+	_, err := b.store.AddObservation(memory.AddObservationParams{
+		SessionID: "manual-save",
+		Type:      "decision",
+		Title:     title,
+		Content:   content,
+		Scope:     "project",
+	})
+	if err != nil {
+		log.Printf("WARNING: change bridge: record %s transition for %q: %v", to, changeID, err)
+	}
+}
+
+// OnChangeResized records a change resize in memory, one observation
+// per resize like lifecycle transitions.
+//
+// Best-effort: memory save failures are logged but don't propagate.
+func (b *MemoryBridge) OnChangeResized(changeID string, from, to changes.ChangeSize, note string) {
+	content := fmt.Sprintf("**Resize**: change `%s` %s → %s", changeID, from, to)
+	if note != "" {
+		content += "\n\n" + note
+	}
+
+	_ = b.store.CreateSession("manual-save", "", "")
+
+	_, err := b.store.AddObservation(memory.AddObservationParams{
+		SessionID: "manual-save",
+		Type:      "decision",
+		Title:     fmt.Sprintf("Change resized to %s: %s", to, changeID),
+		Content:   content,
+		Scope:     "project",
+	})
+	if err != nil {
+		log.Printf("WARNING: change bridge: record resize of %q: %v", changeID, err)
+	}
+}
+
+// notifyLifecycleObserver is a nil-safe helper called from change tool
+// Handle methods. If observer is nil, this is a no-op.
+func notifyLifecycleObserver(obs ChangeLifecycleObserver, changeID string, from, to changes.ChangeStatus, note string) {
+	if obs == nil {
+		return
+	}
+	obs.OnChangeTransition(changeID, from, to, note)
+}
+
+// notifyResizeObserver is a nil-safe helper called after a resize.
+func notifyResizeObserver(obs ChangeLifecycleObserver, changeID string, from, to changes.ChangeSize, note string) {
+	if obs == nil {
+		return
+	}
+	obs.OnChangeResized(changeID, from, to, note)
+}
+
 // normalizeProject converts a project name to a lowercase slug suitable
 // for use in topic_key paths (e.g. "My Project" → "my-project").
 func normalizeProject(name string) string {
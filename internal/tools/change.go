@@ -27,16 +27,19 @@ func (t *ChangeTool) Definition() mcp.Tool {
 	return mcp.NewTool("sdd_change",
 		mcp.WithDescription(
 			"Create a new change in the adaptive SDD pipeline. "+
-				"Each change has a type (feature, fix, refactor, enhancement) and "+
+				"Each change has a type (built-in: feature, fix, refactor, enhancement — "+
+				"projects may add more in docs/hoofy-flows.json) and "+
 				"size (small, medium, large) that determine which pipeline stages are required. "+
-				"Only one active change is allowed at a time. "+
+				"Only one active change is allowed per git branch — the change is bound to "+
+				"the branch checked out when it is created. "+
+				"Changes that must land in order can be linked with depends_on / blocks; "+
+				"a warning is shown when prerequisites haven't landed yet. "+
 				"Does NOT require sdd_init_project — works independently.",
 		),
 		mcp.WithString("type",
 			mcp.Required(),
-			mcp.Description("The kind of work: feature (new capability), fix (bug fix), "+
-				"refactor (restructure without behavior change), enhancement (improve existing feature)"),
-			mcp.Enum("feature", "fix", "refactor", "enhancement"),
+			mcp.Description("The kind of work: "+changeTypeSummary()),
+			mcp.Enum(changeTypeNames()...),
 		),
 		mcp.WithString("size",
 			mcp.Required(),
@@ -50,6 +53,13 @@ func (t *ChangeTool) Definition() mcp.Tool {
 			mcp.Description("Brief description of the change. Used to generate the change ID (slug). "+
 				"Example: 'Fix FTS5 empty query crash' → change ID 'fix-fts5-empty-query-crash'"),
 		),
+		mcp.WithString("depends_on",
+			mcp.Description("Comma-separated IDs of existing changes that must land (complete) before this one. "+
+				"Example: 'add-user-schema, add-auth-api'"),
+		),
+		mcp.WithString("blocks",
+			mcp.Description("Comma-separated IDs of existing changes that must wait for this one to land."),
+		),
 	)
 }
 
@@ -58,6 +68,16 @@ func (t *ChangeTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	changeType := changes.ChangeType(req.GetString("type", ""))
 	changeSize := changes.ChangeSize(req.GetString("size", ""))
 	description := req.GetString("description", "")
+	dependsOn := changes.ParseLinks(req.GetString("depends_on", ""))
+	blocks := changes.ParseLinks(req.GetString("blocks", ""))
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("finding project root: %w", err)
+	}
+	if err := useProjectFlows(projectRoot); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	// Validate required fields.
 	if err := changes.ValidateType(changeType); err != nil {
@@ -70,21 +90,17 @@ func (t *ChangeTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		return mcp.NewToolResultError("'description' is required — briefly describe the change"), nil
 	}
 
-	projectRoot, err := findProjectRoot()
-	if err != nil {
-		return nil, fmt.Errorf("finding project root: %w", err)
-	}
-
-	// Guard: only one active change at a time.
+	// Guard: only one active change per branch.
 	active, err := t.store.LoadActive(projectRoot)
 	if err != nil {
 		return nil, fmt.Errorf("checking active changes: %w", err)
 	}
 	if active != nil {
 		return mcp.NewToolResultError(fmt.Sprintf(
-			"An active change already exists: %q (%s/%s, stage: %s). "+
-				"Complete or archive it before starting a new one.",
-			active.ID, active.Type, active.Size, active.CurrentStage,
+			"An active change already exists: %q (%s/%s, stage: %s, branch: %s). "+
+				"Complete it, or set it aside with `sdd_change_manage` (action: pause or abandon), "+
+				"before starting a new one — or check out another branch.",
+			active.ID, active.Type, active.Size, active.CurrentStage, branchLabel(active.Branch),
 		)), nil
 	}
 
@@ -138,8 +154,26 @@ func (t *ChangeTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		Status:       changes.StatusActive,
 		CreatedAt:    now,
 		UpdatedAt:    now,
+		DependsOn:    dependsOn,
+		Blocks:       blocks,
+	}
+
+	// Links must point at existing changes without forming a cycle.
+	var pending []changes.ChangeRecord
+	if len(dependsOn) > 0 || len(blocks) > 0 {
+		all, err := t.store.List(projectRoot)
+		if err != nil {
+			return nil, fmt.Errorf("listing changes: %w", err)
+		}
+		if err := changes.ValidateLinks(change, all); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		pending = changes.PendingPrerequisites(change, all)
 	}
 
+	change.BindToHead(projectRoot)
+	change.RecordBaseCommit(projectRoot)
+
 	if err := t.store.Create(projectRoot, change); err != nil {
 		return nil, fmt.Errorf("creating change: %w", err)
 	}
@@ -159,12 +193,19 @@ func (t *ChangeTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 		}
 	}
 
+	baseLine := ""
+	if change.BaseCommit != "" {
+		baseLine = fmt.Sprintf("**Base commit:** `%s`\n", changes.ShortSHA(change.BaseCommit))
+	}
+
 	response := fmt.Sprintf(
 		"# Change Created\n\n"+
 			"**ID:** `%s`\n"+
 			"**Type:** %s\n"+
 			"**Size:** %s\n"+
 			"**Description:** %s\n"+
+			"**Branch:** %s\n"+
+			"%s%s"+
 			"**Status:** active\n\n"+
 			"## Pipeline (%d stages)\n\n"+
 			"%s\n"+
@@ -172,7 +213,7 @@ func (t *ChangeTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 			"Current stage: **%s**\n\n"+
 			"Generate the content for the `%s` stage, then call `sdd_change_advance` "+
 			"with the content to save it and move to the next stage.",
-		change.ID, changeType, changeSize, description,
+		change.ID, changeType, changeSize, description, branchLabel(change.Branch), baseLine, linkLines(change),
 		len(flow), stageList.String(),
 		flow[0], flow[0],
 	)
@@ -185,5 +226,64 @@ func (t *ChangeTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 			"limited context information without them."
 	}
 
+	if len(pending) > 0 {
+		response += "\n\n---\n\n" + formatPendingPrerequisites(pending)
+	}
+
 	return mcp.NewToolResultText(response), nil
 }
+
+// formatPendingPrerequisites warns about prerequisites that haven't
+// landed yet.
+func formatPendingPrerequisites(pending []changes.ChangeRecord) string {
+	var b strings.Builder
+	b.WriteString("⚠️ **Prerequisites not landed yet.** This change depends on:\n\n")
+	for _, c := range pending {
+		fmt.Fprintf(&b, "- `%s` (%s, stage: %s)\n", c.ID, c.Status, c.CurrentStage)
+	}
+	b.WriteString("\nFinish them first, or plan this change so it doesn't rely on their unmerged work.")
+	return b.String()
+}
+
+// linkLines renders a change's depends_on / blocks links as header
+// lines, or "" when it has none.
+func linkLines(change *changes.ChangeRecord) string {
+	var b strings.Builder
+	for _, links := range []struct {
+		label string
+		ids   []string
+	}{{"Depends on", change.DependsOn}, {"Blocks", change.Blocks}} {
+		if len(links.ids) == 0 {
+			continue
+		}
+		quoted := make([]string, len(links.ids))
+		for i, id := range links.ids {
+			quoted[i] = "`" + id + "`"
+		}
+		fmt.Fprintf(&b, "**%s:** %s\n", links.label, strings.Join(quoted, ", "))
+	}
+	return b.String()
+}
+
+// branchLabel renders a change's git branch for responses.
+func branchLabel(branch string) string {
+	if branch == "" {
+		return "(unbound)"
+	}
+	return "`" + branch + "`"
+}
+
+// changeTypeSummary lists the registered change types with their
+// descriptions, e.g. "feature (new capability), fix (bug fix)".
+func changeTypeSummary() string {
+	reg := changes.CurrentRegistry()
+	parts := make([]string, 0, len(reg.Types()))
+	for _, t := range reg.Types() {
+		if d := reg.Describe(t); d != "" {
+			parts = append(parts, fmt.Sprintf("%s (%s)", t, d))
+		} else {
+			parts = append(parts, string(t))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
+	"github.com/HendryAvila/Hoofy/internal/templates"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -14,13 +17,14 @@ import (
 // It is the workhorse of the adaptive pipeline — saves stage content
 // and advances the state machine.
 type ChangeAdvanceTool struct {
-	store  changes.Store
-	bridge ChangeObserver
+	store    changes.Store
+	renderer templates.Renderer
+	bridge   ChangeObserver
 }
 
-// NewChangeAdvanceTool creates a ChangeAdvanceTool with the given change store.
-func NewChangeAdvanceTool(store changes.Store) *ChangeAdvanceTool {
-	return &ChangeAdvanceTool{store: store}
+// NewChangeAdvanceTool creates a ChangeAdvanceTool with its dependencies.
+func NewChangeAdvanceTool(store changes.Store, renderer templates.Renderer) *ChangeAdvanceTool {
+	return &ChangeAdvanceTool{store: store, renderer: renderer}
 }
 
 // SetBridge injects an optional ChangeObserver for memory persistence.
@@ -28,39 +32,60 @@ func (t *ChangeAdvanceTool) SetBridge(obs ChangeObserver) { t.bridge = obs }
 
 // Definition returns the MCP tool definition for registration.
 func (t *ChangeAdvanceTool) Definition() mcp.Tool {
-	return mcp.NewTool("sdd_change_advance",
+	opts := []mcp.ToolOption{
 		mcp.WithDescription(
-			"Save content for the current stage and advance to the next stage. "+
-				"This is the workhorse tool for the adaptive change pipeline. "+
-				"It saves the AI-generated content as a markdown file, advances the state machine, "+
-				"and reports the next stage. When the final stage (verify) is completed, "+
-				"the change is marked as completed.",
+			"Save content for the current stage and advance to the next stage. " +
+				"This is the workhorse tool for the adaptive change pipeline. " +
+				"Pass the stage's structured fields (e.g. describe: summary, motivation; " +
+				"verify: results) to render the artifact from the stage template, or raw `content` " +
+				"for stages without a template or free-form artifacts — not both. " +
+				"It saves the artifact as a markdown file, advances the state machine, " +
+				"and reports the next stage. When the final stage (verify) is completed, " +
+				"the change is marked as completed. " +
+				"Content is validated per stage before it is saved: context-check must list the " +
+				"artifacts it checked, spec must define requirement IDs (FR-001), tasks must define " +
+				"TASK-NNN items with acceptance criteria and acyclic **Dependencies** on defined tasks " +
+				"(the computed waves are saved as tasks.json), and verify must mention every task. " +
+				"A spec with ADDED/MODIFIED/REMOVED Requirements sections (and ADDED Business Rules) " +
+				"is previewed as a diff when saved and merged into docs/requirements.md and " +
+				"docs/business-rules.md when the change completes.",
 		),
 		mcp.WithString("content",
-			mcp.Required(),
-			mcp.Description("The AI-generated content for the current stage. "+
-				"Must be actual content, not placeholders. Written as <stage>.md in the change directory."),
+			mcp.Description("Raw markdown for the current stage, used as-is instead of the stage template. "+
+				"Must be actual content, not placeholders. Written as <stage>.md in the change directory. "+
+				"Required for stages without a template."),
 		),
 		mcp.WithString("title",
 			mcp.Description("Optional title for the stage content. "+
-				"Used in the response and memory observation."),
+				"Used as the artifact heading (default: the change description), in the response and memory observation."),
 		),
-	)
+		mcp.WithBoolean("force",
+			mcp.Description("Accept content that fails stage validation. "+
+				"The skipped violations are recorded in change.json. Default: false"),
+		),
+	}
+	for _, name := range templates.ChangeFieldNames() {
+		opts = append(opts, mcp.WithString(name,
+			mcp.Description(fmt.Sprintf("%s Stages: %s.",
+				templates.ChangeFieldDescription(name), strings.Join(templates.ChangeFieldStages(name), ", "))),
+		))
+	}
+	return mcp.NewTool("sdd_change_advance", opts...)
 }
 
 // Handle processes the sdd_change_advance tool call.
 func (t *ChangeAdvanceTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	content := req.GetString("content", "")
 	title := req.GetString("title", "")
-
-	if strings.TrimSpace(content) == "" {
-		return mcp.NewToolResultError("'content' is required — provide the AI-generated content for the current stage"), nil
-	}
+	force := req.GetBool("force", false)
 
 	projectRoot, err := findProjectRoot()
 	if err != nil {
 		return nil, fmt.Errorf("finding project root: %w", err)
 	}
+	if err := useProjectFlows(projectRoot); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	active, err := t.store.LoadActive(projectRoot)
 	if err != nil {
@@ -77,33 +102,85 @@ func (t *ChangeAdvanceTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("unknown stage %q — no filename mapping", currentStage)
 	}
 
-	// Write content to sdd/changes/<id>/<stage>.md
+	content, errResult, err := t.stageContent(req, active, content, title)
+	if errResult != nil || err != nil {
+		return errResult, err
+	}
+
 	changeDir := changes.ChangePath(projectRoot, active.ID)
-	stagePath := filepath.Join(changeDir, filename)
-	if err := writeStageFile(stagePath, content); err != nil {
-		return nil, fmt.Errorf("writing %s: %w", filename, err)
+
+	// Validate the content before anything is written.
+	violations := changes.ValidateStage(changes.StageInput{
+		Change:    active,
+		Stage:     currentStage,
+		ChangeDir: changeDir,
+		Content:   content,
+	})
+	if len(violations) > 0 {
+		if !force {
+			return validationErrorResult(currentStage, violations), nil
+		}
+		changes.RecordOverride(active, violations)
 	}
 
 	// Check if this is the final stage (verify).
 	isLast := changes.IsLastStage(active)
 
+	// Link the change to its code: commits and diff since the base commit.
+	codeNote := ""
+	if isLast && active.BaseCommit != "" {
+		summary, err := changes.CaptureCode(projectRoot, active.BaseCommit)
+		if err != nil {
+			codeNote = fmt.Sprintf("\n\n⚠️ Could not capture code changes: %v", err)
+		} else {
+			active.Code = summary
+			content = strings.TrimRight(content, "\n") + "\n\n" + summary.Markdown(active.BaseCommit)
+			codeNote = fmt.Sprintf("\n\n**Code:** %d commit(s), %d file(s) touched since `%s` — recorded in verify.md and change.json.",
+				len(summary.Commits), len(summary.Files), changes.ShortSHA(active.BaseCommit))
+		}
+	}
+
+	// Write content to sdd/changes/<id>/<stage>.md
+	stagePath := filepath.Join(changeDir, filename)
+	if err := writeStageFile(stagePath, content); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", filename, err)
+	}
+
+	deltaNote := ""
+	var specPlan *changes.DeltaPlan
 	if isLast {
-		// Final stage — complete the change.
+		// Final stage — complete the change. The spec delta is recorded
+		// now and written to the project artifacts once the record is saved.
 		if err := changes.CompleteChange(active); err != nil {
-			return nil, fmt.Errorf("completing change: %w", err)
+			return mcp.NewToolResultError(fmt.Sprintf("completing change: %v", err)), nil
 		}
+		specPlan, deltaNote = planSpecMerge(projectRoot, active)
 	} else {
 		// Advance to the next stage.
 		if err := changes.Advance(active); err != nil {
-			return nil, fmt.Errorf("advancing change: %w", err)
+			return mcp.NewToolResultError(fmt.Sprintf("advancing change: %v", err)), nil
 		}
 	}
 
+	// Show what the spec's delta sections will do to the project artifacts.
+	if currentStage == changes.StageSpec && !isLast {
+		deltaNote = previewSpecDelta(projectRoot, active)
+	}
+
+	// Persist the task graph next to tasks.md.
+	if currentStage == changes.StageTasks {
+		deltaNote += saveChangeTaskGraph(stagePath, active.ID, content)
+	}
+
 	// Persist updated change record.
 	if err := t.store.Save(projectRoot, active); err != nil {
 		return nil, fmt.Errorf("saving change: %w", err)
 	}
 
+	if specPlan != nil {
+		deltaNote = t.writeSpecMerge(projectRoot, active, specPlan)
+	}
+
 	// Notify bridge.
 	notifyChangeObserver(t.bridge, active.ID, currentStage, content)
 
@@ -117,9 +194,9 @@ func (t *ChangeAdvanceTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 				"**Size:** %s\n"+
 				"**Status:** completed\n\n"+
 				"All stages have been completed. The change artifacts are in `sdd/changes/%s/`.\n\n"+
-				"You can archive this change with `sdd_change_status` to review it, "+
-				"or start a new change with `sdd_change`.",
-			currentStage, active.ID, active.Type, active.Size, active.ID,
+				"You can archive this change with `sdd_change_manage` (action: archive), "+
+				"or start a new change with `sdd_change`.%s%s",
+			currentStage, active.ID, active.Type, active.Size, active.ID, codeNote, deltaNote,
 		)
 		return mcp.NewToolResultText(response), nil
 	}
@@ -128,14 +205,7 @@ func (t *ChangeAdvanceTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 	nextStage := active.CurrentStage
 	var stageProgress strings.Builder
 	for _, s := range active.Stages {
-		marker := "⬜"
-		switch s.Status {
-		case "completed":
-			marker = "✅"
-		case "in_progress":
-			marker = "🔄"
-		}
-		fmt.Fprintf(&stageProgress, "  %s %s\n", marker, s.Name)
+		fmt.Fprintf(&stageProgress, "  %s %s\n", stageMarker(s.Status), s.Name)
 	}
 
 	titleLine := ""
@@ -152,11 +222,218 @@ func (t *ChangeAdvanceTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 			"## Next Step\n\n"+
 			"Current stage: **%s**\n\n"+
 			"Generate the content for the `%s` stage, then call `sdd_change_advance` "+
-			"with the content to save it and move to the next stage.",
+			"with the content to save it and move to the next stage.%s",
 		currentStage, titleLine, active.ID, filename,
 		stageProgress.String(),
-		nextStage, nextStage,
+		nextStage, nextStage, deltaNote,
 	)
 
 	return mcp.NewToolResultText(response), nil
 }
+
+// stageContent returns the artifact for the current stage: raw content
+// as given, or the stage template rendered from the structured fields.
+func (t *ChangeAdvanceTool) stageContent(req mcp.CallToolRequest, change *changes.ChangeRecord, content, title string) (string, *mcp.CallToolResult, error) {
+	stage := string(change.CurrentStage)
+	fields := map[string]string{}
+	for _, name := range templates.ChangeFieldNames() {
+		if v := strings.TrimSpace(req.GetString(name, "")); v != "" {
+			fields[name] = v
+		}
+	}
+	tmpl, stageFields, hasTemplate := templates.ChangeStageTemplate(stage)
+
+	if strings.TrimSpace(content) != "" {
+		if len(fields) > 0 {
+			return "", mcp.NewToolResultError(fmt.Sprintf(
+				"pass either 'content' or the %s fields, not both (got fields: %s)", stage, joinKeys(fields))), nil
+		}
+		return content, nil, nil
+	}
+	if !hasTemplate {
+		return "", mcp.NewToolResultError(fmt.Sprintf(
+			"'content' is required — the %s stage has no template, so provide its markdown content", stage)), nil
+	}
+	if len(fields) == 0 {
+		return "", mcp.NewToolResultError(fmt.Sprintf(
+			"'content' is required — provide the content for the %s stage, or its fields: %s",
+			stage, formatChangeFields(stageFields))), nil
+	}
+
+	var problems []string
+	for name := range fields {
+		if !slices.ContainsFunc(stageFields, func(f templates.ChangeField) bool { return f.Name == name }) {
+			problems = append(problems, fmt.Sprintf("'%s' is not a %s field", name, stage))
+		}
+	}
+	for _, f := range stageFields {
+		if f.Required && fields[f.Name] == "" {
+			problems = append(problems, fmt.Sprintf("'%s' is required", f.Name))
+		}
+	}
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return "", mcp.NewToolResultError(fmt.Sprintf("invalid %s fields: %s. Fields: %s",
+			stage, strings.Join(problems, "; "), formatChangeFields(stageFields))), nil
+	}
+
+	if strings.TrimSpace(title) == "" {
+		title = change.Description
+	}
+	rendered, err := t.renderer.Render(tmpl, templates.ChangeStageData{
+		Title:    title,
+		ChangeID: change.ID,
+		Type:     string(change.Type),
+		Size:     string(change.Size),
+		Stage:    stage,
+		Fields:   fields,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("rendering %s: %w", stage, err)
+	}
+	return rendered, nil, nil
+}
+
+// formatChangeFields lists a stage's fields, marking the optional ones.
+func formatChangeFields(fields []templates.ChangeField) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+		if !f.Required {
+			names[i] += " (optional)"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// joinKeys returns a map's keys, sorted and comma-separated.
+func joinKeys(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return strings.Join(keys, ", ")
+}
+
+// validationErrorResult reports stage validation failures as a tool
+// error. The violations are also attached as structured content so
+// clients can act on each rule.
+func validationErrorResult(stage changes.ChangeStage, violations []changes.Violation) *mcp.CallToolResult {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "❌ The `%s` content failed validation (%d issue(s)):\n\n", stage, len(violations))
+	for _, v := range violations {
+		fmt.Fprintf(&sb, "- `%s`: %s\n", v.Rule, v.Message)
+	}
+	sb.WriteString("\nNothing was saved. Fix the content and call `sdd_change_advance` again, " +
+		"or pass `force: true` to accept it anyway — the override is recorded in change.json.")
+
+	result := mcp.NewToolResultStructured(map[string]any{
+		"stage":      stage,
+		"violations": violations,
+	}, sb.String())
+	result.IsError = true
+	return result
+}
+
+// previewSpecDelta renders the diff a spec's ADDED/MODIFIED/REMOVED
+// sections will apply to the project artifacts when the change completes.
+func previewSpecDelta(projectRoot string, change *changes.ChangeRecord) string {
+	plan, err := changes.PlanSpecDelta(projectRoot, change)
+	if err != nil {
+		return fmt.Sprintf("\n\n⚠️ Could not preview the spec delta: %v", err)
+	}
+	if plan == nil {
+		return ""
+	}
+	return "\n\n## Spec Delta (preview)\n\n" +
+		"Applied to the project artifacts when this change completes. " +
+		"ADDED IDs are renumbered to the next free project IDs.\n\n" +
+		formatDeltaPlan(projectRoot, plan)
+}
+
+// planSpecMerge plans the merge of the change's spec delta into the
+// project artifacts and records it on the change. Nothing is written:
+// the caller saves the change first, then calls writeSpecMerge, so a
+// failed save can't leave a merge that the next completion repeats.
+func planSpecMerge(projectRoot string, change *changes.ChangeRecord) (*changes.DeltaPlan, string) {
+	plan, err := changes.PlanSpecDelta(projectRoot, change)
+	if err != nil {
+		return nil, fmt.Sprintf("\n\n⚠️ Could not merge the spec delta: %v", err)
+	}
+	if plan == nil {
+		return nil, ""
+	}
+	plan.Record(change)
+	return plan, ""
+}
+
+// writeSpecMerge writes a recorded spec merge to the project artifacts.
+// If the write fails (the files are left untouched), the record is
+// reverted so the merge can be retried.
+func (t *ChangeAdvanceTool) writeSpecMerge(projectRoot string, change *changes.ChangeRecord, plan *changes.DeltaPlan) string {
+	if err := plan.Write(); err != nil {
+		change.SpecMerge = nil
+		if saveErr := t.store.Save(projectRoot, change); saveErr != nil {
+			return fmt.Sprintf("\n\n⚠️ Could not merge the spec delta: %v. Reverting spec_merge in change.json also failed (%v) — "+
+				"remove it by hand before merging again.", err, saveErr)
+		}
+		return fmt.Sprintf("\n\n⚠️ Could not merge the spec delta: %v", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n\n## Project Specs Updated\n\n")
+	for _, m := range plan.Merge.Added {
+		fmt.Fprintf(&sb, "- ✅ %s added (spec.md: %s)\n", m.Project, m.Local)
+	}
+	for _, id := range plan.Merge.Modified {
+		fmt.Fprintf(&sb, "- 🔄 %s modified\n", id)
+	}
+	for _, id := range plan.Merge.Removed {
+		fmt.Fprintf(&sb, "- ❌ %s removed\n", id)
+	}
+	if plan.Merge.RulesAdded > 0 {
+		fmt.Fprintf(&sb, "- ✅ %d business rule(s) added\n", plan.Merge.RulesAdded)
+	}
+	sb.WriteString("\n")
+	sb.WriteString(formatDeltaPlan(projectRoot, plan))
+	return sb.String()
+}
+
+// saveChangeTaskGraph builds the task graph of a change's tasks.md and
+// saves it as tasks.json, keeping the statuses of tasks already
+// tracked with sdd_task. Failures are reported, not fatal: the content
+// already passed validation, or was forced past it.
+func saveChangeTaskGraph(tasksPath, changeID, content string) string {
+	parsed := taskgraph.Parse(content)
+	if len(parsed) == 0 {
+		return ""
+	}
+	graph, err := taskgraph.Build(parsed)
+	if err != nil {
+		return fmt.Sprintf("\n\n⚠️ tasks.json was not written: %v", err)
+	}
+	if prev, err := taskgraph.Load(tasksPath); err == nil {
+		graph.Carry(prev)
+	}
+	if err := graph.Save(tasksPath); err != nil {
+		return fmt.Sprintf("\n\n⚠️ Could not save the task graph: %v", err)
+	}
+	savedTo := fmt.Sprintf("sdd/changes/%s/%s", changeID, taskgraph.File)
+	return "\n\n" + formatTaskGraph(graph, graph.CheckWaves(taskgraph.ParseWaves(content)), savedTo)
+}
+
+// formatDeltaPlan renders a plan's warnings and diff.
+func formatDeltaPlan(projectRoot string, plan *changes.DeltaPlan) string {
+	var sb strings.Builder
+	for _, w := range plan.Warnings {
+		fmt.Fprintf(&sb, "⚠️ %s\n", w)
+	}
+	if len(plan.Warnings) > 0 {
+		sb.WriteString("\n")
+	}
+	if diff := plan.Diff(projectRoot); diff != "" {
+		fmt.Fprintf(&sb, "```diff\n%s```\n", diff)
+	}
+	return sb.String()
+}
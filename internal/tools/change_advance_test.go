@@ -2,12 +2,15 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -15,7 +18,7 @@ import (
 
 func TestChangeAdvanceTool_Definition(t *testing.T) {
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 	def := tool.Definition()
 
 	if def.Name != "sdd_change_advance" {
@@ -28,7 +31,7 @@ func TestChangeAdvanceTool_Handle_AdvancesStage(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// fix/small flow: describe → context-check → tasks → verify
 	// Current stage: describe (first, in_progress)
@@ -78,15 +81,15 @@ func TestChangeAdvanceTool_Handle_CompletesChange(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// fix/small flow: describe → context-check → tasks → verify
 	// Advance through all stages.
 	stages := []string{
 		"# Description\n\nFix something.",
-		"# Context Check\n\nNo conflicts found.",
-		"# Tasks\n\n- [ ] Task 1",
-		"# Verification\n\nAll good.",
+		"# Context Check\n\nChecked business-rules.md — no conflicts found.",
+		"# Tasks\n\n### TASK-001: Task 1\n**Acceptance Criteria**:\n- [ ] Done",
+		"# Verification\n\nTASK-001: all good.",
 	}
 
 	for _, content := range stages {
@@ -128,14 +131,14 @@ func TestChangeAdvanceTool_Handle_CompletionResponse(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// fix/small: describe → context-check → tasks → verify
 	contents := []string{
 		"# Describe\n\nContent.",
-		"# Context Check\n\nContent.",
-		"# Tasks\n\nContent.",
-		"# Verify\n\nContent.",
+		"# Context Check\n\nNo artifacts found.",
+		"# Tasks\n\n### TASK-001: Task 1\n**Acceptance Criteria**:\n- [ ] Done",
+		"# Verify\n\nTASK-001 verified.",
 	}
 
 	var lastResult *mcp.CallToolResult
@@ -169,7 +172,7 @@ func TestChangeAdvanceTool_Handle_EmptyContent(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	req := mcp.CallToolRequest{}
 	req.Params.Arguments = map[string]interface{}{
@@ -189,12 +192,116 @@ func TestChangeAdvanceTool_Handle_EmptyContent(t *testing.T) {
 	}
 }
 
+func TestChangeAdvanceTool_Handle_StageTemplate(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "Fix empty query crash")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
+	advance := func(args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		args map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{}, "or its fields: summary, motivation, affected_areas (optional), acceptance_criteria (optional)"},
+		{map[string]interface{}{"summary": "Guard empty queries."}, "'motivation' is required"},
+		{map[string]interface{}{"summary": "x", "motivation": "y", "results": "z"}, "'results' is not a describe field"},
+		{map[string]interface{}{"content": "# Describe", "summary": "x"}, "not both (got fields: summary)"},
+	} {
+		result := advance(tc.args)
+		if !isErrorResult(result) || !strings.Contains(getResultText(result), tc.want) {
+			t.Errorf("%v: got %s, want error containing %q", tc.args, getResultText(result), tc.want)
+		}
+	}
+
+	result := advance(map[string]interface{}{
+		"summary":        "Return no results for an empty FTS5 query.",
+		"motivation":     "Searching for '' crashes the server.",
+		"affected_areas": "- internal/memory/search.go",
+	})
+	if isErrorResult(result) {
+		t.Fatalf("describe failed: %s", getResultText(result))
+	}
+	data, err := os.ReadFile(filepath.Join(changes.ChangePath(tmpDir, change.ID), "describe.md"))
+	if err != nil {
+		t.Fatalf("describe.md should exist: %v", err)
+	}
+	for _, want := range []string{
+		"# Fix empty query crash — Description",
+		"Change `fix-empty-query-crash` (fix/small) | Stage: describe",
+		"## Summary\n\nReturn no results for an empty FTS5 query.",
+		"## Affected Areas\n\n- internal/memory/search.go",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("describe.md missing %q:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "## Acceptance Criteria") {
+		t.Errorf("empty optional section rendered:\n%s", data)
+	}
+
+	// Rendered artifacts go through stage validation like raw content.
+	result = advance(map[string]interface{}{"artifacts_checked": "Looked around.", "conflicts": "None found"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "context-check") {
+		t.Fatalf("expected validation error, got: %s", getResultText(result))
+	}
+	result = advance(map[string]interface{}{
+		"artifacts_checked": "- docs/requirements.md — FR-004 covers search",
+		"conflicts":         "None found",
+		"title":             "Empty query guard",
+	})
+	if isErrorResult(result) {
+		t.Fatalf("context-check failed: %s", getResultText(result))
+	}
+	data, _ = os.ReadFile(filepath.Join(changes.ChangePath(tmpDir, change.ID), "context-check.md"))
+	if !strings.Contains(string(data), "# Empty query guard — Context Check") {
+		t.Errorf("title should head the artifact:\n%s", data)
+	}
+}
+
+func TestChangeAdvanceTool_Handle_StageWithoutTemplate(t *testing.T) {
+	tmpDir, cleanup := setupChangeProject(t)
+	defer cleanup()
+	t.Cleanup(func() { changes.SetRegistry(nil) })
+
+	flows := `{"stages": {"threat-model": {}},
+  "types": {"security": {"extends": "fix", "flows": {"small": ["threat-model", "context-check", "verify"]}}}}`
+	if err := os.WriteFile(changes.FlowsPath(tmpDir), []byte(flows), 0o644); err != nil {
+		t.Fatalf("setup: write flows: %v", err)
+	}
+	create := mcp.CallToolRequest{}
+	create.Params.Arguments = map[string]interface{}{"type": "security", "size": "small", "description": "rotate keys"}
+	if result, err := NewChangeTool(changes.NewFileStore()).Handle(context.Background(), create); err != nil || isErrorResult(result) {
+		t.Fatalf("create failed: %v %s", err, getResultText(result))
+	}
+
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]interface{}{"summary": "Rotate the keys."}
+	result, err := NewChangeAdvanceTool(changes.NewFileStore(), mustRenderer(t)).Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "the threat-model stage has no template") {
+		t.Errorf("expected content required error, got: %s", getResultText(result))
+	}
+}
+
 func TestChangeAdvanceTool_Handle_NoContent(t *testing.T) {
 	_, cleanup, _ := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "no content test")
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	req := mcp.CallToolRequest{}
 	req.Params.Arguments = map[string]interface{}{}
@@ -213,7 +320,7 @@ func TestChangeAdvanceTool_Handle_NoActiveChange(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	req := mcp.CallToolRequest{}
 	req.Params.Arguments = map[string]interface{}{
@@ -238,7 +345,7 @@ func TestChangeAdvanceTool_Handle_WritesCorrectFilename(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// refactor/small: scope → context-check → tasks → verify
 	// First stage: scope → should write scope.md
@@ -263,7 +370,7 @@ func TestChangeAdvanceTool_Handle_WithTitle(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	req := mcp.CallToolRequest{}
 	req.Params.Arguments = map[string]interface{}{
@@ -290,7 +397,7 @@ func TestChangeAdvanceTool_Handle_ProgressMarkers(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// feature/medium: charter → context-check → spec → tasks → verify
 	// Advance first stage.
@@ -319,15 +426,15 @@ func TestChangeAdvanceTool_Handle_MediumFlowFullCycle(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// fix/medium: describe → context-check → spec → tasks → verify
 	contents := []string{
 		"# Describe\n\nDescription.",
-		"# Context Check\n\nNo conflicts.",
-		"# Spec\n\nSpecification.",
-		"# Tasks\n\n- [ ] Task 1",
-		"# Verify\n\nVerification.",
+		"# Context Check\n\nChecked requirements.md — no conflicts.",
+		"# Spec\n\n- **FR-001**: Specification.",
+		"# Tasks\n\n### TASK-001: Task 1\n**Acceptance Criteria**:\n- [ ] Done",
+		"# Verify\n\nTASK-001 verified.",
 	}
 
 	for i, c := range contents {
@@ -357,17 +464,17 @@ func TestChangeAdvanceTool_Handle_LargeFlowFullCycle(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// feature/large: charter → context-check → spec → clarify → design → tasks → verify
 	contents := []string{
 		"# Charter\n\nCharter content.",
-		"# Context Check\n\nNo conflicts.",
-		"# Spec\n\nSpecification.",
+		"# Context Check\n\nChecked requirements.md — no conflicts.",
+		"# Spec\n\n- **FR-001**: Specification.",
 		"# Clarify\n\nClarifications.",
 		"# Design\n\nArchitecture.",
-		"# Tasks\n\n- [ ] Task 1",
-		"# Verify\n\nVerification.",
+		"# Tasks\n\n### TASK-001: Task 1\n**Acceptance Criteria**:\n- [ ] Done",
+		"# Verify\n\nTASK-001 verified.",
 	}
 
 	for i, c := range contents {
@@ -394,9 +501,320 @@ func TestChangeAdvanceTool_Handle_LargeFlowFullCycle(t *testing.T) {
 	}
 }
 
+func TestChangeAdvanceTool_Handle_ValidationAndForce(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "validate me")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
+	advance := func(args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		return result
+	}
+
+	advance(map[string]interface{}{"content": "# Describe\n\nFix it."})
+
+	// Invalid context-check: structured error, nothing saved.
+	result := advance(map[string]interface{}{"content": "# Context Check\n\nLooks fine."})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "context-check.artifacts") {
+		t.Fatalf("expected validation error, got: %s", getResultText(result))
+	}
+	structured, ok := result.StructuredContent.(map[string]any)
+	if !ok || structured["stage"] != changes.StageContextCheck {
+		t.Errorf("structured content = %#v", result.StructuredContent)
+	}
+	changeDir := changes.ChangePath(tmpDir, change.ID)
+	if _, err := os.Stat(filepath.Join(changeDir, "context-check.md")); !os.IsNotExist(err) {
+		t.Error("rejected content must not be written")
+	}
+
+	// Forced: accepted and recorded.
+	result = advance(map[string]interface{}{"content": "# Context Check\n\nLooks fine.", "force": true})
+	if isErrorResult(result) {
+		t.Fatalf("forced advance failed: %s", getResultText(result))
+	}
+	reloaded, err := store.Load(tmpDir, change.ID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if reloaded.CurrentStage != changes.StageTasks {
+		t.Errorf("CurrentStage = %s, want tasks", reloaded.CurrentStage)
+	}
+	if len(reloaded.Overrides) != 1 || reloaded.Overrides[0].Stage != changes.StageContextCheck ||
+		reloaded.Overrides[0].Violations[0].Rule != "context-check.artifacts" {
+		t.Errorf("Overrides = %+v", reloaded.Overrides)
+	}
+}
+
+func TestChangeAdvanceTool_Handle_TasksSavesGraph(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "graph me")
+	defer cleanup()
+
+	tool := NewChangeAdvanceTool(changes.NewFileStore(), mustRenderer(t))
+	advance := func(content string) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]interface{}{"content": content}
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		if isErrorResult(result) {
+			t.Fatalf("advance failed: %s", getResultText(result))
+		}
+		return result
+	}
+	advance("# Describe\n\nFix it.")
+	advance("# Context Check\n\nNo artifacts found.")
+
+	result := advance(`# Tasks
+
+### TASK-001: Model
+**Dependencies**: None
+**Acceptance Criteria**: saved
+
+### TASK-002: Endpoint
+**Dependencies**: TASK-001
+**Acceptance Criteria**: 201
+
+### TASK-003: Docs
+**Dependencies**: None
+**Acceptance Criteria**: written
+
+## Execution Waves
+
+**Wave 1**:
+- TASK-001
+- TASK-002
+- TASK-003
+`)
+	text := getResultText(result)
+	for _, want := range []string{
+		"## Task Graph",
+		"3 task(s) in 2 wave(s)",
+		"TASK-002 is in wave 1 but depends on TASK-001 in wave 1 (computed: wave 2)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("response missing %q:\n%s", want, text)
+		}
+	}
+
+	graph, err := taskgraph.Load(filepath.Join(changes.ChangePath(tmpDir, change.ID), "tasks.md"))
+	if err != nil || graph == nil {
+		t.Fatalf("Load tasks.json = %v, %v", graph, err)
+	}
+	if len(graph.Waves) != 2 || strings.Join(graph.Waves[0], ",") != "TASK-001,TASK-003" || graph.Waves[1][0] != "TASK-002" {
+		t.Errorf("Waves = %v", graph.Waves)
+	}
+}
+
+// gitInit turns dir into a git repository with one commit.
+// Skips the test when git is not installed.
+func gitInit(t *testing.T, dir string) func(args ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-q", "-b", "main")
+	run("add", ".")
+	run("commit", "-q", "-m", "initial")
+	return run
+}
+
+func TestChangeAdvanceTool_Handle_VerifyCapturesCode(t *testing.T) {
+	tmpDir, cleanup := setupChangeProjectWithArtifacts(t)
+	defer cleanup()
+	git := gitInit(t, tmpDir)
+
+	store := changes.NewFileStore()
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]interface{}{"type": "fix", "size": "small", "description": "fix search crash"}
+	result, err := NewChangeTool(store).Handle(context.Background(), req)
+	if err != nil || isErrorResult(result) {
+		t.Fatalf("sdd_change failed: %v %s", err, getResultText(result))
+	}
+	if !strings.Contains(getResultText(result), "**Base commit:**") {
+		t.Errorf("response should show the base commit:\n%s", getResultText(result))
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "search.go"), []byte("package search\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	git("add", "search.go")
+	git("commit", "-q", "-m", "Guard empty query")
+
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
+	for _, c := range []string{
+		"# Describe\n\nCrash on empty query.",
+		"# Context Check\n\nChecked requirements.md — no conflicts.",
+		"# Tasks\n\n### TASK-001: Guard empty query\n**Acceptance Criteria**:\n- [ ] no panic",
+		"# Verify\n\nTASK-001 done.",
+	} {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]interface{}{"content": c}
+		result, err = tool.Handle(context.Background(), req)
+		if err != nil || isErrorResult(result) {
+			t.Fatalf("advance failed: %v %s", err, getResultText(result))
+		}
+	}
+	if !strings.Contains(getResultText(result), "1 commit(s), 1 file(s) touched") {
+		t.Errorf("completion should summarize the code:\n%s", getResultText(result))
+	}
+
+	verify, err := os.ReadFile(filepath.Join(changes.ChangePath(tmpDir, "fix-search-crash"), "verify.md"))
+	if err != nil || !strings.Contains(string(verify), "Guard empty query") || !strings.Contains(string(verify), "`search.go`") {
+		t.Errorf("verify.md should list commits and files: %v\n%s", err, verify)
+	}
+	rec, err := store.Load(tmpDir, "fix-search-crash")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if rec.Code == nil || len(rec.Code.Files) != 1 || rec.Code.Files[0] != "search.go" {
+		t.Fatalf("change.json code = %+v", rec.Code)
+	}
+
+	// Later context checks show which files the change touched.
+	req = mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]interface{}{"change_description": "search crash on unicode"}
+	result, err = NewContextCheckTool(store, nil).Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("context check failed: %v", err)
+	}
+	if !strings.Contains(getResultText(result), "Files touched: `search.go`") {
+		t.Errorf("context check should list touched files:\n%s", getResultText(result))
+	}
+}
+
+func TestChangeAdvanceTool_Handle_SpecDeltaPreviewAndMerge(t *testing.T) {
+	tmpDir, cleanup, _ := createActiveChange(t, changes.TypeFix, changes.SizeMedium, "sso login")
+	defer cleanup()
+	reqPath := filepath.Join(tmpDir, "docs", "requirements.md")
+	original := "# Requirements\n\n## Functional Requirements\n\n### Must Have\n\n- **FR-001**: Users can sign up\n- **FR-002**: Users can log in with email\n"
+	if err := os.WriteFile(reqPath, []byte(original), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	store := changes.NewFileStore()
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
+	advance := func(content string) string {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]interface{}{"content": content}
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil || isErrorResult(result) {
+			t.Fatalf("advance failed: %v %s", err, getResultText(result))
+		}
+		return getResultText(result)
+	}
+
+	advance("# Describe\n\nLogin should accept SSO.")
+	advance("# Context Check\n\nChecked requirements.md — FR-002 changes.")
+	text := advance("# Spec\n\n## ADDED Requirements\n\n- **FR-001**: Users can link an SSO identity\n\n" +
+		"## MODIFIED Requirements\n\n- **FR-002**: Users can log in with email or SSO\n")
+	if !strings.Contains(text, "## Spec Delta (preview)") ||
+		!strings.Contains(text, "+- **FR-003**: Users can link an SSO identity _(added by `sso-login`)_") ||
+		!strings.Contains(text, "-- **FR-002**: Users can log in with email") {
+		t.Errorf("spec stage should preview the delta:\n%s", text)
+	}
+	if data, _ := os.ReadFile(reqPath); string(data) != original {
+		t.Error("the preview must not touch requirements.md")
+	}
+
+	advance("# Tasks\n\n### TASK-001: SSO login\n**Acceptance Criteria**:\n- [ ] works")
+	text = advance("# Verify\n\nTASK-001 verified.")
+	if !strings.Contains(text, "## Project Specs Updated") || !strings.Contains(text, "FR-003 added (spec.md: FR-001)") {
+		t.Errorf("completion should report the merge:\n%s", text)
+	}
+	data, _ := os.ReadFile(reqPath)
+	if !strings.Contains(string(data), "- **FR-002**: Users can log in with email or SSO _(modified by `sso-login`)_") ||
+		!strings.Contains(string(data), "- **FR-003**: Users can link an SSO identity _(added by `sso-login`)_") {
+		t.Errorf("requirements.md not merged:\n%s", data)
+	}
+	rec, err := store.Load(tmpDir, "sso-login")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if rec.SpecMerge == nil || len(rec.SpecMerge.Added) != 1 || rec.SpecMerge.Added[0].Project != "FR-003" {
+		t.Errorf("change.json spec_merge = %+v", rec.SpecMerge)
+	}
+}
+
+// saveFailingStore fails Save while fail is set.
+type saveFailingStore struct {
+	changes.Store
+	fail bool
+}
+
+func (s *saveFailingStore) Save(projectRoot string, c *changes.ChangeRecord) error {
+	if s.fail {
+		return errors.New("disk full")
+	}
+	return s.Store.Save(projectRoot, c)
+}
+
+func TestChangeAdvanceTool_Handle_SpecMergeAfterSave(t *testing.T) {
+	tmpDir, cleanup, _ := createActiveChange(t, changes.TypeFix, changes.SizeMedium, "sso login")
+	defer cleanup()
+	reqPath := filepath.Join(tmpDir, "docs", "requirements.md")
+	original := "# Requirements\n\n## Functional Requirements\n\n### Must Have\n\n- **FR-001**: Users can sign up\n"
+	if err := os.WriteFile(reqPath, []byte(original), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	store := &saveFailingStore{Store: changes.NewFileStore()}
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
+	advance := func(content string) (*mcp.CallToolResult, error) {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]interface{}{"content": content}
+		return tool.Handle(context.Background(), req)
+	}
+	for _, content := range []string{
+		"# Describe\n\nLogin should accept SSO.",
+		"# Context Check\n\nChecked requirements.md — nothing conflicts.",
+		"# Spec\n\n## ADDED Requirements\n\n- **FR-001**: Users can link an SSO identity\n",
+		"# Tasks\n\n### TASK-001: SSO login\n**Acceptance Criteria**:\n- [ ] works",
+	} {
+		if result, err := advance(content); err != nil || isErrorResult(result) {
+			t.Fatalf("advance failed: %v %s", err, getResultText(result))
+		}
+	}
+
+	// The completion can't be saved: the project specs stay untouched.
+	store.fail = true
+	if _, err := advance("# Verify\n\nTASK-001 verified."); err == nil {
+		t.Fatal("expected save error")
+	}
+	if data, _ := os.ReadFile(reqPath); string(data) != original {
+		t.Errorf("requirements.md changed before the change was saved:\n%s", data)
+	}
+
+	// Retrying merges exactly once.
+	store.fail = false
+	if result, err := advance("# Verify\n\nTASK-001 verified."); err != nil || isErrorResult(result) {
+		t.Fatalf("retry failed: %v %s", err, getResultText(result))
+	}
+	data, _ := os.ReadFile(reqPath)
+	if strings.Count(string(data), "Users can link an SSO identity") != 1 || !strings.Contains(string(data), "**FR-002**: Users can link") {
+		t.Errorf("requirements.md =\n%s", data)
+	}
+}
+
 func TestChangeAdvanceTool_SetBridge(t *testing.T) {
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// Should not panic with nil.
 	tool.SetBridge(nil)
@@ -412,7 +830,7 @@ func TestChangeAdvanceTool_Handle_BridgeNotification(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	tool := NewChangeAdvanceTool(store)
+	tool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	var notified bool
 	var notifiedStage changes.ChangeStage
@@ -23,7 +23,7 @@ func TestIntegration_FullFixSmallFlow(t *testing.T) {
 
 	store := changes.NewFileStore()
 	changeTool := NewChangeTool(store)
-	advanceTool := NewChangeAdvanceTool(store)
+	advanceTool := NewChangeAdvanceTool(store, mustRenderer(t))
 	statusTool := NewChangeStatusTool(store)
 
 	// Step 1: Create a fix/small change.
@@ -73,15 +73,15 @@ func TestIntegration_FullFixSmallFlow(t *testing.T) {
 			expectNext: "context-check",
 		},
 		{
-			content:    "# Context Check\n\nNo conflicting specs or business rules found. Safe to proceed.",
+			content:    "# Context Check\n\nChecked requirements.md and business-rules.md: no conflicts. Safe to proceed.",
 			expectNext: "tasks",
 		},
 		{
-			content:    "# Implementation Tasks\n\n- [ ] Add nil check before accessing results.Rows\n- [ ] Add test for empty query case\n- [ ] Update error handling in search handler",
+			content:    "# Implementation Tasks\n\n- TASK-001: Add nil check before accessing results.Rows\n  Acceptance criteria: empty query returns no rows\n- TASK-002: Add test for empty query case\n  Acceptance criteria: test fails without the fix",
 			expectNext: "verify",
 		},
 		{
-			content:      "# Verification\n\n## Tests Added\n- TestSearchHandler_EmptyQuery\n- TestSearchHandler_NilResults\n\n## Manual Testing\n- Verified empty query no longer crashes\n- Existing search functionality unaffected",
+			content:      "# Verification\n\n## Tests Added\n- TASK-001, TASK-002: TestSearchHandler_EmptyQuery\n- TestSearchHandler_NilResults\n\n## Manual Testing\n- Verified empty query no longer crashes\n- Existing search functionality unaffected",
 			isCompletion: true,
 		},
 	}
@@ -147,7 +147,7 @@ func TestIntegration_FeatureLargeWithADRs(t *testing.T) {
 
 	store := changes.NewFileStore()
 	changeTool := NewChangeTool(store)
-	advanceTool := NewChangeAdvanceTool(store)
+	advanceTool := NewChangeAdvanceTool(store, mustRenderer(t))
 	adrTool := NewADRTool(store)
 	statusTool := NewChangeStatusTool(store)
 
@@ -166,12 +166,12 @@ func TestIntegration_FeatureLargeWithADRs(t *testing.T) {
 	// feature/large: charter → context-check → spec → clarify → design → tasks → verify
 	stageContents := []string{
 		"# Charter\n\nAdd JWT-based user authentication with email/password login.",
-		"# Context Check\n\nNo conflicting specs found. No prior auth changes detected.",
+		"# Context Check\n\nChecked requirements.md: no conflicting specs found. No prior auth changes detected.",
 		"# Specification\n\n## FR-001: User Registration\nUsers can register with email and password.",
 		"# Clarifications\n\nQ: OAuth support?\nA: Not in v1, only email/password.",
 		"# Design\n\n## Architecture\nJWT with refresh tokens, bcrypt hashing.\n\n## Components\n- AuthModule\n- UserModule",
-		"# Tasks\n\n### TASK-001: Create user model\n### TASK-002: Implement JWT middleware",
-		"# Verification\n\nAll requirements covered. Tests passing.",
+		"# Tasks\n\n### TASK-001: Create user model\n**Acceptance Criteria**:\n- [ ] Model persisted\n\n### TASK-002: Implement JWT middleware\n**Acceptance Criteria**:\n- [ ] Invalid tokens rejected",
+		"# Verification\n\nAll requirements covered. TASK-001 and TASK-002 tests passing.",
 	}
 
 	// Capture an ADR after the design stage (stage index 4).
@@ -307,7 +307,7 @@ func TestIntegration_RefactorMediumFlow(t *testing.T) {
 
 	store := changes.NewFileStore()
 	changeTool := NewChangeTool(store)
-	advanceTool := NewChangeAdvanceTool(store)
+	advanceTool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// refactor/medium: scope → context-check → design → tasks → verify
 	createReq := mcp.CallToolRequest{}
@@ -323,10 +323,10 @@ func TestIntegration_RefactorMediumFlow(t *testing.T) {
 
 	stageContents := []string{
 		"# Scope\n\n## What Changes\n- Extract auth logic from handlers into AuthModule\n\n## What Doesn't Change\n- API contract remains the same\n- Database schema unchanged",
-		"# Context Check\n\nNo conflicting specs found. Safe to proceed with refactor.",
+		"# Context Check\n\nChecked design.md: no conflicting specs found. Safe to proceed with refactor.",
 		"# Design\n\n## AuthModule\n- Handles JWT creation and validation\n- Encapsulates bcrypt hashing\n- Exposes clean interface for handlers",
-		"# Tasks\n\n### TASK-001: Create AuthModule interface\n### TASK-002: Move JWT logic\n### TASK-003: Update handlers to use AuthModule",
-		"# Verification\n\n- All existing tests pass\n- No API changes\n- AuthModule has 95% coverage",
+		"# Tasks\n\n### TASK-001: Create AuthModule interface\nAcceptance criteria: interface compiles\n### TASK-002: Move JWT logic\nAcceptance criteria: handlers no longer sign tokens\n### TASK-003: Update handlers to use AuthModule\nAcceptance criteria: existing tests pass",
+		"# Verification\n\n- TASK-001, TASK-002, TASK-003 done\n- All existing tests pass\n- No API changes\n- AuthModule has 95% coverage",
 	}
 
 	for i, c := range stageContents {
@@ -457,14 +457,14 @@ func TestIntegration_AdvanceAfterCompletion(t *testing.T) {
 	defer cleanup()
 
 	store := changes.NewFileStore()
-	advanceTool := NewChangeAdvanceTool(store)
+	advanceTool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	// Complete all stages (fix/small: describe → context-check → tasks → verify).
 	stages := []string{
 		"# Describe\n\nContent.",
-		"# Context Check\n\nContent.",
-		"# Tasks\n\nContent.",
-		"# Verify\n\nContent.",
+		"# Context Check\n\nNo artifacts found.",
+		"# Tasks\n\n### TASK-001: Task 1\n**Acceptance Criteria**:\n- [ ] Done",
+		"# Verify\n\nTASK-001 verified.",
 	}
 	for _, c := range stages {
 		req := mcp.CallToolRequest{}
@@ -492,7 +492,7 @@ func TestIntegration_BridgeAcrossTools(t *testing.T) {
 
 	store := changes.NewFileStore()
 	changeTool := NewChangeTool(store)
-	advanceTool := NewChangeAdvanceTool(store)
+	advanceTool := NewChangeAdvanceTool(store, mustRenderer(t))
 	adrTool := NewADRTool(store)
 
 	// Track all bridge notifications.
@@ -561,7 +561,7 @@ func TestIntegration_ContextCheckToolInFlow(t *testing.T) {
 
 	changeStore := changes.NewFileStore()
 	changeTool := NewChangeTool(changeStore)
-	advanceTool := NewChangeAdvanceTool(changeStore)
+	advanceTool := NewChangeAdvanceTool(changeStore, mustRenderer(t))
 	contextCheckTool := NewContextCheckTool(changeStore, nil) // nil memory — degrades gracefully
 
 	// Create SDD artifacts that context-check will scan.
@@ -639,7 +639,7 @@ func TestIntegration_ContextCheckToolInFlow(t *testing.T) {
 
 	// Stage 3: Advance tasks.
 	advanceReq.Params.Arguments = map[string]interface{}{
-		"content": "# Tasks\n\n### TASK-001: Create password reset token model\n### TASK-002: Add POST /auth/reset-password endpoint\n### TASK-003: Send reset email with token link",
+		"content": "# Tasks\n\n### TASK-001: Create password reset token model\n**Acceptance Criteria**: tokens expire after 1h\n### TASK-002: Add POST /auth/reset-password endpoint\n**Acceptance Criteria**: returns 204\n### TASK-003: Send reset email with token link\n**Acceptance Criteria**: email contains link",
 	}
 	if _, err = advanceTool.Handle(context.Background(), advanceReq); err != nil {
 		t.Fatalf("advance tasks: %v", err)
@@ -647,7 +647,7 @@ func TestIntegration_ContextCheckToolInFlow(t *testing.T) {
 
 	// Stage 4: Advance verify — completes the change.
 	advanceReq.Params.Arguments = map[string]interface{}{
-		"content": "# Verification\n\nAll tasks trace to password reset functionality.\nNo conflicts with existing auth requirements (FR-001, FR-002).\nTest coverage planned for all 3 tasks.",
+		"content": "# Verification\n\nAll tasks trace to password reset functionality.\nNo conflicts with existing auth requirements (FR-001, FR-002).\nTest coverage planned for TASK-001, TASK-002 and TASK-003.",
 	}
 	result, err = advanceTool.Handle(context.Background(), advanceReq)
 	if err != nil {
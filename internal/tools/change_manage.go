@@ -0,0 +1,537 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ChangeManageTool handles the sdd_change_manage MCP tool.
+// It moves changes through their lifecycle outside the stage pipeline:
+// archive, abandon, reopen, pause, switch, rewind, resize, link, unlink,
+// and list.
+type ChangeManageTool struct {
+	store  changes.Store
+	bridge ChangeLifecycleObserver
+}
+
+// NewChangeManageTool creates a ChangeManageTool with the given change store.
+func NewChangeManageTool(store changes.Store) *ChangeManageTool {
+	return &ChangeManageTool{store: store}
+}
+
+// SetBridge injects an optional ChangeLifecycleObserver for memory persistence.
+func (t *ChangeManageTool) SetBridge(obs ChangeLifecycleObserver) { t.bridge = obs }
+
+// Definition returns the MCP tool definition for registration.
+func (t *ChangeManageTool) Definition() mcp.Tool {
+	return mcp.NewTool("sdd_change_manage",
+		mcp.WithDescription(
+			"Manage the lifecycle of changes. Actions: "+
+				"archive (move a completed or paused change to docs/history/), "+
+				"abandon (drop an unfinished change with a reason — frees the active slot), "+
+				"reopen (move a change from docs/history/ back to docs/changes/), "+
+				"pause (set the active change aside so a new one can start), "+
+				"switch (pause this branch's active change and resume a paused one here, "+
+				"rebinding it to the checked-out branch), "+
+				"rewind (reopen an earlier stage of the active change: later stages become stale "+
+				"and must be completed again before verify; previous artifacts are kept as "+
+				"numbered revisions in revisions/), "+
+				"resize (switch the active change to the flow of another size: stages the new size adds "+
+				"are inserted after the current stage, completed stages and their files are kept, and "+
+				"shrinking past a completed stage is refused; recorded in change.json), "+
+				"link / unlink (add or remove depends_on / blocks links of an existing change; "+
+				"unknown IDs and cycles are refused), "+
+				"list (all changes, filterable by status and type).",
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Description("Lifecycle action to perform"),
+			mcp.Enum("archive", "abandon", "reopen", "pause", "switch", "rewind", "resize", "link", "unlink", "list"),
+		),
+		mcp.WithString("change_id",
+			mcp.Description("Target change ID. Required for archive, reopen and switch. "+
+				"abandon, pause, rewind, resize, link and unlink default to the active change."),
+		),
+		mcp.WithString("stage",
+			mcp.Description("rewind only: the earlier stage to reopen (e.g. spec)"),
+		),
+		mcp.WithString("size",
+			mcp.Description("resize only: the new size"),
+			mcp.Enum("small", "medium", "large"),
+		),
+		mcp.WithString("depends_on",
+			mcp.Description("link / unlink only: comma-separated IDs of changes that must land before this one."),
+		),
+		mcp.WithString("blocks",
+			mcp.Description("link / unlink only: comma-separated IDs of changes that must wait for this one."),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Why the change is abandoned (required for abandon) or resized (optional). Recorded in change.json."),
+		),
+		mcp.WithString("status",
+			mcp.Description("list only: filter by status"),
+			mcp.Enum("active", "paused", "completed", "archived", "abandoned"),
+		),
+		mcp.WithString("type",
+			mcp.Description("list only: filter by change type"),
+			mcp.Enum(changeTypeNames()...),
+		),
+	)
+}
+
+// Handle processes the sdd_change_manage tool call.
+func (t *ChangeManageTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	action := req.GetString("action", "")
+	changeID := strings.TrimSpace(req.GetString("change_id", ""))
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("finding project root: %w", err)
+	}
+	if err := useProjectFlows(projectRoot); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	switch action {
+	case "archive":
+		return t.handleArchive(projectRoot, changeID)
+	case "abandon":
+		return t.handleAbandon(projectRoot, changeID, req.GetString("reason", ""))
+	case "reopen":
+		return t.handleReopen(projectRoot, changeID)
+	case "pause":
+		return t.handlePause(projectRoot, changeID)
+	case "switch":
+		return t.handleSwitch(projectRoot, changeID)
+	case "rewind":
+		return t.handleRewind(projectRoot, changeID, req.GetString("stage", ""))
+	case "resize":
+		return t.handleResize(projectRoot, changeID, req.GetString("size", ""), req.GetString("reason", ""))
+	case "link", "unlink":
+		return t.handleLink(projectRoot, changeID, action,
+			changes.ParseLinks(req.GetString("depends_on", "")), changes.ParseLinks(req.GetString("blocks", "")))
+	case "list":
+		return t.handleList(projectRoot, req.GetString("status", ""), req.GetString("type", ""))
+	case "":
+		return mcp.NewToolResultError("'action' is required — one of: archive, abandon, reopen, pause, switch, rewind, resize, link, unlink, list"), nil
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"invalid action %q: must be one of: archive, abandon, reopen, pause, switch, rewind, resize, link, unlink, list", action,
+		)), nil
+	}
+}
+
+func (t *ChangeManageTool) handleArchive(projectRoot, changeID string) (*mcp.CallToolResult, error) {
+	if changeID == "" {
+		return mcp.NewToolResultError("'change_id' is required for archive"), nil
+	}
+	change, err := t.store.Load(projectRoot, changeID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := t.store.Archive(projectRoot, changeID); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	notifyLifecycleObserver(t.bridge, changeID, change.Status, changes.StatusArchived, "")
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"# Change Archived\n\n"+
+			"**ID:** `%s`\n"+
+			"**Status:** %s → archived\n\n"+
+			"Artifacts moved to `docs/%s/%s/`. Use `sdd_change_manage` with "+
+			"action `reopen` to bring it back.",
+		changeID, change.Status, changes.HistoryDir, changeID,
+	)), nil
+}
+
+func (t *ChangeManageTool) handleAbandon(projectRoot, changeID, reason string) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(reason) == "" {
+		return mcp.NewToolResultError("'reason' is required for abandon — record why the change is dropped"), nil
+	}
+	change, errResult, err := t.loadOrActive(projectRoot, changeID)
+	if errResult != nil || err != nil {
+		return errResult, err
+	}
+	if err := t.store.Abandon(projectRoot, change.ID, reason); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	notifyLifecycleObserver(t.bridge, change.ID, change.Status, changes.StatusAbandoned,
+		"**Reason**: "+strings.TrimSpace(reason))
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"# Change Abandoned\n\n"+
+			"**ID:** `%s`\n"+
+			"**Status:** %s → abandoned\n"+
+			"**Stopped at:** %s\n"+
+			"**Reason:** %s\n\n"+
+			"Artifacts moved to `docs/%s/%s/`. You can start a new change with `sdd_change`.",
+		change.ID, change.Status, change.CurrentStage, strings.TrimSpace(reason),
+		changes.HistoryDir, change.ID,
+	)), nil
+}
+
+func (t *ChangeManageTool) handleReopen(projectRoot, changeID string) (*mcp.CallToolResult, error) {
+	if changeID == "" {
+		return mcp.NewToolResultError("'change_id' is required for reopen"), nil
+	}
+	from := changes.StatusArchived
+	if prev := t.findChange(projectRoot, changeID); prev != nil {
+		from = prev.Status
+	}
+	change, err := t.store.Reopen(projectRoot, changeID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	notifyLifecycleObserver(t.bridge, change.ID, from, change.Status, "")
+
+	next := fmt.Sprintf("Current stage: **%s**. Continue with `sdd_change_advance`.", change.CurrentStage)
+	if change.Status == changes.StatusPaused {
+		next = "Another change is active, so this one was reopened as **paused**. " +
+			"Use `sdd_change_manage` with action `switch` to resume it."
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"# Change Reopened\n\n"+
+			"**ID:** `%s`\n"+
+			"**Status:** %s → %s\n\n"+
+			"%s",
+		change.ID, from, change.Status, next,
+	)), nil
+}
+
+func (t *ChangeManageTool) handlePause(projectRoot, changeID string) (*mcp.CallToolResult, error) {
+	change, errResult, err := t.loadOrActive(projectRoot, changeID)
+	if errResult != nil || err != nil {
+		return errResult, err
+	}
+	if change.Status != changes.StatusActive {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"change %q is not active (status: %s)", change.ID, change.Status,
+		)), nil
+	}
+
+	change.Status = changes.StatusPaused
+	if err := t.store.Save(projectRoot, change); err != nil {
+		return nil, fmt.Errorf("saving change: %w", err)
+	}
+	notifyLifecycleObserver(t.bridge, change.ID, changes.StatusActive, changes.StatusPaused, "")
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"# Change Paused\n\n"+
+			"**ID:** `%s`\n"+
+			"**Stage:** %s\n\n"+
+			"You can start a new change with `sdd_change`, or resume this one with "+
+			"`sdd_change_manage` action `switch`.",
+		change.ID, change.CurrentStage,
+	)), nil
+}
+
+func (t *ChangeManageTool) handleSwitch(projectRoot, changeID string) (*mcp.CallToolResult, error) {
+	if changeID == "" {
+		return mcp.NewToolResultError("'change_id' is required for switch"), nil
+	}
+	target, err := t.store.Load(projectRoot, changeID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"%v — archived or abandoned changes must be reopened first", err,
+		)), nil
+	}
+	if target.Status == changes.StatusActive {
+		return mcp.NewToolResultError(fmt.Sprintf("change %q is already active", changeID)), nil
+	}
+	if target.Status != changes.StatusPaused {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"only paused changes can be resumed — %q is %s", changeID, target.Status,
+		)), nil
+	}
+
+	active, err := t.store.LoadActive(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("loading active change: %w", err)
+	}
+
+	paused := "none"
+	if active != nil {
+		active.Status = changes.StatusPaused
+		if err := t.store.Save(projectRoot, active); err != nil {
+			return nil, fmt.Errorf("pausing change %q: %w", active.ID, err)
+		}
+		notifyLifecycleObserver(t.bridge, active.ID, changes.StatusActive, changes.StatusPaused,
+			fmt.Sprintf("Switched to `%s`.", target.ID))
+		paused = fmt.Sprintf("`%s`", active.ID)
+	}
+
+	target.Status = changes.StatusActive
+	target.BindToHead(projectRoot)
+	if err := t.store.Save(projectRoot, target); err != nil {
+		return nil, fmt.Errorf("resuming change %q: %w", target.ID, err)
+	}
+	notifyLifecycleObserver(t.bridge, target.ID, changes.StatusPaused, changes.StatusActive, "")
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"# Switched Change\n\n"+
+			"**Active:** `%s` (stage: %s, branch: %s)\n"+
+			"**Paused:** %s\n\n"+
+			"Continue with `sdd_change_advance`.",
+		target.ID, target.CurrentStage, branchLabel(target.Branch), paused,
+	)), nil
+}
+
+func (t *ChangeManageTool) handleRewind(projectRoot, changeID, stage string) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(stage) == "" {
+		return mcp.NewToolResultError("'stage' is required for rewind — name the earlier stage to reopen"), nil
+	}
+	change, errResult, err := t.loadOrActive(projectRoot, changeID)
+	if errResult != nil || err != nil {
+		return errResult, err
+	}
+
+	target := changes.ChangeStage(strings.TrimSpace(stage))
+	if err := changes.Rewind(change, target); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Keep the artifacts being superseded: the reopened stage and every
+	// stage that just went stale.
+	changeDir := changes.ChangePath(projectRoot, change.ID)
+	var kept []string
+	for _, s := range change.Stages {
+		if s.Name != target && s.Status != "stale" {
+			continue
+		}
+		path, err := changes.SnapshotStage(changeDir, s.Name)
+		if err != nil {
+			return nil, fmt.Errorf("saving revision of %s: %w", s.Name, err)
+		}
+		if path != "" {
+			kept = append(kept, fmt.Sprintf("- `%s` → `%s/%s`", changes.StageFilename(s.Name),
+				changes.RevisionsDir, filepath.Base(path)))
+		}
+	}
+
+	if err := t.store.Save(projectRoot, change); err != nil {
+		return nil, fmt.Errorf("saving change: %w", err)
+	}
+
+	var progress strings.Builder
+	for _, s := range change.Stages {
+		fmt.Fprintf(&progress, "  %s %s\n", stageMarker(s.Status), s.Name)
+	}
+
+	revisions := "No earlier artifacts to keep.\n"
+	if len(kept) > 0 {
+		revisions = strings.Join(kept, "\n") + "\n"
+	}
+
+	stale := "none"
+	if names := changes.StaleStages(change); len(names) > 0 {
+		parts := make([]string, len(names))
+		for i, n := range names {
+			parts[i] = string(n)
+		}
+		stale = strings.Join(parts, ", ")
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"# Change Rewound\n\n"+
+			"**ID:** `%s`\n"+
+			"**Reopened stage:** %s (revision %d)\n"+
+			"**Stale stages:** %s\n\n"+
+			"## Progress\n\n"+
+			"%s\n"+
+			"## Revisions Kept\n\n"+
+			"%s\n"+
+			"## Next Step\n\n"+
+			"Revise the `%s` stage and call `sdd_change_advance` with the new content. "+
+			"Each stale stage must be completed again before `verify`.",
+		change.ID, target, change.Stages[changes.CurrentStageIndex(change)].Revision, stale,
+		progress.String(), revisions, target,
+	)), nil
+}
+
+func (t *ChangeManageTool) handleResize(projectRoot, changeID, size, reason string) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(size) == "" {
+		return mcp.NewToolResultError("'size' is required for resize — one of: small, medium, large"), nil
+	}
+	change, errResult, err := t.loadOrActive(projectRoot, changeID)
+	if errResult != nil || err != nil {
+		return errResult, err
+	}
+
+	rec, err := changes.Resize(change, changes.ChangeSize(strings.TrimSpace(size)), reason)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := t.store.Save(projectRoot, change); err != nil {
+		return nil, fmt.Errorf("saving change: %w", err)
+	}
+
+	added, removed := joinStages(rec.Added), joinStages(rec.Removed)
+	note := fmt.Sprintf("**At stage**: %s\n**Stages added**: %s\n**Stages removed**: %s", rec.AtStage, added, removed)
+	if rec.Reason != "" {
+		note += "\n**Reason**: " + rec.Reason
+	}
+	notifyResizeObserver(t.bridge, change.ID, rec.From, rec.To, note)
+
+	var progress strings.Builder
+	for _, s := range change.Stages {
+		fmt.Fprintf(&progress, "  %s %s\n", stageMarker(s.Status), s.Name)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"# Change Resized\n\n"+
+			"**ID:** `%s`\n"+
+			"**Size:** %s → %s\n"+
+			"**Stages added:** %s\n"+
+			"**Stages removed:** %s\n\n"+
+			"## Progress\n\n"+
+			"%s\n"+
+			"## Next Step\n\n"+
+			"Current stage: **%s**. Continue with `sdd_change_advance`; "+
+			"the added stages come before `verify`.",
+		change.ID, rec.From, rec.To, added, removed, progress.String(), change.CurrentStage,
+	)), nil
+}
+
+// handleLink adds (link) or removes (unlink) depends_on / blocks links
+// of an existing change.
+func (t *ChangeManageTool) handleLink(projectRoot, changeID, action string, dependsOn, blocks []string) (*mcp.CallToolResult, error) {
+	if len(dependsOn)+len(blocks) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("'depends_on' or 'blocks' is required for %s — comma-separated change IDs", action)), nil
+	}
+	change, errResult, err := t.loadOrActive(projectRoot, changeID)
+	if errResult != nil || err != nil {
+		return errResult, err
+	}
+	all, err := t.store.List(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("listing changes: %w", err)
+	}
+
+	heading := "Change Linked"
+	if action == "link" {
+		err = changes.Link(change, all, dependsOn, blocks)
+	} else {
+		heading = "Change Unlinked"
+		err = changes.Unlink(change, dependsOn, blocks)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := t.store.Save(projectRoot, change); err != nil {
+		return nil, fmt.Errorf("saving change: %w", err)
+	}
+
+	links := linkLines(change)
+	if links == "" {
+		links = "**Links:** none\n"
+	}
+	response := fmt.Sprintf("# %s\n\n**ID:** `%s`\n%s\nThe change queue in `sdd_change_status` reflects the new links.",
+		heading, change.ID, links)
+	if pending := changes.PendingPrerequisites(change, all); len(pending) > 0 && !change.Landed() {
+		response += "\n\n---\n\n" + formatPendingPrerequisites(pending)
+	}
+	return mcp.NewToolResultText(response), nil
+}
+
+// joinStages renders stage names as a comma-separated list, or "none".
+func joinStages(stages []changes.ChangeStage) string {
+	if len(stages) == 0 {
+		return "none"
+	}
+	names := make([]string, len(stages))
+	for i, s := range stages {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (t *ChangeManageTool) handleList(projectRoot, status, changeType string) (*mcp.CallToolResult, error) {
+	if status != "" {
+		if err := changes.ValidateStatus(changes.ChangeStatus(status)); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	if changeType != "" {
+		if err := changes.ValidateType(changes.ChangeType(changeType)); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	all, err := t.store.List(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("listing changes: %w", err)
+	}
+
+	var matched []changes.ChangeRecord
+	for _, c := range all {
+		if status != "" && string(c.Status) != status {
+			continue
+		}
+		if changeType != "" && string(c.Type) != changeType {
+			continue
+		}
+		matched = append(matched, c)
+	}
+
+	// Most recently touched first; RFC3339 UTC strings sort chronologically.
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].UpdatedAt > matched[j].UpdatedAt
+	})
+
+	if len(matched) == 0 {
+		return mcp.NewToolResultText("No changes found matching the filters."), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Changes (%d)\n\n", len(matched))
+	b.WriteString("| ID | Type | Size | Status | Stage | Branch | Updated |\n")
+	b.WriteString("|----|------|------|--------|-------|--------|---------|\n")
+	for _, c := range matched {
+		fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s | %s | %s |\n",
+			c.ID, c.Type, c.Size, c.Status, c.CurrentStage, branchLabel(c.Branch), c.UpdatedAt)
+	}
+
+	return mcp.NewToolResultText(b.String()), nil
+}
+
+// loadOrActive loads changeID, or the active change when changeID is
+// empty. A non-nil result means the lookup failed with a tool error.
+func (t *ChangeManageTool) loadOrActive(projectRoot, changeID string) (*changes.ChangeRecord, *mcp.CallToolResult, error) {
+	if changeID != "" {
+		change, err := t.store.Load(projectRoot, changeID)
+		if err != nil {
+			return nil, mcp.NewToolResultError(err.Error()), nil
+		}
+		return change, nil, nil
+	}
+
+	change, err := t.store.LoadActive(projectRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading active change: %w", err)
+	}
+	if change == nil {
+		return nil, mcp.NewToolResultError("No active change found — pass 'change_id' explicitly."), nil
+	}
+	return change, nil, nil
+}
+
+// findChange returns the listed change with the given ID, or nil.
+func (t *ChangeManageTool) findChange(projectRoot, changeID string) *changes.ChangeRecord {
+	all, err := t.store.List(projectRoot)
+	if err != nil {
+		return nil
+	}
+	for i := range all {
+		if all[i].ID == changeID {
+			return &all[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,433 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// --- ChangeManageTool tests ---
+
+// callManage runs sdd_change_manage with the given arguments.
+func callManage(t *testing.T, tool *ChangeManageTool, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	result, err := tool.Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	return result
+}
+
+// transition is one lifecycle notification captured by mockLifecycleObserver.
+type transition struct {
+	changeID string
+	from, to changes.ChangeStatus
+	note     string
+}
+
+// mockLifecycleObserver implements ChangeLifecycleObserver for testing.
+type mockLifecycleObserver struct {
+	got     []transition
+	resizes []string
+}
+
+func (m *mockLifecycleObserver) OnChangeTransition(changeID string, from, to changes.ChangeStatus, note string) {
+	m.got = append(m.got, transition{changeID, from, to, note})
+}
+
+func (m *mockLifecycleObserver) OnChangeResized(changeID string, from, to changes.ChangeSize, note string) {
+	m.resizes = append(m.resizes, fmt.Sprintf("%s %s→%s %s", changeID, from, to, note))
+}
+
+func TestChangeManageTool_Definition(t *testing.T) {
+	tool := NewChangeManageTool(changes.NewFileStore())
+	def := tool.Definition()
+
+	if def.Name != "sdd_change_manage" {
+		t.Errorf("name = %q, want sdd_change_manage", def.Name)
+	}
+	if _, ok := def.InputSchema.Properties["action"]; !ok {
+		t.Error("definition should have 'action' parameter")
+	}
+}
+
+func TestChangeManageTool_AbandonActiveFreesSlot(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFeature, changes.SizeSmall, "unwanted feature")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	tool := NewChangeManageTool(store)
+	obs := &mockLifecycleObserver{}
+	tool.SetBridge(obs)
+
+	result := callManage(t, tool, map[string]interface{}{"action": "abandon", "reason": "scope moved to v2"})
+	if isErrorResult(result) {
+		t.Fatalf("expected success, got error: %s", getResultText(result))
+	}
+	if !strings.Contains(getResultText(result), "scope moved to v2") {
+		t.Error("response should echo the reason")
+	}
+
+	data, err := os.ReadFile(filepath.Join(changes.HistoryPath(tmpDir), change.ID, changes.ChangeConfigFile))
+	if err != nil {
+		t.Fatalf("abandoned change.json should be in history: %v", err)
+	}
+	if !strings.Contains(string(data), `"abandon_reason": "scope moved to v2"`) {
+		t.Errorf("change.json should record the reason:\n%s", data)
+	}
+
+	// A new change can now be started.
+	createResult, err := NewChangeTool(store).Handle(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+			"type": "fix", "size": "small", "description": "next thing",
+		}},
+	})
+	if err != nil || isErrorResult(createResult) {
+		t.Fatalf("sdd_change after abandon failed: %v %s", err, getResultText(createResult))
+	}
+
+	if len(obs.got) != 1 || obs.got[0] != (transition{change.ID, changes.StatusActive, changes.StatusAbandoned, "**Reason**: scope moved to v2"}) {
+		t.Errorf("transitions = %+v", obs.got)
+	}
+}
+
+func TestChangeManageTool_AbandonRequiresReason(t *testing.T) {
+	_, cleanup, _ := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "keep me")
+	defer cleanup()
+
+	result := callManage(t, NewChangeManageTool(changes.NewFileStore()), map[string]interface{}{"action": "abandon"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "'reason' is required") {
+		t.Errorf("expected reason error, got: %s", getResultText(result))
+	}
+}
+
+func TestChangeManageTool_ArchiveAndReopen(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "finished fix")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	change.Status = changes.StatusCompleted
+	if err := store.Save(tmpDir, change); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	tool := NewChangeManageTool(store)
+	obs := &mockLifecycleObserver{}
+	tool.SetBridge(obs)
+
+	result := callManage(t, tool, map[string]interface{}{"action": "archive", "change_id": change.ID})
+	if isErrorResult(result) {
+		t.Fatalf("archive failed: %s", getResultText(result))
+	}
+	if _, err := os.Stat(changes.ChangePath(tmpDir, change.ID)); !os.IsNotExist(err) {
+		t.Error("archived change should leave docs/changes/")
+	}
+
+	result = callManage(t, tool, map[string]interface{}{"action": "reopen", "change_id": change.ID})
+	if isErrorResult(result) {
+		t.Fatalf("reopen failed: %s", getResultText(result))
+	}
+	reopened, err := store.Load(tmpDir, change.ID)
+	if err != nil {
+		t.Fatalf("reopened change should be back in docs/changes/: %v", err)
+	}
+	if reopened.Status != changes.StatusActive {
+		t.Errorf("status = %s, want active", reopened.Status)
+	}
+
+	want := []transition{
+		{change.ID, changes.StatusCompleted, changes.StatusArchived, ""},
+		{change.ID, changes.StatusArchived, changes.StatusActive, ""},
+	}
+	if len(obs.got) != len(want) || obs.got[0] != want[0] || obs.got[1] != want[1] {
+		t.Errorf("transitions = %+v, want %+v", obs.got, want)
+	}
+}
+
+func TestChangeManageTool_ArchiveActiveRefused(t *testing.T) {
+	_, cleanup, change := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "still going")
+	defer cleanup()
+
+	result := callManage(t, NewChangeManageTool(changes.NewFileStore()),
+		map[string]interface{}{"action": "archive", "change_id": change.ID})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "cannot archive active change") {
+		t.Errorf("expected refusal, got: %s", getResultText(result))
+	}
+}
+
+func TestChangeManageTool_PauseAndSwitch(t *testing.T) {
+	tmpDir, cleanup, first := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "first change")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	tool := NewChangeManageTool(store)
+
+	if result := callManage(t, tool, map[string]interface{}{"action": "pause"}); isErrorResult(result) {
+		t.Fatalf("pause failed: %s", getResultText(result))
+	}
+	if active, _ := store.LoadActive(tmpDir); active != nil {
+		t.Fatalf("no change should be active after pause, got %q", active.ID)
+	}
+
+	second := &changes.ChangeRecord{
+		ID: "second-change", Type: changes.TypeFix, Size: changes.SizeSmall,
+		Stages: first.Stages, CurrentStage: first.CurrentStage, Status: changes.StatusActive,
+	}
+	if err := store.Create(tmpDir, second); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	result := callManage(t, tool, map[string]interface{}{"action": "switch", "change_id": first.ID})
+	if isErrorResult(result) {
+		t.Fatalf("switch failed: %s", getResultText(result))
+	}
+	active, _ := store.LoadActive(tmpDir)
+	if active == nil || active.ID != first.ID {
+		t.Fatalf("active change = %v, want %q", active, first.ID)
+	}
+	if paused, _ := store.Load(tmpDir, second.ID); paused.Status != changes.StatusPaused {
+		t.Errorf("previously active change status = %s, want paused", paused.Status)
+	}
+
+	result = callManage(t, tool, map[string]interface{}{"action": "switch", "change_id": first.ID})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "already active") {
+		t.Errorf("switching to the active change should fail, got: %s", getResultText(result))
+	}
+}
+
+func TestChangeManageTool_ListFilters(t *testing.T) {
+	tmpDir, cleanup, _ := createActiveChange(t, changes.TypeFeature, changes.SizeSmall, "new login")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	done := &changes.ChangeRecord{
+		ID: "old-fix", Type: changes.TypeFix, Size: changes.SizeSmall,
+		CurrentStage: changes.StageVerify, Status: changes.StatusCompleted,
+	}
+	if err := store.Create(tmpDir, done); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := store.Archive(tmpDir, done.ID); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+
+	tool := NewChangeManageTool(store)
+
+	text := getResultText(callManage(t, tool, map[string]interface{}{"action": "list"}))
+	if !strings.Contains(text, "# Changes (2)") || !strings.Contains(text, "`new-login`") || !strings.Contains(text, "`old-fix`") {
+		t.Errorf("unfiltered list:\n%s", text)
+	}
+
+	text = getResultText(callManage(t, tool, map[string]interface{}{"action": "list", "status": "archived"}))
+	if !strings.Contains(text, "`old-fix`") || strings.Contains(text, "`new-login`") {
+		t.Errorf("status filter:\n%s", text)
+	}
+
+	text = getResultText(callManage(t, tool, map[string]interface{}{"action": "list", "type": "feature"}))
+	if !strings.Contains(text, "`new-login`") || strings.Contains(text, "`old-fix`") {
+		t.Errorf("type filter:\n%s", text)
+	}
+
+	result := callManage(t, tool, map[string]interface{}{"action": "list", "status": "done"})
+	if !isErrorResult(result) {
+		t.Error("invalid status filter should be a tool error")
+	}
+}
+
+func TestChangeManageTool_InvalidAction(t *testing.T) {
+	_, cleanup := setupChangeProject(t)
+	defer cleanup()
+
+	result := callManage(t, NewChangeManageTool(changes.NewFileStore()), map[string]interface{}{"action": "delete"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "invalid action") {
+		t.Errorf("expected invalid action error, got: %s", getResultText(result))
+	}
+}
+
+func TestChangeManageTool_RewindKeepsRevisions(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFix, changes.SizeLarge, "rewind me")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	changeDir := changes.ChangePath(tmpDir, change.ID)
+	for change.CurrentStage != changes.StageTasks {
+		if err := os.WriteFile(filepath.Join(changeDir, changes.StageFilename(change.CurrentStage)),
+			[]byte("# "+string(change.CurrentStage)+" v1\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := changes.Advance(change); err != nil {
+			t.Fatalf("Advance failed: %v", err)
+		}
+	}
+	if err := store.Save(tmpDir, change); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	tool := NewChangeManageTool(store)
+	result := callManage(t, tool, map[string]interface{}{"action": "rewind", "stage": "spec"})
+	if isErrorResult(result) {
+		t.Fatalf("rewind failed: %s", getResultText(result))
+	}
+	text := getResultText(result)
+	if !strings.Contains(text, "**Stale stages:** design") {
+		t.Errorf("response should list stale stages:\n%s", text)
+	}
+
+	for _, name := range []string{"spec.1.md", "design.1.md"} {
+		if _, err := os.Stat(filepath.Join(changeDir, changes.RevisionsDir, name)); err != nil {
+			t.Errorf("revision %s should exist: %v", name, err)
+		}
+	}
+
+	reloaded, err := store.Load(tmpDir, change.ID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if reloaded.CurrentStage != changes.StageSpec {
+		t.Errorf("CurrentStage = %s, want spec", reloaded.CurrentStage)
+	}
+
+	result = callManage(t, tool, map[string]interface{}{"action": "rewind", "stage": "verify"})
+	if !isErrorResult(result) {
+		t.Error("rewinding forward should be a tool error")
+	}
+	result = callManage(t, tool, map[string]interface{}{"action": "rewind"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "'stage' is required") {
+		t.Errorf("expected missing stage error, got: %s", getResultText(result))
+	}
+}
+
+func TestChangeManageTool_LinkUnlink(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFeature, changes.SizeSmall, "add api")
+	defer cleanup()
+
+	// Created after the active change.
+	store := changes.NewFileStore()
+	schema := &changes.ChangeRecord{ID: "add-schema", Type: changes.TypeFeature, Size: changes.SizeSmall,
+		Status: changes.StatusPaused, CurrentStage: changes.StageDescribe}
+	if err := store.Create(tmpDir, schema); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	tool := NewChangeManageTool(store)
+
+	result := callManage(t, tool, map[string]interface{}{"action": "link"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "'depends_on' or 'blocks' is required") {
+		t.Errorf("expected missing links error, got: %s", getResultText(result))
+	}
+	result = callManage(t, tool, map[string]interface{}{"action": "link", "depends_on": "add-ghost"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "add-ghost, which does not exist") {
+		t.Errorf("expected unknown ID error, got: %s", getResultText(result))
+	}
+
+	result = callManage(t, tool, map[string]interface{}{"action": "link", "depends_on": "add-schema"})
+	if isErrorResult(result) {
+		t.Fatalf("link failed: %s", getResultText(result))
+	}
+	text := getResultText(result)
+	for _, want := range []string{"# Change Linked", "**Depends on:** `add-schema`", "Prerequisites not landed yet"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("response missing %q:\n%s", want, text)
+		}
+	}
+	if reloaded, _ := store.Load(tmpDir, change.ID); reloaded == nil || len(reloaded.DependsOn) != 1 {
+		t.Errorf("depends_on not saved: %+v", reloaded)
+	}
+
+	// The reverse link would close a cycle.
+	result = callManage(t, tool, map[string]interface{}{"action": "link", "change_id": "add-schema", "depends_on": change.ID})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "dependency cycle") {
+		t.Errorf("expected cycle error, got: %s", getResultText(result))
+	}
+
+	result = callManage(t, tool, map[string]interface{}{"action": "unlink", "depends_on": "add-schema"})
+	if isErrorResult(result) || !strings.Contains(getResultText(result), "**Links:** none") {
+		t.Fatalf("unlink failed: %s", getResultText(result))
+	}
+	if reloaded, _ := store.Load(tmpDir, change.ID); reloaded == nil || len(reloaded.DependsOn) != 0 {
+		t.Errorf("depends_on not removed: %+v", reloaded)
+	}
+	result = callManage(t, tool, map[string]interface{}{"action": "unlink", "blocks": "add-schema"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "does not block add-schema") {
+		t.Errorf("expected missing link error, got: %s", getResultText(result))
+	}
+}
+
+func TestChangeManageTool_Resize(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "grow me")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	for change.CurrentStage != changes.StageTasks {
+		if err := changes.Advance(change); err != nil {
+			t.Fatalf("Advance failed: %v", err)
+		}
+	}
+	if err := store.Save(tmpDir, change); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	tool := NewChangeManageTool(store)
+	obs := &mockLifecycleObserver{}
+	tool.SetBridge(obs)
+
+	result := callManage(t, tool, map[string]interface{}{"action": "resize"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "'size' is required") {
+		t.Errorf("expected missing size error, got: %s", getResultText(result))
+	}
+	result = callManage(t, tool, map[string]interface{}{"action": "resize", "size": "small"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "already small") {
+		t.Errorf("expected same size error, got: %s", getResultText(result))
+	}
+
+	result = callManage(t, tool, map[string]interface{}{"action": "resize", "size": "large", "reason": "touches the schema"})
+	if isErrorResult(result) {
+		t.Fatalf("resize failed: %s", getResultText(result))
+	}
+	text := getResultText(result)
+	for _, want := range []string{"small → large", "**Stages added:** spec, design", "🔄 tasks"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("response missing %q:\n%s", want, text)
+		}
+	}
+
+	reloaded, err := store.Load(tmpDir, change.ID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if reloaded.Size != changes.SizeLarge || len(reloaded.Resizes) != 1 || reloaded.Resizes[0].Reason != "touches the schema" {
+		t.Errorf("reloaded change = %+v", reloaded)
+	}
+	if len(obs.resizes) != 1 || !strings.Contains(obs.resizes[0], "small→large") || !strings.Contains(obs.resizes[0], "touches the schema") {
+		t.Errorf("observer resizes = %v", obs.resizes)
+	}
+
+	statusReq := mcp.CallToolRequest{}
+	statusReq.Params.Arguments = map[string]interface{}{}
+	statusResult, err := NewChangeStatusTool(store).Handle(context.Background(), statusReq)
+	if err != nil {
+		t.Fatalf("change status failed: %v", err)
+	}
+	if text := getResultText(statusResult); !strings.Contains(text, "- small → large at tasks (added: spec, design; removed: none) — touches the schema") {
+		t.Errorf("change status missing resize history:\n%s", text)
+	}
+
+	// Once spec has started, shrinking back to small would drop it.
+	if err := changes.Advance(reloaded); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if err := store.Save(tmpDir, reloaded); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	result = callManage(t, tool, map[string]interface{}{"action": "resize", "size": "small"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "spec") {
+		t.Errorf("expected shrink refusal, got: %s", getResultText(result))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/HendryAvila/Hoofy/internal/changes"
@@ -27,8 +28,11 @@ func (t *ChangeStatusTool) Definition() mcp.Tool {
 	return mcp.NewTool("sdd_change_status",
 		mcp.WithDescription(
 			"Show the current state of a change. If `change_id` is provided, "+
-				"shows that specific change. Otherwise, shows the active change. "+
-				"Returns stage progress, artifact sizes, and ADRs captured.",
+				"shows that specific change. Otherwise, shows the active change for the "+
+				"checked-out git branch. Returns stage progress, artifact sizes, task progress "+
+				"(tasks done and the next wave, once tasks.md exists), ADRs captured, "+
+				"every in-flight (active or paused) change with its branch, and — when changes are "+
+				"linked with depends_on/blocks — the queue of changes still to land in dependency order.",
 		),
 		mcp.WithString("change_id",
 			mcp.Description("Specific change ID to inspect. If omitted, shows the active change."),
@@ -44,6 +48,14 @@ func (t *ChangeStatusTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return nil, fmt.Errorf("finding project root: %w", err)
 	}
+	if err := useProjectFlows(projectRoot); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	inFlight, err := t.store.ListInFlight(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("listing in-flight changes: %w", err)
+	}
 
 	var change *changes.ChangeRecord
 	if changeID != "" {
@@ -57,7 +69,13 @@ func (t *ChangeStatusTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 			return nil, fmt.Errorf("loading active change: %w", err)
 		}
 		if change == nil {
-			return mcp.NewToolResultError("No active change found. Create one with `sdd_change` first."), nil
+			msg := "No active change found. Create one with `sdd_change` first."
+			if len(inFlight) > 0 {
+				msg = "No active change on the checked-out branch. Create one with `sdd_change`, " +
+					"or resume one with `sdd_change_manage` (action: switch).\n\n" +
+					formatInFlight(inFlight, "")
+			}
+			return mcp.NewToolResultError(msg), nil
 		}
 	}
 
@@ -74,13 +92,7 @@ func (t *ChangeStatusTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 	}
 
 	for _, s := range change.Stages {
-		marker := "⬜"
-		switch s.Status {
-		case "completed":
-			marker = "✅"
-		case "in_progress":
-			marker = "🔄"
-		}
+		marker := stageMarker(s.Status)
 
 		artifact := "—"
 		filename := changes.StageFilename(s.Name)
@@ -89,11 +101,17 @@ func (t *ChangeStatusTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 			if info, statErr := os.Stat(filePath); statErr == nil {
 				artifact = fmt.Sprintf("`%s` (%d bytes)", filename, info.Size())
 			}
+			if revs, _ := changes.StageRevisions(changeDir, s.Name); len(revs) > 0 {
+				artifact += fmt.Sprintf(" + %d revision(s)", len(revs))
+			}
 		}
 
 		fmt.Fprintf(&stageTable, "| %s %s | %s | %s |\n", marker, s.Name, s.Status, artifact)
 	}
 
+	// Task progress, once the tasks stage has produced tasks.md.
+	taskSection := taskProgressSection(filepath.Join(changeDir, changes.StageFilename(changes.StageTasks)))
+
 	// ADRs section.
 	adrSection := ""
 	if len(change.ADRs) > 0 {
@@ -105,6 +123,21 @@ func (t *ChangeStatusTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 		adrSection = adrList.String() + "\n"
 	}
 
+	// Resize history, if the change was resized mid-flight.
+	resizeSection := ""
+	if len(change.Resizes) > 0 {
+		var resizeList strings.Builder
+		resizeList.WriteString("## Resizes\n\n")
+		for _, r := range change.Resizes {
+			fmt.Fprintf(&resizeList, "- %s → %s at %s (added: %s; removed: %s)", r.From, r.To, r.AtStage, joinStages(r.Added), joinStages(r.Removed))
+			if r.Reason != "" {
+				fmt.Fprintf(&resizeList, " — %s", r.Reason)
+			}
+			resizeList.WriteString("\n")
+		}
+		resizeSection = resizeList.String() + "\n"
+	}
+
 	response := fmt.Sprintf(
 		"# Change Status\n\n"+
 			"**ID:** `%s`\n"+
@@ -112,16 +145,105 @@ func (t *ChangeStatusTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 			"**Size:** %s\n"+
 			"**Description:** %s\n"+
 			"**Status:** %s\n"+
+			"**Branch:** %s\n"+
+			"%s"+
 			"**Created:** %s\n"+
 			"**Updated:** %s\n\n"+
 			"## Stage Progress\n\n"+
 			"%s\n"+
-			"%s",
+			"%s%s%s",
 		change.ID, change.Type, change.Size, change.Description,
-		change.Status, change.CreatedAt, change.UpdatedAt,
+		change.Status, branchLabel(change.Branch), linkLines(change), change.CreatedAt, change.UpdatedAt,
 		stageTable.String(),
-		adrSection,
+		taskSection, resizeSection, adrSection,
 	)
 
+	if len(inFlight) > 0 {
+		response += formatInFlight(inFlight, change.ID)
+	}
+
+	all, err := t.store.List(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("listing changes: %w", err)
+	}
+	if queue := changes.Queue(all); hasLinks(queue) {
+		response += "\n" + formatQueue(queue, change.ID)
+	}
+
 	return mcp.NewToolResultText(response), nil
 }
+
+// formatInFlight renders the active and paused changes across all
+// branches, marking the one currently shown.
+func formatInFlight(inFlight []changes.ChangeRecord, currentID string) string {
+	var b strings.Builder
+	b.WriteString("## In-Flight Changes\n\n")
+	b.WriteString("| Change | Status | Stage | Branch |\n")
+	b.WriteString("|--------|--------|-------|--------|\n")
+	for _, c := range inFlight {
+		marker := ""
+		if c.ID == currentID {
+			marker = " 👉"
+		}
+		branch := branchLabel(c.Branch)
+		if c.Worktree != "" {
+			branch += fmt.Sprintf(" (worktree `%s`)", c.Worktree)
+		}
+		fmt.Fprintf(&b, "| `%s`%s | %s | %s | %s |\n", c.ID, marker, c.Status, c.CurrentStage, branch)
+	}
+	return b.String()
+}
+
+// hasLinks reports whether any queued change waits on another, which is
+// when the queue is worth showing.
+func hasLinks(queue []changes.QueueEntry) bool {
+	for _, e := range queue {
+		if len(e.Waiting) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// formatQueue renders the changes still to land in dependency order:
+// the level in the DAG, and what each one waits on.
+func formatQueue(queue []changes.QueueEntry, currentID string) string {
+	var b strings.Builder
+	b.WriteString("## Change Queue\n\n")
+	b.WriteString("| Level | Change | Status | Waits on |\n")
+	b.WriteString("|-------|--------|--------|----------|\n")
+	for _, e := range queue {
+		marker := ""
+		if e.Change.ID == currentID {
+			marker = " 👉"
+		}
+		level := strconv.Itoa(e.Level)
+		if e.Level == 0 {
+			level = "❌ cycle"
+		}
+		waits := "— ready"
+		if len(e.Waiting) > 0 {
+			parts := make([]string, len(e.Waiting))
+			for i, dep := range e.Waiting {
+				parts[i] = fmt.Sprintf("`%s` (%s)", dep.ID, dep.Status)
+			}
+			waits = strings.Join(parts, ", ")
+		}
+		fmt.Fprintf(&b, "| %s | `%s`%s | %s | %s |\n", level, e.Change.ID, marker, e.Change.Status, waits)
+	}
+	return b.String()
+}
+
+// stageMarker returns the progress icon for a change stage status.
+func stageMarker(status string) string {
+	switch status {
+	case "completed":
+		return "✅"
+	case "in_progress":
+		return "🔄"
+	case "stale":
+		return "⚠️"
+	default:
+		return "⬜"
+	}
+}
@@ -267,7 +267,7 @@ func TestChangeStatusTool_Handle_CompletedChange(t *testing.T) {
 
 	// Complete the change through the advance tool.
 	store := changes.NewFileStore()
-	advanceTool := NewChangeAdvanceTool(store)
+	advanceTool := NewChangeAdvanceTool(store, mustRenderer(t))
 
 	stages := []string{
 		"# Describe\n\nContent.",
@@ -306,3 +306,39 @@ func TestChangeStatusTool_Handle_CompletedChange(t *testing.T) {
 		t.Error("result should show ✅ markers for all completed stages")
 	}
 }
+
+func TestChangeStatusTool_Handle_ListsInFlightChanges(t *testing.T) {
+	tmpDir, cleanup, current := createActiveChange(t, changes.TypeFix, changes.SizeSmall, "current work")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	other := &changes.ChangeRecord{
+		ID: "elsewhere", Type: changes.TypeFeature, Size: changes.SizeSmall,
+		Stages: current.Stages, CurrentStage: current.CurrentStage,
+		Status: changes.StatusActive, Branch: "feature/other",
+	}
+	if err := store.Create(tmpDir, other); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]interface{}{}
+	result, err := NewChangeStatusTool(store).Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if isErrorResult(result) {
+		t.Fatalf("expected success, got error: %s", getResultText(result))
+	}
+
+	text := getResultText(result)
+	if !strings.Contains(text, "## In-Flight Changes") {
+		t.Fatalf("result should list in-flight changes:\n%s", text)
+	}
+	if !strings.Contains(text, "`elsewhere`") || !strings.Contains(text, "`feature/other`") {
+		t.Errorf("in-flight list should show the other change and its branch:\n%s", text)
+	}
+	if !strings.Contains(text, "`current-work` 👉") {
+		t.Errorf("the shown change should be marked:\n%s", text)
+	}
+}
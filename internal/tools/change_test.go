@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -294,6 +295,164 @@ func TestChangeTool_Handle_ActiveChangeBlocks(t *testing.T) {
 	}
 }
 
+func TestChangeTool_Handle_OneActiveChangePerBranch(t *testing.T) {
+	tmpDir, cleanup := setupChangeProjectWithArtifacts(t)
+	defer cleanup()
+
+	gitDir := filepath.Join(tmpDir, ".git")
+	if err := os.MkdirAll(gitDir, 0o755); err != nil {
+		t.Fatalf("setup: mkdir .git: %v", err)
+	}
+	checkout := func(branch string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/"+branch+"\n"), 0o644); err != nil {
+			t.Fatalf("setup: write HEAD: %v", err)
+		}
+	}
+	create := func(desc string) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]interface{}{"type": "fix", "size": "small", "description": desc}
+		result, err := NewChangeTool(changes.NewFileStore()).Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		return result
+	}
+
+	checkout("main")
+	if result := create("fix on main"); isErrorResult(result) {
+		t.Fatalf("first change failed: %s", getResultText(result))
+	} else if !strings.Contains(getResultText(result), "**Branch:** `main`") {
+		t.Errorf("response should show the bound branch: %s", getResultText(result))
+	}
+
+	if result := create("second on main"); !isErrorResult(result) {
+		t.Error("a second active change on the same branch should be refused")
+	}
+
+	checkout("feature/login")
+	if result := create("fix on feature"); isErrorResult(result) {
+		t.Fatalf("change on another branch should be allowed: %s", getResultText(result))
+	}
+
+	rec, err := changes.NewFileStore().Load(tmpDir, "fix-on-feature")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if rec.Branch != "feature/login" {
+		t.Errorf("Branch = %q, want feature/login", rec.Branch)
+	}
+}
+
+func TestChangeTool_Handle_Links(t *testing.T) {
+	tmpDir, cleanup, schema := createActiveChange(t, changes.TypeFeature, changes.SizeSmall, "add schema")
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	schema.Status = changes.StatusPaused
+	if err := store.Save(tmpDir, schema); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	create := func(args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := NewChangeTool(store).Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		return result
+	}
+
+	result := create(map[string]interface{}{
+		"type": "feature", "size": "small", "description": "add api", "depends_on": "add-schema, add-auth",
+	})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "add-api depends on add-auth, which does not exist") {
+		t.Fatalf("expected unknown link error, got: %s", getResultText(result))
+	}
+	if _, err := store.Load(tmpDir, "add-api"); err == nil {
+		t.Error("a change with invalid links should not be created")
+	}
+
+	result = create(map[string]interface{}{
+		"type": "feature", "size": "small", "description": "add api", "depends_on": "add-schema",
+	})
+	if isErrorResult(result) {
+		t.Fatalf("create failed: %s", getResultText(result))
+	}
+	text := getResultText(result)
+	for _, want := range []string{"**Depends on:** `add-schema`", "⚠️ **Prerequisites not landed yet.**", "- `add-schema` (paused, stage: describe)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("response missing %q:\n%s", want, text)
+		}
+	}
+
+	// The status shows the queue in dependency order.
+	statusReq := mcp.CallToolRequest{}
+	statusReq.Params.Arguments = map[string]interface{}{}
+	statusResult, err := NewChangeStatusTool(store).Handle(context.Background(), statusReq)
+	if err != nil {
+		t.Fatalf("change status failed: %v", err)
+	}
+	text = getResultText(statusResult)
+	for _, want := range []string{
+		"## Change Queue",
+		"| 1 | `add-schema` | paused | — ready |",
+		"| 2 | `add-api` 👉 | active | `add-schema` (paused) |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("status missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestChangeTool_Handle_ProjectDefinedType(t *testing.T) {
+	tmpDir, cleanup := setupChangeProject(t)
+	defer cleanup()
+	t.Cleanup(func() { changes.SetRegistry(nil) })
+
+	flows := `{
+  "stages": {"threat-model": {}},
+  "types": {"security": {"extends": "fix",
+    "flows": {"small": ["describe", "context-check", "threat-model", "verify"]}}}
+}`
+	if err := os.WriteFile(changes.FlowsPath(tmpDir), []byte(flows), 0o644); err != nil {
+		t.Fatalf("setup: write flows: %v", err)
+	}
+
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]interface{}{"type": "security", "size": "small", "description": "rotate api keys"}
+	result, err := NewChangeTool(changes.NewFileStore()).Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if isErrorResult(result) {
+		t.Fatalf("project-defined type should be accepted: %s", getResultText(result))
+	}
+
+	rec, err := changes.NewFileStore().Load(tmpDir, "rotate-api-keys")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(rec.Stages) != 4 || rec.Stages[2].Name != "threat-model" {
+		t.Errorf("stages = %+v, want the security/small flow", rec.Stages)
+	}
+	if typeParam := fmt.Sprint(NewChangeTool(changes.NewFileStore()).Definition().InputSchema.Properties["type"]); !strings.Contains(typeParam, "security") {
+		t.Errorf("sdd_change type param = %s, want it to include security", typeParam)
+	}
+
+	// A broken flows file is reported, not silently ignored.
+	if err := os.WriteFile(changes.FlowsPath(tmpDir), []byte(`{"types": {"security": {"flows": {"small": ["describe"]}}}}`), 0o644); err != nil {
+		t.Fatalf("setup: write flows: %v", err)
+	}
+	req.Params.Arguments = map[string]interface{}{"type": "security", "size": "small", "description": "another"}
+	result, _ = NewChangeTool(changes.NewFileStore()).Handle(context.Background(), req)
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "hoofy-flows.json") {
+		t.Errorf("expected flows file error, got: %s", getResultText(result))
+	}
+}
+
 func TestChangeTool_Handle_AllTypeSizeCombinations(t *testing.T) {
 	types := []changes.ChangeType{changes.TypeFeature, changes.TypeFix, changes.TypeRefactor, changes.TypeEnhancement}
 	sizes := []changes.ChangeSize{changes.SizeSmall, changes.SizeMedium, changes.SizeLarge}
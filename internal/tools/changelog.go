@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/changelog"
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ChangelogTool handles the sdd_changelog MCP tool.
+// It renders a Keep a Changelog section from completed changes.
+type ChangelogTool struct {
+	store changes.Store
+}
+
+// NewChangelogTool creates a ChangelogTool with the given change store.
+func NewChangelogTool(store changes.Store) *ChangelogTool {
+	return &ChangelogTool{store: store}
+}
+
+// Definition returns the MCP tool definition for registration.
+func (t *ChangelogTool) Definition() mcp.Tool {
+	return mcp.NewTool("sdd_changelog",
+		mcp.WithDescription(
+			"Render a Keep a Changelog section from the changes completed between two "+
+				"dates or git tags. feature → Added, fix → Fixed, refactor/enhancement → Changed "+
+				"(project types follow the type they extend); each entry links the ADRs the "+
+				"change produced. With `write: true`, merges the entries into the `## [Unreleased]` "+
+				"section of CHANGELOG.md, creating the file or section as needed and skipping "+
+				"changes already listed.",
+		),
+		mcp.WithString("from",
+			mcp.Description("Start of the range: a date (YYYY-MM-DD, inclusive) or a git tag (exclusive). Omit for the beginning."),
+		),
+		mcp.WithString("to",
+			mcp.Description("End of the range: a date (YYYY-MM-DD, inclusive) or a git tag (inclusive). Omit for now."),
+		),
+		mcp.WithString("version",
+			mcp.Description("Release version for the heading, e.g. '1.4.0'. Omit to render [Unreleased]. Not allowed with write."),
+		),
+		mcp.WithBoolean("write",
+			mcp.Description("Merge the entries into the [Unreleased] section of CHANGELOG.md at the project root."),
+		),
+	)
+}
+
+// Handle processes the sdd_changelog tool call.
+func (t *ChangelogTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opts := changelog.Options{
+		From:    strings.TrimSpace(req.GetString("from", "")),
+		To:      strings.TrimSpace(req.GetString("to", "")),
+		Version: strings.TrimSpace(req.GetString("version", "")),
+	}
+	write := req.GetBool("write", false)
+	if write && opts.Version != "" {
+		return mcp.NewToolResultError("'version' and 'write' can't be combined — write always targets the [Unreleased] section"), nil
+	}
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("finding project root: %w", err)
+	}
+	if err := useProjectFlows(projectRoot); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	rel, err := changelog.Build(projectRoot, t.store, changes.CurrentRegistry(), opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Changelog\n\n")
+	if len(rel.Entries) == 0 {
+		sb.WriteString("⬜ No completed changes in this range.\n")
+		return mcp.NewToolResultText(sb.String()), nil
+	}
+
+	if write {
+		added, err := changelog.UpdateFile(changelog.Path(projectRoot), rel)
+		if err != nil {
+			return nil, fmt.Errorf("updating changelog: %w", err)
+		}
+		if added == 0 {
+			fmt.Fprintf(&sb, "⬜ `%s` already lists all %d change(s) under [Unreleased].\n\n", changelog.File, len(rel.Entries))
+		} else {
+			fmt.Fprintf(&sb, "✅ Added %d of %d change(s) to the [Unreleased] section of `%s`.\n\n", added, len(rel.Entries), changelog.File)
+		}
+	}
+
+	sb.WriteString("```markdown\n")
+	sb.WriteString(rel.Markdown())
+	sb.WriteString("```\n")
+	return mcp.NewToolResultText(sb.String()), nil
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestChangelogTool_Handle(t *testing.T) {
+	tmpDir, cleanup := setupChangeProject(t)
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	for _, c := range []*changes.ChangeRecord{
+		{ID: "add-export", Type: changes.TypeFeature, Description: "Add CSV export", Status: changes.StatusCompleted, UpdatedAt: "2026-05-02T10:00:00Z", ADRs: []string{"ADR-002"}},
+		{ID: "fix-timeout", Type: changes.TypeFix, Description: "Fix upload timeout", Status: changes.StatusCompleted, UpdatedAt: "2026-05-03T10:00:00Z"},
+		{ID: "wip", Type: changes.TypeFeature, Description: "Still going", Status: changes.StatusActive, UpdatedAt: "2026-05-03T11:00:00Z"},
+	} {
+		if err := store.Create(tmpDir, c); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	tool := NewChangelogTool(store)
+
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]interface{}{"from": "2026-05-01", "version": "2.0.0"})
+	text := getResultText(result)
+	if result.IsError ||
+		!strings.Contains(text, "## [2.0.0] - ") ||
+		!strings.Contains(text, "### Added\n\n- Add CSV export (`add-export`) — ADR-002") ||
+		!strings.Contains(text, "### Fixed\n\n- Fix upload timeout (`fix-timeout`)") {
+		t.Errorf("unexpected changelog:\n%s", text)
+	}
+	if strings.Contains(text, "wip") {
+		t.Errorf("active changes must be left out:\n%s", text)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "CHANGELOG.md")); !os.IsNotExist(err) {
+		t.Error("CHANGELOG.md must not be written without write: true")
+	}
+
+	if result := call(map[string]interface{}{"version": "2.0.0", "write": true}); !result.IsError {
+		t.Error("version with write should be rejected")
+	}
+
+	result = call(map[string]interface{}{"write": true})
+	if result.IsError || !strings.Contains(getResultText(result), "Added 2 of 2 change(s)") {
+		t.Errorf("write result:\n%s", getResultText(result))
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("CHANGELOG.md not written: %v", err)
+	}
+	if !strings.Contains(string(data), "## [Unreleased]\n\n### Added\n\n- Add CSV export (`add-export`)") {
+		t.Errorf("CHANGELOG.md:\n%s", data)
+	}
+
+	result = call(map[string]interface{}{"write": true})
+	if !strings.Contains(getResultText(result), "already lists all 2 change(s)") {
+		t.Errorf("second write should add nothing:\n%s", getResultText(result))
+	}
+
+	if result := call(map[string]interface{}{"from": "2027-01-01"}); !strings.Contains(getResultText(result), "No completed changes") {
+		t.Errorf("empty range:\n%s", getResultText(result))
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/HendryAvila/Hoofy/internal/config"
 	"github.com/HendryAvila/Hoofy/internal/memory"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -93,7 +94,8 @@ func (t *ContextTool) Definition() mcp.Tool {
 	return mcp.NewTool("sdd_get_context",
 		mcp.WithDescription(
 			"Read the current state of the SDD project. "+
-				"Returns pipeline status, current stage, clarity score, and optionally "+
+				"Returns pipeline status, current stage, clarity score, task progress "+
+				"(tracked with sdd_task), and optionally "+
 				"the content of specific stage artifacts. "+
 				"Use this to understand where the project is in the SDD pipeline.",
 		),
@@ -106,8 +108,8 @@ func (t *ContextTool) Definition() mcp.Tool {
 		mcp.WithString("detail_level",
 			mcp.Description(
 				"Level of detail for the overview: "+
-					"'summary' (default — stage names + status only — minimal tokens), "+
-					"'standard' (pipeline table, artifact sizes, next steps), "+
+					"'summary' (default — stage names + status and task progress only — minimal tokens), "+
+					"'standard' (pipeline table, artifact sizes, task progress, next steps), "+
 					"'full' (include complete artifact content inline). "+
 					"Defaults to 'summary'. Ignored when 'stage' is set.",
 			),
@@ -148,7 +150,7 @@ func (t *ContextTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp
 	var result *mcp.CallToolResult
 	switch detailLevel {
 	case "summary":
-		result = t.buildSummaryOverview(cfg)
+		result = t.buildSummaryOverview(cfg, projectRoot)
 	case "full":
 		result, err = t.buildFullOverview(cfg, projectRoot)
 		if err != nil {
@@ -288,6 +290,11 @@ func (t *ContextTool) buildOverview(cfg *config.ProjectConfig, projectRoot strin
 			meta.Name, config.StageFilename(stage), exists)
 	}
 
+	// Implementation progress tracked with sdd_task.
+	if section := taskProgressSection(config.StagePath(projectRoot, config.StageTasks)); section != "" {
+		sb.WriteString("\n" + section)
+	}
+
 	// Next steps.
 	sb.WriteString("\n## Next Steps\n\n")
 	sb.WriteString(nextStepGuidance(cfg))
@@ -297,7 +304,7 @@ func (t *ContextTool) buildOverview(cfg *config.ProjectConfig, projectRoot strin
 
 // buildSummaryOverview creates a minimal overview with stage names and status only.
 // Designed for minimal token usage — progressive disclosure pattern.
-func (t *ContextTool) buildSummaryOverview(cfg *config.ProjectConfig) *mcp.CallToolResult {
+func (t *ContextTool) buildSummaryOverview(cfg *config.ProjectConfig, projectRoot string) *mcp.CallToolResult {
 	var sb strings.Builder
 
 	fmt.Fprintf(&sb, "# %s [%s mode]\n\n", cfg.Name, cfg.Mode)
@@ -318,6 +325,11 @@ func (t *ContextTool) buildSummaryOverview(cfg *config.ProjectConfig) *mcp.CallT
 		fmt.Fprintf(&sb, "%s %s%s\n", indicator, meta.Name, current)
 	}
 
+	if graph, err := taskgraph.Open(config.StagePath(projectRoot, config.StageTasks)); err == nil && graph != nil && len(graph.Tasks) > 0 {
+		p := graph.Progress()
+		fmt.Fprintf(&sb, "\nTasks: %s — next: %s\n", p.Summary(), p.NextStep())
+	}
+
 	return mcp.NewToolResultText(sb.String())
 }
 
@@ -20,12 +20,12 @@ import (
 // from server instructions (ADR-001).
 type ContextCheckTool struct {
 	changeStore changes.Store
-	memStore    *memory.Store // nullable — works without memory
+	memStore    memory.Backend // nullable — works without memory
 }
 
 // NewContextCheckTool creates a ContextCheckTool with its dependencies.
 // memStore may be nil — the tool degrades gracefully by skipping memory search.
-func NewContextCheckTool(cs changes.Store, ms *memory.Store) *ContextCheckTool {
+func NewContextCheckTool(cs changes.Store, ms memory.Backend) *ContextCheckTool {
 	return &ContextCheckTool{changeStore: cs, memStore: ms}
 }
 
@@ -126,6 +126,9 @@ func (t *ContextCheckTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 	if len(matches) > 0 {
 		for _, m := range matches {
 			fmt.Fprintf(&sb, "- **%s** (%s/%s): %s\n", m.ID, m.Type, m.Size, m.Description)
+			if files := touchedFilesLine(m); files != "" {
+				fmt.Fprintf(&sb, "  - Files touched: %s\n", files)
+			}
 		}
 	} else {
 		sb.WriteString("_No completed changes found matching this description._\n")
@@ -358,6 +361,24 @@ func (t *ContextCheckTool) findRelevantChanges(projectRoot string, keywords []st
 	return result
 }
 
+// maxTouchedFiles caps how many files are listed per prior change.
+const maxTouchedFiles = 8
+
+// touchedFilesLine lists the files a change touched, as captured at its
+// verify stage. Returns "" when the change has no code summary.
+func touchedFilesLine(c changes.ChangeRecord) string {
+	if c.Code == nil || len(c.Code.Files) == 0 {
+		return ""
+	}
+	files := c.Code.Files
+	more := ""
+	if len(files) > maxTouchedFiles {
+		more = fmt.Sprintf(" (+%d more)", len(files)-maxTouchedFiles)
+		files = files[:maxTouchedFiles]
+	}
+	return "`" + strings.Join(files, "`, `") + "`" + more
+}
+
 // scanConventionFiles reads convention files from the project root.
 // Returns only files that exist, with content truncated to maxConventionLines.
 func (t *ContextCheckTool) scanConventionFiles(projectRoot string) []conventionInfo {
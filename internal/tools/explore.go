@@ -13,11 +13,11 @@ import (
 // It captures structured pre-pipeline context and saves it as a memory
 // observation with type=explore and topic_key upsert support.
 type ExploreTool struct {
-	store *memory.Store
+	store memory.Backend
 }
 
 // NewExploreTool creates an ExploreTool with the given memory store.
-func NewExploreTool(store *memory.Store) *ExploreTool {
+func NewExploreTool(store memory.Backend) *ExploreTool {
 	return &ExploreTool{store: store}
 }
 
@@ -14,7 +14,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/HendryAvila/Hoofy/internal/changes"
 	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/templates"
 )
 
 // findProjectRoot walks up from the current working directory looking
@@ -73,3 +75,49 @@ func writeStageFile(path, content string) error {
 	}
 	return os.WriteFile(path, []byte(content), 0o644)
 }
+
+// useProjectFlows installs the project's change registry (built-in flows
+// merged with docs/hoofy-flows.json) so that edits to the file take
+// effect without restarting the server. A broken file is reported as
+// an error and leaves the previous registry in place.
+func useProjectFlows(projectRoot string) error {
+	reg, err := changes.LoadRegistry(projectRoot)
+	if err != nil {
+		return err
+	}
+	changes.SetRegistry(reg)
+	return nil
+}
+
+// LoadProjectFlows installs the change registry of the project containing
+// the working directory. Call it before registering tools so the
+// sdd_change type enum includes project-defined types.
+func LoadProjectFlows() error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return fmt.Errorf("finding project root: %w", err)
+	}
+	return useProjectFlows(projectRoot)
+}
+
+// LoadProjectTemplates returns a renderer with the template overrides of
+// the project containing the working directory layered over the
+// embedded templates (see templates.LoadRenderer).
+func LoadProjectTemplates() (*templates.EmbedRenderer, error) {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("finding project root: %w", err)
+	}
+	return templates.LoadRenderer(projectRoot)
+}
+
+// changeTypeNames returns the registered change types as strings,
+// for tool enums.
+func changeTypeNames() []string {
+	types := changes.CurrentRegistry().Types()
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return names
+}
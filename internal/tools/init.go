@@ -101,7 +101,8 @@ func (t *InitTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.Ca
 	}
 
 	// Generate and write/append agent instructions file.
-	agentFile, agentAction, err := t.writeAgentInstructions(projectRoot, name, config.DocsDir)
+	relDocsDir := filepath.ToSlash(config.ResolveDocsDir(projectRoot))
+	agentFile, agentAction, err := t.writeAgentInstructions(projectRoot, name, relDocsDir)
 	if err != nil {
 		// Non-fatal: log but don't fail initialization.
 		agentFile = ""
@@ -134,7 +135,7 @@ func (t *InitTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.Ca
 			"%s\n\n"+
 			"Use `sdd_create_principles` to define your project's golden invariants.\n\n"+
 			"**Tell me about your project's core beliefs** — what rules should NEVER be broken?",
-		name, modeLabel, config.DocsDir, config.DocsDir,
+		name, modeLabel, relDocsDir, relDocsDir,
 		agentLine, modeHint,
 	)
 
@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/metrics"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MetricsTool handles the sdd_metrics MCP tool.
+// It aggregates the stage timestamps of changes into flow analytics.
+type MetricsTool struct {
+	store changes.Store
+}
+
+// NewMetricsTool creates a MetricsTool with the given change store.
+func NewMetricsTool(store changes.Store) *MetricsTool {
+	return &MetricsTool{store: store}
+}
+
+// Definition returns the MCP tool definition for registration.
+func (t *MetricsTool) Definition() mcp.Tool {
+	return mcp.NewTool("sdd_metrics",
+		mcp.WithDescription(
+			"Report change cycle-time metrics from the stage timestamps in change.json: "+
+				"lead time per completed change, time spent in each stage, throughput and lead "+
+				"time by type and size, how often context-check led to a scope change (the change "+
+				"was resized, rewound to an earlier stage, or abandoned afterwards), and the "+
+				"largest outliers relative to their median. Read-only.",
+		),
+		mcp.WithString("from",
+			mcp.Description("Start of the range (YYYY-MM-DD, inclusive). Omit for the beginning."),
+		),
+		mcp.WithString("to",
+			mcp.Description("End of the range (YYYY-MM-DD, inclusive). Omit for now."),
+		),
+		mcp.WithNumber("outliers",
+			mcp.Description(fmt.Sprintf("Number of outliers to list (default %d).", metrics.DefaultOutliers)),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown (default) or json."),
+			mcp.Enum(metrics.FormatMarkdown, metrics.FormatJSON),
+		),
+	)
+}
+
+// Handle processes the sdd_metrics tool call.
+func (t *MetricsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opts := metrics.Options{
+		From:     strings.TrimSpace(req.GetString("from", "")),
+		To:       strings.TrimSpace(req.GetString("to", "")),
+		Outliers: intArgTools(req, "outliers", 0),
+	}
+	format := req.GetString("format", metrics.FormatMarkdown)
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("finding project root: %w", err)
+	}
+
+	report, err := metrics.Build(projectRoot, t.store, opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	out, err := report.Render(format)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(out), nil
+}
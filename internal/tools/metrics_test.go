@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMetricsTool_Handle(t *testing.T) {
+	tmpDir, cleanup := setupChangeProject(t)
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	for _, c := range []*changes.ChangeRecord{
+		{ID: "add-export", Type: changes.TypeFeature, Size: changes.SizeSmall, Status: changes.StatusCompleted, Stages: []changes.StageEntry{
+			{Name: changes.StageDescribe, Status: "completed", StartedAt: "2026-05-02T08:00:00Z", CompletedAt: "2026-05-02T09:00:00Z"},
+			{Name: changes.StageVerify, Status: "completed", StartedAt: "2026-05-02T09:00:00Z", CompletedAt: "2026-05-02T12:00:00Z"},
+		}},
+		{ID: "wip", Type: changes.TypeFix, Size: changes.SizeSmall, Status: changes.StatusActive, Stages: []changes.StageEntry{
+			{Name: changes.StageDescribe, Status: "in_progress", StartedAt: "2026-05-03T08:00:00Z"},
+		}},
+	} {
+		if err := store.Create(tmpDir, c); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	tool := NewMetricsTool(store)
+
+	call := func(args map[string]interface{}) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		return result
+	}
+
+	text := getResultText(call(map[string]interface{}{"from": "2026-05-01"}))
+	for _, want := range []string{"# Change Metrics", "1 change(s) completed — median 4.0h", "| `add-export` | feature | small | 2026-05-02 | 4.0h |"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("markdown missing %q:\n%s", want, text)
+		}
+	}
+
+	result := call(map[string]interface{}{"format": "json", "outliers": float64(1)})
+	var report struct {
+		Changes []struct {
+			ID string `json:"id"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(getResultText(result)), &report); err != nil || len(report.Changes) != 1 {
+		t.Errorf("json output = %s (%v)", getResultText(result), err)
+	}
+
+	if result := call(map[string]interface{}{"from": "May"}); !isErrorResult(result) || !strings.Contains(getResultText(result), "YYYY-MM-DD") {
+		t.Errorf("expected bad date error, got: %s", getResultText(result))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/HendryAvila/Hoofy/internal/changes"
 	"github.com/HendryAvila/Hoofy/internal/config"
 	"github.com/HendryAvila/Hoofy/internal/memory"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -22,13 +23,14 @@ import (
 // Standalone by design (ADR: three-feature design) — works without an
 // active change pipeline or hoofy.json.
 type ReviewTool struct {
-	memStore *memory.Store // nullable — degrades gracefully
+	memStore    memory.Backend // nullable — degrades gracefully
+	changeStore changes.Store
 }
 
 // NewReviewTool creates a ReviewTool with its dependencies.
 // memStore may be nil — the tool skips ADR search when unavailable.
-func NewReviewTool(ms *memory.Store) *ReviewTool {
-	return &ReviewTool{memStore: ms}
+func NewReviewTool(ms memory.Backend, cs changes.Store) *ReviewTool {
+	return &ReviewTool{memStore: ms, changeStore: cs}
 }
 
 // Definition returns the MCP tool definition for registration.
@@ -114,6 +116,9 @@ func (t *ReviewTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	// --- Section 4: ADR Alignment ---
 	adrItems := t.searchADRs(changeDesc, projectName)
 
+	// --- Section 5: Related past changes and the files they touched ---
+	pastItems := t.relatedPastChanges(cwd, keywords)
+
 	// Write header with specs analyzed.
 	if len(specsFound) > 0 {
 		fmt.Fprintf(&sb, "**Specs analyzed**: %s\n\n", strings.Join(specsFound, ", "))
@@ -165,6 +170,18 @@ func (t *ReviewTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	}
 	sb.WriteString("\n")
 
+	// Write past change items.
+	sb.WriteString("## Related Past Changes\n\n")
+	if len(pastItems) > 0 {
+		sb.WriteString("Files these changes touched — check the new code doesn't regress them.\n\n")
+		for _, item := range pastItems {
+			writeChecklistItem(&sb, item, detailLevel)
+		}
+	} else {
+		sb.WriteString("_No related past changes with recorded code._\n")
+	}
+	sb.WriteString("\n")
+
 	// General checks — always included.
 	sb.WriteString("## General Checks\n\n")
 	sb.WriteString("- [ ] No new business rules introduced without updating business-rules.md\n")
@@ -380,6 +397,37 @@ func (t *ReviewTool) searchADRs(changeDesc, projectName string) []checklistItem
 
 // --- Private helpers ---
 
+// reviewMaxPastChanges is the maximum number of past changes to include.
+const reviewMaxPastChanges = 5
+
+// relatedPastChanges lists changes whose description matches the keywords
+// and whose verify stage recorded the files they touched.
+func (t *ReviewTool) relatedPastChanges(cwd string, keywords []string) []checklistItem {
+	if t.changeStore == nil {
+		return nil
+	}
+	all, err := t.changeStore.List(cwd)
+	if err != nil {
+		return nil
+	}
+
+	var items []checklistItem
+	for _, c := range all {
+		if c.Code == nil || len(c.Code.Files) == 0 || !keywordMatch(c.Description+" "+c.ID, keywords) {
+			continue
+		}
+		items = append(items, checklistItem{
+			id:       c.ID,
+			summary:  touchedFilesLine(c),
+			fullText: fmt.Sprintf("%s — touched `%s`", c.Description, strings.Join(c.Code.Files, "`, `")),
+		})
+		if len(items) == reviewMaxPastChanges {
+			break
+		}
+	}
+	return items
+}
+
 // intArgReview extracts an integer argument from a tool request.
 func intArgReview(req mcp.CallToolRequest, key string, defaultVal int) int {
 	v, ok := req.GetArguments()[key].(float64)
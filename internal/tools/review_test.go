@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestReviewTool_Handle_RelatedPastChanges(t *testing.T) {
+	tmpDir, cleanup := setupChangeProject(t)
+	defer cleanup()
+
+	store := changes.NewFileStore()
+	past := &changes.ChangeRecord{
+		ID: "fix-search-crash", Type: changes.TypeFix, Size: changes.SizeSmall,
+		Description: "Fix search crash on empty query", Status: changes.StatusCompleted,
+		CurrentStage: changes.StageVerify,
+		Code:         &changes.CodeSummary{Files: []string{"internal/search/handler.go", "internal/search/query.go"}},
+	}
+	unrelated := &changes.ChangeRecord{
+		ID: "add-billing", Type: changes.TypeFeature, Size: changes.SizeSmall,
+		Description: "Add billing page", Status: changes.StatusCompleted,
+		CurrentStage: changes.StageVerify,
+		Code:         &changes.CodeSummary{Files: []string{"web/billing.tsx"}},
+	}
+	for _, c := range []*changes.ChangeRecord{past, unrelated} {
+		if err := store.Create(tmpDir, c); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]interface{}{"change_description": "Speed up search results"}
+	result, err := NewReviewTool(nil, store).Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	text := getResultText(result)
+	if !strings.Contains(text, "## Related Past Changes") ||
+		!strings.Contains(text, "**fix-search-crash**: `internal/search/handler.go`, `internal/search/query.go`") {
+		t.Errorf("review should list files of related past changes:\n%s", text)
+	}
+	if strings.Contains(text, "billing") {
+		t.Errorf("unrelated changes should be left out:\n%s", text)
+	}
+}
@@ -20,12 +20,12 @@ import (
 // formal change pipeline (87% of sessions per the Codified Context paper).
 type SuggestContextTool struct {
 	changeStore changes.Store
-	memStore    *memory.Store // nullable — degrades gracefully
+	memStore    memory.Backend // nullable — degrades gracefully
 }
 
 // NewSuggestContextTool creates a SuggestContextTool with its dependencies.
 // memStore may be nil — the tool skips memory search when unavailable.
-func NewSuggestContextTool(cs changes.Store, ms *memory.Store) *SuggestContextTool {
+func NewSuggestContextTool(cs changes.Store, ms memory.Backend) *SuggestContextTool {
 	return &SuggestContextTool{changeStore: cs, memStore: ms}
 }
 
@@ -0,0 +1,228 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TaskTool handles the sdd_task MCP tool.
+// It tracks implementation progress per TASK-NNN for the active change
+// or the project pipeline.
+type TaskTool struct {
+	store changes.Store
+}
+
+// NewTaskTool creates a TaskTool with the given change store.
+func NewTaskTool(store changes.Store) *TaskTool {
+	return &TaskTool{store: store}
+}
+
+// Definition returns the MCP tool definition for registration.
+func (t *TaskTool) Definition() mcp.Tool {
+	return mcp.NewTool("sdd_task",
+		mcp.WithDescription(
+			"Track implementation progress of the tasks in tasks.md. Actions: "+
+				"start (mark a task in progress — all its dependencies must be done), "+
+				"done (mark a task done and tick its checkboxes in tasks.md), "+
+				"block (mark a task blocked, with a reason), "+
+				"list (every task with its wave and status, overall progress, and the next wave to work on). "+
+				"Works on the active change's tasks.md when it has one, otherwise on docs/tasks.md. "+
+				"Status is saved in tasks.json next to tasks.md.",
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Description("Progress action to perform"),
+			mcp.Enum("start", "done", "block", "list"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Task ID, e.g. 'TASK-003'. Required for start, done and block."),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Why the task is blocked. Required for block."),
+		),
+		mcp.WithString("scope",
+			mcp.Description("Which tasks.md to track. Default: the active change's, falling back to the project's."),
+			mcp.Enum("change", "project"),
+		),
+	)
+}
+
+// Handle processes the sdd_task tool call.
+func (t *TaskTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	action := req.GetString("action", "")
+	taskID := strings.ToUpper(strings.TrimSpace(req.GetString("task_id", "")))
+	reason := req.GetString("reason", "")
+
+	var status taskgraph.Status
+	switch action {
+	case "start":
+		status = taskgraph.StatusInProgress
+	case "done":
+		status = taskgraph.StatusDone
+	case "block":
+		status = taskgraph.StatusBlocked
+		if strings.TrimSpace(reason) == "" {
+			return mcp.NewToolResultError("'reason' is required for block — what is the task waiting on?"), nil
+		}
+	case "list":
+	case "":
+		return mcp.NewToolResultError("'action' is required — one of: start, done, block, list"), nil
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("invalid action %q: must be one of: start, done, block, list", action)), nil
+	}
+	if action != "list" && taskID == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("'task_id' is required for %s", action)), nil
+	}
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("finding project root: %w", err)
+	}
+	if err := useProjectFlows(projectRoot); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tasksPath, label, errMsg, err := t.resolveTasks(projectRoot, req.GetString("scope", ""))
+	if err != nil {
+		return nil, err
+	}
+	if errMsg != "" {
+		return mcp.NewToolResultError(errMsg), nil
+	}
+
+	graph, err := taskgraph.Open(tasksPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %v", label, err)), nil
+	}
+
+	var sb strings.Builder
+	if action != "list" {
+		if err := graph.SetStatus(taskID, status, reason, time.Now()); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if err := graph.Save(tasksPath); err != nil {
+			return nil, fmt.Errorf("saving task status: %w", err)
+		}
+		if err := syncCheckboxes(tasksPath, taskID, status == taskgraph.StatusDone); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&sb, "%s **%s** is now %s\n\n", taskStatusMarker(status), taskID, status)
+	}
+
+	fmt.Fprintf(&sb, "# Tasks: %s\n\n", label)
+	sb.WriteString(formatTaskProgress(graph))
+	sb.WriteString("\n| Task | Wave | Status | Depends on |\n")
+	sb.WriteString("|------|------|--------|------------|\n")
+	for _, task := range graph.Tasks {
+		name := task.ID
+		if task.Title != "" {
+			name += ": " + task.Title
+		}
+		state := string(task.Status)
+		if state == "" {
+			state = string(taskgraph.StatusPending)
+		}
+		if task.BlockedReason != "" {
+			state += " — " + task.BlockedReason
+		}
+		deps := "—"
+		if len(task.Dependencies) > 0 {
+			deps = strings.Join(task.Dependencies, ", ")
+		}
+		fmt.Fprintf(&sb, "| %s %s | %d | %s | %s |\n", taskStatusMarker(task.Status), name, task.Wave, state, deps)
+	}
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// resolveTasks picks the tasks artifact to track and a label for it.
+// A non-empty errMsg is a user error.
+func (t *TaskTool) resolveTasks(projectRoot, scope string) (path, label, errMsg string, err error) {
+	projectPath := config.StagePath(projectRoot, config.StageTasks)
+	if scope == "project" {
+		if !fileExists(projectPath) {
+			return "", "", "docs/tasks.md does not exist — create it with `sdd_create_tasks` first.", nil
+		}
+		return projectPath, "project (`docs/tasks.md`)", "", nil
+	}
+
+	active, err := t.store.LoadActive(projectRoot)
+	if err != nil {
+		return "", "", "", fmt.Errorf("loading active change: %w", err)
+	}
+	if active != nil {
+		changePath := filepath.Join(changes.ChangePath(projectRoot, active.ID), changes.StageFilename(changes.StageTasks))
+		if fileExists(changePath) {
+			return changePath, fmt.Sprintf("change `%s`", active.ID), "", nil
+		}
+	}
+	if scope == "change" {
+		if active == nil {
+			return "", "", "No active change found. Create one with `sdd_change` first.", nil
+		}
+		return "", "", fmt.Sprintf("Change `%s` has no tasks.md yet — complete its tasks stage first.", active.ID), nil
+	}
+	if fileExists(projectPath) {
+		return projectPath, "project (`docs/tasks.md`)", "", nil
+	}
+	return "", "", "No tasks.md found: neither the active change nor the project has a task breakdown yet.", nil
+}
+
+// syncCheckboxes mirrors a task's status in the checkboxes of its block
+// in tasks.md: ticked when done, cleared otherwise.
+func syncCheckboxes(tasksPath, taskID string, done bool) error {
+	data, err := os.ReadFile(tasksPath)
+	if err != nil {
+		return fmt.Errorf("reading tasks: %w", err)
+	}
+	updated := taskgraph.MarkCheckboxes(string(data), taskID, done)
+	if updated == string(data) {
+		return nil
+	}
+	if err := writeStageFile(tasksPath, updated); err != nil {
+		return fmt.Errorf("writing tasks: %w", err)
+	}
+	return nil
+}
+
+// taskProgressSection renders the progress of the tasks artifact at
+// tasksPath as a "## Task Progress" section, or "" when there is none.
+func taskProgressSection(tasksPath string) string {
+	graph, err := taskgraph.Open(tasksPath)
+	if err != nil {
+		return fmt.Sprintf("## Task Progress\n\n⚠️ %v\n\n", err)
+	}
+	if graph == nil || len(graph.Tasks) == 0 {
+		return ""
+	}
+	return "## Task Progress\n\n" + formatTaskProgress(graph) + "\n"
+}
+
+// formatTaskProgress renders overall progress and the next wave.
+func formatTaskProgress(g *taskgraph.Graph) string {
+	p := g.Progress()
+	return fmt.Sprintf("**Progress:** %s\n**Next:** %s\n", p.Summary(), p.NextStep())
+}
+
+// taskStatusMarker returns the progress icon for a task status.
+func taskStatusMarker(status taskgraph.Status) string {
+	switch status {
+	case taskgraph.StatusDone:
+		return "✅"
+	case taskgraph.StatusInProgress:
+		return "🔄"
+	case taskgraph.StatusBlocked:
+		return "⚠️"
+	default:
+		return "⬜"
+	}
+}
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HendryAvila/Hoofy/internal/changes"
+	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const trackedTasks = `# Tasks
+
+### TASK-001: Model
+**Dependencies**: None
+**Acceptance Criteria**:
+- [ ] saved
+
+### TASK-002: Endpoint
+**Dependencies**: TASK-001
+**Acceptance Criteria**:
+- [ ] returns 201
+
+### TASK-003: Docs
+**Dependencies**: None
+**Acceptance Criteria**:
+- [ ] written
+`
+
+// callTask runs sdd_task with the given arguments.
+func callTask(t *testing.T, tool *TaskTool, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	result, err := tool.Handle(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	return result
+}
+
+func TestTaskTool_Definition(t *testing.T) {
+	def := NewTaskTool(changes.NewFileStore()).Definition()
+	if def.Name != "sdd_task" {
+		t.Errorf("name = %q, want sdd_task", def.Name)
+	}
+}
+
+func TestTaskTool_Handle_ChangeTasks(t *testing.T) {
+	tmpDir, cleanup, change := createActiveChange(t, changes.TypeFeature, changes.SizeSmall, "track tasks")
+	defer cleanup()
+
+	tasksPath := filepath.Join(changes.ChangePath(tmpDir, change.ID), "tasks.md")
+	if err := writeStageFile(tasksPath, trackedTasks); err != nil {
+		t.Fatal(err)
+	}
+	store := changes.NewFileStore()
+	tool := NewTaskTool(store)
+
+	// User errors.
+	for _, tc := range []struct {
+		args map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"action": "start"}, "'task_id' is required"},
+		{map[string]interface{}{"action": "block", "task_id": "TASK-003"}, "'reason' is required"},
+		{map[string]interface{}{"action": "pause", "task_id": "TASK-003"}, "invalid action"},
+		{map[string]interface{}{"action": "start", "task_id": "TASK-002"}, "TASK-001 (pending)"},
+		{map[string]interface{}{"action": "done", "task_id": "TASK-009"}, "TASK-009 is not defined"},
+	} {
+		result := callTask(t, tool, tc.args)
+		if !isErrorResult(result) || !strings.Contains(getResultText(result), tc.want) {
+			t.Errorf("%v: got %s, want error containing %q", tc.args, getResultText(result), tc.want)
+		}
+	}
+
+	if result := callTask(t, tool, map[string]interface{}{"action": "start", "task_id": "task-001"}); isErrorResult(result) {
+		t.Fatalf("start failed: %s", getResultText(result))
+	}
+	result := callTask(t, tool, map[string]interface{}{"action": "done", "task_id": "TASK-001"})
+	if isErrorResult(result) || !strings.Contains(getResultText(result), "✅ **TASK-001** is now done") {
+		t.Fatalf("done: %s", getResultText(result))
+	}
+	callTask(t, tool, map[string]interface{}{"action": "block", "task_id": "TASK-003", "reason": "waiting on API docs"})
+
+	data, _ := os.ReadFile(tasksPath)
+	if !strings.Contains(string(data), "- [x] saved") || !strings.Contains(string(data), "- [ ] returns 201") {
+		t.Errorf("tasks.md checkboxes not synced:\n%s", data)
+	}
+	graph, err := taskgraph.Load(tasksPath)
+	if err != nil || graph == nil {
+		t.Fatalf("Load tasks.json = %v, %v", graph, err)
+	}
+	if graph.Task("TASK-001").Status != taskgraph.StatusDone || graph.Task("TASK-003").BlockedReason != "waiting on API docs" {
+		t.Errorf("tasks.json = %+v", graph.Tasks)
+	}
+
+	text := getResultText(callTask(t, tool, map[string]interface{}{"action": "list"}))
+	for _, want := range []string{
+		"# Tasks: change `track-tasks`",
+		"**Progress:** 1/3 tasks done (33%) · 1 blocked",
+		"**Next:** Wave 2 — ready: TASK-002",
+		"| ✅ TASK-001: Model | 1 | done | — |",
+		"| ⬜ TASK-002: Endpoint | 2 | pending | TASK-001 |",
+		"| ⚠️ TASK-003: Docs | 1 | blocked — waiting on API docs | — |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("list missing %q:\n%s", want, text)
+		}
+	}
+
+	// Progress shows up in the change status.
+	statusReq := mcp.CallToolRequest{}
+	statusReq.Params.Arguments = map[string]interface{}{}
+	statusResult, err := NewChangeStatusTool(store).Handle(context.Background(), statusReq)
+	if err != nil {
+		t.Fatalf("change status failed: %v", err)
+	}
+	if text := getResultText(statusResult); !strings.Contains(text, "## Task Progress") || !strings.Contains(text, "1/3 tasks done") {
+		t.Errorf("change status missing task progress:\n%s", text)
+	}
+}
+
+func TestTaskTool_Handle_ProjectTasks(t *testing.T) {
+	tmpDir, cleanup := setupTestProjectAtStage(t, config.ModeGuided, config.StageValidate)
+	defer cleanup()
+
+	tool := NewTaskTool(changes.NewFileStore())
+
+	result := callTask(t, tool, map[string]interface{}{"action": "list"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "No tasks.md found") {
+		t.Fatalf("expected missing tasks error, got: %s", getResultText(result))
+	}
+	result = callTask(t, tool, map[string]interface{}{"action": "list", "scope": "change"})
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "No active change") {
+		t.Fatalf("expected no active change error, got: %s", getResultText(result))
+	}
+
+	if err := writeStageFile(config.StagePath(tmpDir, config.StageTasks), trackedTasks); err != nil {
+		t.Fatal(err)
+	}
+	result = callTask(t, tool, map[string]interface{}{"action": "done", "task_id": "TASK-001"})
+	if isErrorResult(result) || !strings.Contains(getResultText(result), "project (`docs/tasks.md`)") {
+		t.Fatalf("done: %s", getResultText(result))
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "docs", "tasks.json")); err != nil {
+		t.Errorf("tasks.json not written: %v", err)
+	}
+
+	// Progress shows up in sdd_get_context, summary and standard.
+	contextTool := NewContextTool(config.NewFileStore())
+	for _, level := range []string{"summary", "standard"} {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]interface{}{"detail_level": level}
+		result, err := contextTool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("get_context failed: %v", err)
+		}
+		text := getResultText(result)
+		if !strings.Contains(text, "1/3 tasks done (33%)") || !strings.Contains(text, "Wave 1 — ready: TASK-003") {
+			t.Errorf("%s context missing task progress:\n%s", level, text)
+		}
+	}
+}
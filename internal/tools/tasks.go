@@ -3,9 +3,11 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/HendryAvila/Hoofy/internal/config"
 	"github.com/HendryAvila/Hoofy/internal/pipeline"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
 	"github.com/HendryAvila/Hoofy/internal/templates"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -38,6 +40,9 @@ func (t *TasksTool) Definition() mcp.Tool {
 				"Each task should be small enough for a single commit, have clear acceptance criteria, "+
 				"and reference the requirements (FR-XXX/NFR-XXX) and components it implements. "+
 				"Pass the ACTUAL task content (not placeholders). "+
+				"Hoofy parses the ### TASK-NNN blocks and their **Dependencies** lines into a task graph, "+
+				"rejects dependency cycles and references to undefined tasks, computes execution waves, "+
+				"flags wave_assignments that contradict the dependencies, and saves the graph to docs/tasks.json. "+
 				"Requires: sdd_create_design must have been run first.",
 		),
 		mcp.WithString("total_tasks",
@@ -143,6 +148,16 @@ func (t *TasksTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 		return mcp.NewToolResultError("design.md is empty — run sdd_create_design first"), nil
 	}
 
+	// Parse the task graph before anything is written: a cycle or a
+	// dependency on an undefined task makes the breakdown unexecutable.
+	var graph *taskgraph.Graph
+	if parsed := taskgraph.Parse(tasks); len(parsed) > 0 {
+		graph, err = taskgraph.Build(parsed)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("❌ %v\n\nFix the **Dependencies** lines and call `sdd_create_tasks` again.", err)), nil
+		}
+	}
+
 	pipeline.MarkInProgress(cfg)
 
 	// Fill optional fields with defaults.
@@ -175,6 +190,17 @@ func (t *TasksTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 		return nil, fmt.Errorf("writing tasks: %w", err)
 	}
 
+	graphNote := "\n\n⚠️ No `### TASK-NNN` blocks found — `docs/tasks.json` was not written."
+	if graph != nil {
+		if prev, err := taskgraph.Load(tasksPath); err == nil {
+			graph.Carry(prev)
+		}
+		if err := graph.Save(tasksPath); err != nil {
+			return nil, fmt.Errorf("saving task graph: %w", err)
+		}
+		graphNote = "\n\n" + formatTaskGraph(graph, graph.CheckWaves(taskgraph.ParseWaves(waveAssignments)), "docs/tasks.json")
+	}
+
 	// Advance pipeline to next stage.
 	if err := pipeline.Advance(cfg); err != nil {
 		return nil, fmt.Errorf("advancing pipeline: %w", err)
@@ -188,7 +214,7 @@ func (t *TasksTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 
 	response := fmt.Sprintf(
 		"# Implementation Tasks Created\n\n"+
-			"Saved to `docs/tasks.md`\n\n"+
+			"Saved to `docs/tasks.md`%s\n\n"+
 			"## Content\n\n%s\n\n"+
 			"---\n\n"+
 			"## Next Step\n\n"+
@@ -199,8 +225,25 @@ func (t *TasksTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 			"- Task dependencies are valid (no circular dependencies)\n"+
 			"- No orphaned tasks (tasks that don't trace to any requirement)\n\n"+
 			"Call `sdd_validate` with your validation analysis.",
-		content,
+		graphNote, content,
 	)
 
 	return mcp.NewToolResultText(response), nil
 }
+
+// formatTaskGraph renders the computed waves of a task graph and any
+// contradictions found in the AI-written wave assignments.
+func formatTaskGraph(g *taskgraph.Graph, issues []string, savedTo string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Task Graph\n\n✅ %d task(s) in %d wave(s), no dependency cycles — saved to `%s`\n\n",
+		len(g.Tasks), len(g.Waves), savedTo)
+	sb.WriteString(g.Markdown())
+	if len(issues) > 0 {
+		sb.WriteString("\n⚠️ The wave assignments contradict the dependencies:\n\n")
+		for _, issue := range issues {
+			fmt.Fprintf(&sb, "- %s\n", issue)
+		}
+		sb.WriteString("\nThe computed waves above are authoritative.\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
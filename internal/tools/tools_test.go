@@ -8,9 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/HendryAvila/Hoofy/internal/changes"
 	"github.com/HendryAvila/Hoofy/internal/config"
 	"github.com/HendryAvila/Hoofy/internal/memory"
 	"github.com/HendryAvila/Hoofy/internal/pipeline"
+	"github.com/HendryAvila/Hoofy/internal/taskgraph"
 	"github.com/HendryAvila/Hoofy/internal/templates"
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -1946,6 +1948,78 @@ func TestTasksTool_Handle_AdvancesPipeline(t *testing.T) {
 	}
 }
 
+func TestTasksTool_Handle_TaskGraph(t *testing.T) {
+	tmpDir, cleanup := setupTestProjectAtStage(t, config.ModeGuided, config.StageTasks)
+	defer cleanup()
+
+	designPath := config.StagePath(tmpDir, config.StageDesign)
+	if err := writeStageFile(designPath, "# Design\n\nSome content."); err != nil {
+		t.Fatalf("write design: %v", err)
+	}
+
+	store := config.NewFileStore()
+	renderer, _ := templates.NewRenderer()
+	tool := NewTasksTool(store, renderer)
+	handle := func(tasks, waves string) *mcp.CallToolResult {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]interface{}{
+			"total_tasks":      "3",
+			"estimated_effort": "1 week",
+			"tasks":            tasks,
+			"wave_assignments": waves,
+		}
+		result, err := tool.Handle(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+		return result
+	}
+	tasksJSON := filepath.Join(tmpDir, "docs", "tasks.json")
+
+	// A cycle is rejected before anything is written.
+	result := handle("### TASK-001: A\n**Dependencies**: TASK-002\n### TASK-002: B\n**Dependencies**: TASK-001", "")
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "dependency cycle") {
+		t.Fatalf("expected cycle error, got: %s", getResultText(result))
+	}
+	// So is a dependency on an undefined task.
+	result = handle("### TASK-001: A\n**Dependencies**: TASK-009", "")
+	if !isErrorResult(result) || !strings.Contains(getResultText(result), "TASK-009, which is not defined") {
+		t.Fatalf("expected dangling reference error, got: %s", getResultText(result))
+	}
+	if _, err := os.Stat(tasksJSON); !os.IsNotExist(err) {
+		t.Fatal("rejected tasks must not write tasks.json")
+	}
+	if cfg, _ := store.Load(tmpDir); cfg.CurrentStage != config.StageTasks {
+		t.Fatalf("rejected tasks must not advance the pipeline, got: %s", cfg.CurrentStage)
+	}
+
+	result = handle(
+		"### TASK-001: Setup\n**Dependencies**: None\n### TASK-002: Auth\n**Dependencies**: TASK-001\n### TASK-003: Deploy\n**Dependencies**: TASK-001, TASK-002",
+		"**Wave 1**:\n- TASK-001\n\n**Wave 2**:\n- TASK-002\n- TASK-003",
+	)
+	if isErrorResult(result) {
+		t.Fatalf("unexpected error: %s", getResultText(result))
+	}
+	text := getResultText(result)
+	for _, want := range []string{
+		"3 task(s) in 3 wave(s)",
+		"**Wave 3**:\n- TASK-003: Deploy",
+		"TASK-003 is in wave 2 but depends on TASK-002 in wave 2 (computed: wave 3)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("response missing %q:\n%s", want, text)
+		}
+	}
+	graph, err := taskgraph.Load(config.StagePath(tmpDir, config.StageTasks))
+	if err != nil || graph == nil {
+		t.Fatalf("Load tasks.json = %v, %v", graph, err)
+	}
+	if len(graph.Tasks) != 3 || graph.Tasks[2].Wave != 3 || strings.Join(graph.Tasks[2].Dependencies, ",") != "TASK-001,TASK-002" {
+		t.Errorf("Tasks = %+v", graph.Tasks)
+	}
+}
+
 // --- ValidateTool ---
 
 // setupValidateProject creates a project at validate stage with all artifacts.
@@ -2425,6 +2499,47 @@ func TestMemoryBridge_TopicKeyUpsert(t *testing.T) {
 	}
 }
 
+func TestMemoryBridge_OnChangeTransition(t *testing.T) {
+	ms := memory.NewMemoryBackend(memory.DefaultConfig())
+	bridge := NewMemoryBridge(ms)
+
+	bridge.OnChangeTransition("add-oauth", changes.StatusActive, changes.StatusAbandoned, "**Reason**: vendor dropped")
+	bridge.OnChangeTransition("add-oauth", changes.StatusAbandoned, changes.StatusActive, "")
+
+	results, err := ms.Search("add-oauth", memory.SearchOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected one observation per transition, got %d", len(results))
+	}
+	var sawReason bool
+	for _, r := range results {
+		if strings.Contains(r.Content, "vendor dropped") && strings.Contains(r.Content, "active → abandoned") {
+			sawReason = true
+		}
+	}
+	if !sawReason {
+		t.Errorf("abandon transition with reason not recorded: %+v", results)
+	}
+}
+
+func TestMemoryBridge_OnChangeResized(t *testing.T) {
+	ms := memory.NewMemoryBackend(memory.DefaultConfig())
+	bridge := NewMemoryBridge(ms)
+
+	bridge.OnChangeResized("add-oauth", changes.SizeSmall, changes.SizeMedium, "**Stages added**: spec")
+
+	results, err := ms.Search("add-oauth", memory.SearchOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0].Content, "small → medium") ||
+		!strings.Contains(results[0].Content, "Stages added") {
+		t.Errorf("resize not recorded: %+v", results)
+	}
+}
+
 func TestCharterTool_SetBridge(t *testing.T) {
 	store := config.NewFileStore()
 	renderer, _ := templates.NewRenderer()
@@ -0,0 +1,315 @@
+// Package userconfig handles Hoofy's user-level settings.
+//
+// Unlike the config package (per-project hoofy.json), this package deals
+// with ~/.hoofy/config.json: machine-wide preferences for the memory
+// store, where new projects put their docs, which tools are exposed, and
+// how "hoofy serve" listens. Values are resolved in this order, later
+// wins: built-in defaults → config file → HOOFY_* environment variables
+// → command-line flags (applied by the caller).
+package userconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HendryAvila/Hoofy/internal/config"
+	"github.com/HendryAvila/Hoofy/internal/memory"
+)
+
+// FileName is the user configuration filename inside the data dir.
+const FileName = "config.json"
+
+// Config is the root of ~/.hoofy/config.json.
+type Config struct {
+	Memory MemoryConfig `json:"memory"`
+	Docs   DocsConfig   `json:"docs"`
+	Tools  ToolsConfig  `json:"tools"`
+	Server ServerConfig `json:"server"`
+}
+
+// MemoryConfig mirrors memory.Config with JSON-friendly types.
+type MemoryConfig struct {
+	// Disabled turns off the memory subsystem entirely (no mem_* tools).
+	Disabled             bool     `json:"disabled,omitempty"`
+	DataDir              string   `json:"data_dir,omitempty"`
+	MaxObservationLength int      `json:"max_observation_length,omitempty"`
+	MaxContextResults    int      `json:"max_context_results,omitempty"`
+	MaxSearchResults     int      `json:"max_search_results,omitempty"`
+	DedupeWindow         Duration `json:"dedupe_window,omitempty"`
+}
+
+// DocsConfig holds artifact location preferences.
+type DocsConfig struct {
+	// Dir is where NEW projects are initialized: "docs" or "docs/specs".
+	// Existing projects are always detected from disk.
+	Dir string `json:"dir,omitempty"`
+}
+
+// ToolsConfig controls the MCP tool surface.
+type ToolsConfig struct {
+	// Disabled lists tool names that should not be registered
+	// (e.g. "sdd_audit", "mem_compact").
+	Disabled []string `json:"disabled,omitempty"`
+}
+
+// ServerConfig holds defaults for "hoofy serve" flags.
+type ServerConfig struct {
+	Transport       string   `json:"transport,omitempty"`
+	Addr            string   `json:"addr,omitempty"`
+	Path            string   `json:"path,omitempty"`
+	Token           string   `json:"token,omitempty"`
+	ShutdownTimeout Duration `json:"shutdown_timeout,omitempty"`
+}
+
+// Duration is a time.Duration that marshals as a Go duration string
+// ("15m", "90s") so config files stay human-editable.
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON accepts either a duration string or a number of seconds.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		*d = Duration(v)
+		return nil
+	}
+	var secs float64
+	if err := json.Unmarshal(b, &secs); err != nil {
+		return fmt.Errorf("duration must be a string like \"15m\" or a number of seconds")
+	}
+	*d = Duration(time.Duration(secs * float64(time.Second)))
+	return nil
+}
+
+// Default returns the built-in configuration, matching memory.DefaultConfig.
+func Default() Config {
+	mem := memory.DefaultConfig()
+	return Config{
+		Memory: MemoryConfig{
+			DataDir:              mem.DataDir,
+			MaxObservationLength: mem.MaxObservationLength,
+			MaxContextResults:    mem.MaxContextResults,
+			MaxSearchResults:     mem.MaxSearchResults,
+			DedupeWindow:         Duration(mem.DedupeWindow),
+		},
+		Docs: DocsConfig{Dir: config.DocsDir},
+	}
+}
+
+// DefaultPath returns the config file location: $HOOFY_CONFIG if set,
+// otherwise ~/.hoofy/config.json.
+func DefaultPath() string {
+	if p := os.Getenv("HOOFY_CONFIG"); p != "" {
+		return p
+	}
+	return filepath.Join(memory.DefaultConfig().DataDir, FileName)
+}
+
+// Load resolves the configuration from defaults, the file at path
+// (empty means DefaultPath), and HOOFY_* environment variables, then
+// validates it. A missing file is not an error unless the path was
+// given explicitly.
+func Load(path string) (Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath()
+	}
+
+	cfg := Default()
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	case errors.Is(err, os.ErrNotExist) && !explicit:
+		// No user config — defaults apply.
+	default:
+		return Config{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if err := cfg.applyEnv(os.Getenv); err != nil {
+		return Config{}, err
+	}
+	cfg.Memory.DataDir = expandHome(cfg.Memory.DataDir)
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration (%s): %w", path, err)
+	}
+	return cfg, nil
+}
+
+// applyEnv overlays HOOFY_* environment variables.
+func (c *Config) applyEnv(getenv func(string) string) error {
+	setString := func(key string, dst *string) {
+		if v := getenv(key); v != "" {
+			*dst = v
+		}
+	}
+	setInt := func(key string, dst *int) error {
+		v := getenv(key)
+		if v == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not an integer", key, v)
+		}
+		*dst = n
+		return nil
+	}
+	setDuration := func(key string, dst *Duration) error {
+		v := getenv(key)
+		if v == "" {
+			return nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a duration", key, v)
+		}
+		*dst = Duration(d)
+		return nil
+	}
+	setBool := func(key string, dst *bool) error {
+		v := getenv(key)
+		if v == "" {
+			return nil
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a boolean", key, v)
+		}
+		*dst = b
+		return nil
+	}
+
+	if err := setBool("HOOFY_MEMORY_DISABLED", &c.Memory.Disabled); err != nil {
+		return err
+	}
+	setString("HOOFY_DATA_DIR", &c.Memory.DataDir)
+	if err := setInt("HOOFY_MAX_OBSERVATION_LENGTH", &c.Memory.MaxObservationLength); err != nil {
+		return err
+	}
+	if err := setInt("HOOFY_MAX_CONTEXT_RESULTS", &c.Memory.MaxContextResults); err != nil {
+		return err
+	}
+	if err := setInt("HOOFY_MAX_SEARCH_RESULTS", &c.Memory.MaxSearchResults); err != nil {
+		return err
+	}
+	if err := setDuration("HOOFY_DEDUPE_WINDOW", &c.Memory.DedupeWindow); err != nil {
+		return err
+	}
+	setString("HOOFY_DOCS_DIR", &c.Docs.Dir)
+	if v := getenv("HOOFY_DISABLED_TOOLS"); v != "" {
+		c.Tools.Disabled = splitList(v)
+	}
+	setString("HOOFY_TRANSPORT", &c.Server.Transport)
+	setString("HOOFY_ADDR", &c.Server.Addr)
+	setString("HOOFY_TOKEN", &c.Server.Token)
+	return setDuration("HOOFY_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+}
+
+// Validate reports every invalid setting at once so users can fix the
+// file in one pass.
+func (c Config) Validate() error {
+	var errs []error
+
+	if strings.TrimSpace(c.Memory.DataDir) == "" {
+		errs = append(errs, errors.New("memory.data_dir must not be empty"))
+	}
+	if c.Memory.MaxObservationLength < 100 {
+		errs = append(errs, fmt.Errorf("memory.max_observation_length must be at least 100, got %d", c.Memory.MaxObservationLength))
+	}
+	if c.Memory.MaxContextResults < 1 {
+		errs = append(errs, fmt.Errorf("memory.max_context_results must be positive, got %d", c.Memory.MaxContextResults))
+	}
+	if c.Memory.MaxSearchResults < 1 {
+		errs = append(errs, fmt.Errorf("memory.max_search_results must be positive, got %d", c.Memory.MaxSearchResults))
+	}
+	if c.Memory.DedupeWindow < Duration(time.Minute) {
+		errs = append(errs, fmt.Errorf("memory.dedupe_window must be at least 1m, got %s", time.Duration(c.Memory.DedupeWindow)))
+	}
+
+	switch filepath.ToSlash(filepath.Clean(c.Docs.Dir)) {
+	case config.DocsDir, config.DocsDir + "/" + config.DocsDirFallback:
+	default:
+		errs = append(errs, fmt.Errorf("docs.dir must be %q or %q, got %q", config.DocsDir, config.DocsDir+"/"+config.DocsDirFallback, c.Docs.Dir))
+	}
+
+	for _, name := range c.Tools.Disabled {
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, errors.New("tools.disabled must not contain empty names"))
+			break
+		}
+	}
+
+	switch strings.ToLower(c.Server.Transport) {
+	case "", "stdio", "http", "sse":
+	default:
+		errs = append(errs, fmt.Errorf("server.transport must be stdio, http, or sse, got %q", c.Server.Transport))
+	}
+	if c.Server.Path != "" && !strings.HasPrefix(c.Server.Path, "/") {
+		errs = append(errs, fmt.Errorf("server.path must start with '/', got %q", c.Server.Path))
+	}
+	if c.Server.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must not be negative"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// MemoryStoreConfig converts the settings into a memory.Config.
+func (c Config) MemoryStoreConfig() memory.Config {
+	return memory.Config{
+		DataDir:              c.Memory.DataDir,
+		MaxObservationLength: c.Memory.MaxObservationLength,
+		MaxContextResults:    c.Memory.MaxContextResults,
+		MaxSearchResults:     c.Memory.MaxSearchResults,
+		DedupeWindow:         time.Duration(c.Memory.DedupeWindow),
+	}
+}
+
+// ToolDisabled reports whether a tool is listed in tools.disabled.
+func (c Config) ToolDisabled(name string) bool {
+	for _, d := range c.Tools.Disabled {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+// expandHome resolves a leading "~/" so config files can stay portable.
+func expandHome(p string) string {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, strings.TrimPrefix(p, "~"))
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+package userconfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv blanks every HOOFY_* variable Load reads so the host
+// environment can't leak into tests.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"HOOFY_CONFIG", "HOOFY_MEMORY_DISABLED", "HOOFY_DATA_DIR",
+		"HOOFY_MAX_OBSERVATION_LENGTH", "HOOFY_MAX_CONTEXT_RESULTS",
+		"HOOFY_MAX_SEARCH_RESULTS", "HOOFY_DEDUPE_WINDOW", "HOOFY_DOCS_DIR",
+		"HOOFY_DISABLED_TOOLS", "HOOFY_TRANSPORT", "HOOFY_ADDR",
+		"HOOFY_TOKEN", "HOOFY_SHUTDOWN_TIMEOUT",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefault_IsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("Default().Validate() = %v", err)
+	}
+}
+
+func TestLoad_MissingDefaultFileUsesDefaults(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("HOOFY_CONFIG", filepath.Join(t.TempDir(), "nope.json"))
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Memory.MaxObservationLength != Default().Memory.MaxObservationLength {
+		t.Errorf("MaxObservationLength = %d, want default", cfg.Memory.MaxObservationLength)
+	}
+}
+
+func TestLoad_MissingExplicitFileFails(t *testing.T) {
+	clearEnv(t)
+	if _, err := Load(filepath.Join(t.TempDir(), "nope.json")); err == nil {
+		t.Fatal("expected error for explicit missing config file")
+	}
+}
+
+func TestLoad_FileOverridesDefaults(t *testing.T) {
+	clearEnv(t)
+	dataDir := t.TempDir()
+	path := writeConfig(t, `{
+		"memory": {"data_dir": `+quote(dataDir)+`, "max_observation_length": 5000, "dedupe_window": "1h"},
+		"docs": {"dir": "docs/specs"},
+		"tools": {"disabled": ["sdd_audit"]},
+		"server": {"transport": "http", "addr": ":9000", "shutdown_timeout": 30}
+	}`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Memory.DataDir != dataDir {
+		t.Errorf("DataDir = %q, want %q", cfg.Memory.DataDir, dataDir)
+	}
+	if cfg.Memory.MaxObservationLength != 5000 {
+		t.Errorf("MaxObservationLength = %d, want 5000", cfg.Memory.MaxObservationLength)
+	}
+	if cfg.Memory.MaxSearchResults != Default().Memory.MaxSearchResults {
+		t.Errorf("unset MaxSearchResults should keep default, got %d", cfg.Memory.MaxSearchResults)
+	}
+	if time.Duration(cfg.Memory.DedupeWindow) != time.Hour {
+		t.Errorf("DedupeWindow = %s, want 1h", time.Duration(cfg.Memory.DedupeWindow))
+	}
+	if cfg.Docs.Dir != "docs/specs" {
+		t.Errorf("Docs.Dir = %q, want docs/specs", cfg.Docs.Dir)
+	}
+	if !cfg.ToolDisabled("sdd_audit") || cfg.ToolDisabled("mem_save") {
+		t.Errorf("ToolDisabled mismatch: %v", cfg.Tools.Disabled)
+	}
+	if cfg.Server.Transport != "http" || cfg.Server.Addr != ":9000" {
+		t.Errorf("Server = %+v", cfg.Server)
+	}
+	if time.Duration(cfg.Server.ShutdownTimeout) != 30*time.Second {
+		t.Errorf("ShutdownTimeout = %s, want 30s", time.Duration(cfg.Server.ShutdownTimeout))
+	}
+
+	mc := cfg.MemoryStoreConfig()
+	if mc.DataDir != dataDir || mc.MaxObservationLength != 5000 || mc.DedupeWindow != time.Hour {
+		t.Errorf("MemoryStoreConfig = %+v", mc)
+	}
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, `{"memory": {"max_search_results": 5}, "server": {"token": "from-file"}}`)
+	t.Setenv("HOOFY_MAX_SEARCH_RESULTS", "50")
+	t.Setenv("HOOFY_TOKEN", "from-env")
+	t.Setenv("HOOFY_DISABLED_TOOLS", "mem_compact, sdd_audit ,")
+	t.Setenv("HOOFY_MEMORY_DISABLED", "true")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Memory.MaxSearchResults != 50 {
+		t.Errorf("MaxSearchResults = %d, want 50", cfg.Memory.MaxSearchResults)
+	}
+	if cfg.Server.Token != "from-env" {
+		t.Errorf("Token = %q, want from-env", cfg.Server.Token)
+	}
+	if len(cfg.Tools.Disabled) != 2 || !cfg.ToolDisabled("mem_compact") || !cfg.ToolDisabled("sdd_audit") {
+		t.Errorf("Tools.Disabled = %v", cfg.Tools.Disabled)
+	}
+	if !cfg.Memory.Disabled {
+		t.Error("Memory.Disabled should be true")
+	}
+}
+
+func TestLoad_ExpandsHomeInDataDir(t *testing.T) {
+	clearEnv(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg, err := Load(writeConfig(t, `{"memory": {"data_dir": "~/hoofy-data"}}`))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if want := filepath.Join(home, "hoofy-data"); cfg.Memory.DataDir != want {
+		t.Errorf("DataDir = %q, want %q", cfg.Memory.DataDir, want)
+	}
+}
+
+func TestLoad_BadEnvValue(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("HOOFY_CONFIG", filepath.Join(t.TempDir(), "nope.json"))
+	t.Setenv("HOOFY_MAX_CONTEXT_RESULTS", "lots")
+
+	_, err := Load("")
+	if err == nil || !strings.Contains(err.Error(), "HOOFY_MAX_CONTEXT_RESULTS") {
+		t.Fatalf("expected HOOFY_MAX_CONTEXT_RESULTS error, got %v", err)
+	}
+}
+
+func TestLoad_InvalidJSON(t *testing.T) {
+	clearEnv(t)
+	if _, err := Load(writeConfig(t, `{"memory": `)); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	cfg := Default()
+	cfg.Memory.MaxObservationLength = 10
+	cfg.Memory.MaxSearchResults = 0
+	cfg.Docs.Dir = "artifacts"
+	cfg.Server.Transport = "websocket"
+	cfg.Server.Path = "mcp"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		"memory.max_observation_length",
+		"memory.max_search_results",
+		"docs.dir",
+		"server.transport",
+		"server.path",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestDuration_JSONRoundTrip(t *testing.T) {
+	b, err := json.Marshal(Duration(90 * time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"1m30s"` {
+		t.Errorf("Marshal = %s, want \"1m30s\"", b)
+	}
+
+	var d Duration
+	if err := json.Unmarshal(b, &d); err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(d) != 90*time.Second {
+		t.Errorf("Unmarshal = %s, want 1m30s", time.Duration(d))
+	}
+
+	if err := json.Unmarshal([]byte(`"soon"`), &d); err == nil {
+		t.Error("expected error for invalid duration string")
+	}
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}